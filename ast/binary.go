@@ -0,0 +1,342 @@
+package ast
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/t14raptor/go-fast/parser/scanner/token"
+)
+
+// binaryMagic starts every encoded program.
+const binaryMagic = "\x00gfast"
+
+// binaryVersion is bumped whenever the encoding of values changes. Changes to
+// the node types themselves are caught by binarySchema, which gen_codec.go
+// derives from their definitions.
+const binaryVersion = 1
+
+// binaryMaxDepth bounds how many nested nodes the codec follows, so corrupt
+// input cannot exhaust the stack.
+const binaryMaxDepth = 100000
+
+var (
+	// ErrBinaryVersion is reported when decoding data written by a build
+	// with a different encoding or different node types.
+	ErrBinaryVersion = errors.New("ast: binary AST has an incompatible version")
+	// ErrBinaryCorrupt is reported when decoding data that is not a binary
+	// AST or has been truncated or damaged.
+	ErrBinaryCorrupt = errors.New("ast: corrupt binary AST")
+)
+
+// MarshalBinary encodes the program, including positions and scope
+// contexts, in a binary form read back by UnmarshalBinary. Programs with
+// function bodies still skipped by lazy parsing cannot be encoded.
+func (p *Program) MarshalBinary() (data []byte, err error) {
+	w := &binaryWriter{strings: make(map[string]uint64)}
+	w.buf = append(w.buf, binaryMagic...)
+	w.uvarint(binaryVersion)
+	w.buf = binary.LittleEndian.AppendUint64(w.buf, binarySchema)
+
+	defer func() {
+		switch e := recover().(type) {
+		case nil:
+		case binaryError:
+			data, err = nil, e.err
+		default:
+			panic(e)
+		}
+	}()
+	w.writeProgram(p)
+	return w.buf, nil
+}
+
+// UnmarshalBinary replaces the program with the one encoded in data by
+// MarshalBinary. It fails with ErrBinaryVersion if data was written by an
+// incompatible build, and with ErrBinaryCorrupt if it is malformed.
+func (p *Program) UnmarshalBinary(data []byte) (err error) {
+	rest, ok := bytesCut(data, binaryMagic)
+	if !ok {
+		return fmt.Errorf("%w: missing header", ErrBinaryCorrupt)
+	}
+	r := &binaryReader{data: rest}
+
+	defer func() {
+		switch e := recover().(type) {
+		case nil:
+		case binaryError:
+			err = e.err
+		default:
+			panic(e)
+		}
+	}()
+	if version := r.uvarint(); version != binaryVersion {
+		return fmt.Errorf("%w: format version %d, want %d", ErrBinaryVersion, version, binaryVersion)
+	}
+	if schema := binary.LittleEndian.Uint64(r.next(8)); schema != binarySchema {
+		return fmt.Errorf("%w: schema %016x, want %016x", ErrBinaryVersion, schema, uint64(binarySchema))
+	}
+
+	var program Program
+	r.readProgram(&program)
+	if n := r.remaining(); n != 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrBinaryCorrupt, n)
+	}
+	*p = program
+	return nil
+}
+
+func bytesCut(data []byte, prefix string) ([]byte, bool) {
+	if len(data) < len(prefix) || string(data[:len(prefix)]) != prefix {
+		return nil, false
+	}
+	return data[len(prefix):], true
+}
+
+// binaryError carries an error out of the codec's recursion.
+type binaryError struct{ err error }
+
+// binaryWriter appends the encoding of nodes to buf. Strings are written
+// once and referred to by index afterwards, and positions as the difference
+// from the previous one, which nearly always fits in a byte.
+type binaryWriter struct {
+	buf     []byte
+	strings map[string]uint64
+	last    Idx
+	depth   int
+}
+
+func (w *binaryWriter) fail(err error) { panic(binaryError{err}) }
+
+func (w *binaryWriter) enter() {
+	if w.depth++; w.depth > binaryMaxDepth {
+		w.fail(errors.New("ast: tree too deep to encode"))
+	}
+}
+
+func (w *binaryWriter) unknown(iface string, n any) {
+	w.fail(fmt.Errorf("ast: cannot encode %T as %s", n, iface))
+}
+
+func (w *binaryWriter) u8(v uint8)           { w.buf = append(w.buf, v) }
+func (w *binaryWriter) uvarint(v uint64)     { w.buf = binary.AppendUvarint(w.buf, v) }
+func (w *binaryWriter) int(v int)            { w.buf = binary.AppendVarint(w.buf, int64(v)) }
+func (w *binaryWriter) scope(v ScopeContext) { w.buf = binary.AppendVarint(w.buf, int64(v)) }
+func (w *binaryWriter) token(v token.Token)  { w.u8(uint8(v)) }
+
+func (w *binaryWriter) idx(v Idx) {
+	w.buf = binary.AppendVarint(w.buf, int64(v)-int64(w.last))
+	w.last = v
+}
+
+func (w *binaryWriter) bool(v bool) {
+	if v {
+		w.u8(1)
+	} else {
+		w.u8(0)
+	}
+}
+
+// present writes whether an optional value follows and returns ok.
+func (w *binaryWriter) present(ok bool) bool {
+	w.bool(ok)
+	return ok
+}
+
+func (w *binaryWriter) float(v float64) {
+	w.buf = binary.LittleEndian.AppendUint64(w.buf, math.Float64bits(v))
+}
+
+func (w *binaryWriter) str(s string) {
+	if i, ok := w.strings[s]; ok {
+		w.uvarint(i + 1)
+		return
+	}
+	w.strings[s] = uint64(len(w.strings))
+	w.uvarint(0)
+	w.uvarint(uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+func (w *binaryWriter) strPtr(s *string) {
+	if w.present(s != nil) {
+		w.str(*s)
+	}
+}
+
+func (w *binaryWriter) bigInt(v *big.Int) {
+	if w.present(v != nil) {
+		w.str(v.Text(16))
+	}
+}
+
+func (w *binaryWriter) lazyBody(v *LazyBody) {
+	if v != nil {
+		w.fail(errors.New("ast: cannot encode a lazily parsed function body; call ParseBody first"))
+	}
+}
+
+// binaryReader decodes nodes from data, starting at off. Nodes are
+// allocated in bulk from nodes.
+type binaryReader struct {
+	data    []byte
+	off     int
+	strings []string
+	last    Idx
+	depth   int
+	nodes   binaryNodes
+}
+
+func (r *binaryReader) corrupt() { panic(binaryError{ErrBinaryCorrupt}) }
+
+func (r *binaryReader) enter() {
+	if r.depth++; r.depth > binaryMaxDepth {
+		r.corrupt()
+	}
+}
+
+func (r *binaryReader) remaining() int { return len(r.data) - r.off }
+
+// next consumes n bytes.
+func (r *binaryReader) next(n int) []byte {
+	if n > r.remaining() {
+		r.corrupt()
+	}
+	b := r.data[r.off : r.off+n]
+	r.off += n
+	return b
+}
+
+func (r *binaryReader) u8() uint8 {
+	if r.off >= len(r.data) {
+		r.corrupt()
+	}
+	v := r.data[r.off]
+	r.off++
+	return v
+}
+
+func (r *binaryReader) uvarint() uint64 {
+	if r.off < len(r.data) && r.data[r.off] < 0x80 {
+		v := r.data[r.off]
+		r.off++
+		return uint64(v)
+	}
+	v, n := binary.Uvarint(r.data[r.off:])
+	if n <= 0 {
+		r.corrupt()
+	}
+	r.off += n
+	return v
+}
+
+func (r *binaryReader) varint() int64 {
+	u := r.uvarint()
+	v := int64(u >> 1)
+	if u&1 != 0 {
+		v = ^v
+	}
+	return v
+}
+
+// len reads the length of a list. Every element takes at least one byte,
+// which bounds the allocation for corrupt lengths.
+func (r *binaryReader) len() int {
+	n := r.uvarint()
+	if n > uint64(r.remaining()) {
+		r.corrupt()
+	}
+	return int(n)
+}
+
+func (r *binaryReader) idx() Idx {
+	v := int64(r.last) + r.varint()
+	if v < 0 || v > math.MaxUint32 {
+		r.corrupt()
+	}
+	r.last = Idx(v)
+	return r.last
+}
+
+func (r *binaryReader) int() int            { return int(r.varint()) }
+func (r *binaryReader) token() token.Token  { return token.Token(r.u8()) }
+func (r *binaryReader) present() bool       { return r.bool() }
+func (r *binaryReader) lazyBody() *LazyBody { return nil }
+
+func (r *binaryReader) scope() ScopeContext {
+	v := r.varint()
+	if v < math.MinInt32 || v > math.MaxInt32 {
+		r.corrupt()
+	}
+	return ScopeContext(v)
+}
+
+func (r *binaryReader) bool() bool {
+	switch r.u8() {
+	case 0:
+		return false
+	case 1:
+		return true
+	}
+	r.corrupt()
+	return false
+}
+
+func (r *binaryReader) float() float64 {
+	return math.Float64frombits(binary.LittleEndian.Uint64(r.next(8)))
+}
+
+func (r *binaryReader) str() string {
+	i := r.uvarint()
+	if i != 0 {
+		if i > uint64(len(r.strings)) {
+			r.corrupt()
+		}
+		return r.strings[i-1]
+	}
+	n := r.uvarint()
+	if n > uint64(r.remaining()) {
+		r.corrupt()
+	}
+	s := string(r.next(int(n)))
+	r.strings = append(r.strings, s)
+	return s
+}
+
+func (r *binaryReader) strPtr() *string {
+	if !r.present() {
+		return nil
+	}
+	s := r.str()
+	return &s
+}
+
+func (r *binaryReader) bigInt() *big.Int {
+	if !r.present() {
+		return nil
+	}
+	v, ok := new(big.Int).SetString(r.str(), 16)
+	if !ok {
+		r.corrupt()
+	}
+	return v
+}
+
+// slab hands out pointers into shared chunks of T, so that decoding does not
+// allocate every node separately.
+type slab[T any] []T
+
+func (s *slab[T]) new() *T {
+	return &s.make(1)[0]
+}
+
+func (s *slab[T]) make(n int) []T {
+	if len(*s)+n > cap(*s) {
+		*s = make([]T, 0, max(n, 16, cap(*s)+cap(*s)>>1))
+	}
+	l := len(*s)
+	*s = (*s)[:l+n]
+	return (*s)[l : l+n : l+n]
+}
@@ -0,0 +1,137 @@
+package ast_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/generator"
+	"github.com/t14raptor/go-fast/parser"
+	"github.com/t14raptor/go-fast/resolver"
+)
+
+// identifiers lists the name, scope context and position of every
+// identifier of p.
+func identifiers(p *ast.Program) []string {
+	var list []string
+	ast.Inspect(p, func(n ast.VisitableNode) bool {
+		if id, ok := n.(*ast.Identifier); ok {
+			list = append(list, fmt.Sprintf("%s#%d@%d", id.Name, id.ScopeContext, id.Idx))
+		}
+		return true
+	})
+	return list
+}
+
+// binarySources cover every kind of node the codec encodes.
+var binarySources = []string{
+	"var a = 1, b = 'two', c = 3.5e-7, d = 10n, e = /x/g, f = `t${a}u`;",
+	"function f(x, {y, z = 1}, [w], ...r) { 'use strict'; return new.target ?? x?.[y]?.(z); }",
+	"class A extends B { #p = 1; static { this.#p; } get [k]() { return super.x; } }",
+	"label: for (let i = 0; i < 10; i++) { if (i) continue label; else break; }",
+	"switch (a) { case 1: a++; default: --a; } try { throw a } catch ({e}) {} finally {}",
+	"async function* g() { for await (const x of y) yield* x; } o = {a, b: 1, ...c, m() {}};",
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	for _, src := range binarySources {
+		program := parse(t, src)
+		resolver.Resolve(program)
+
+		data, err := program.MarshalBinary()
+		if err != nil {
+			t.Fatalf("marshal %q: %v", src, err)
+		}
+		var decoded ast.Program
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("unmarshal %q: %v", src, err)
+		}
+		if got, want := generator.Generate(&decoded), generator.Generate(program); got != want {
+			t.Errorf("%s: generated %q; want %q", src, got, want)
+		}
+		if got, want := identifiers(&decoded), identifiers(program); !slices.Equal(got, want) {
+			t.Errorf("%s: identifiers %v; want %v", src, got, want)
+		}
+	}
+}
+
+func TestBinaryErrors(t *testing.T) {
+	data, err := parse(t, "a + b").MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The format version follows the six byte magic.
+	stale := slices.Clone(data)
+	stale[6]++
+	var p ast.Program
+	if err := p.UnmarshalBinary(stale); !errors.Is(err, ast.ErrBinaryVersion) {
+		t.Errorf("stale version: err = %v; want ErrBinaryVersion", err)
+	}
+	for n := range len(data) {
+		if err := p.UnmarshalBinary(data[:n]); !errors.Is(err, ast.ErrBinaryCorrupt) {
+			t.Errorf("truncated to %d bytes: err = %v; want ErrBinaryCorrupt", n, err)
+		}
+	}
+
+	lazy, err := parser.ParseFileWithOptions("function f() {}", parser.Options{LazyFunctions: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lazy.MarshalBinary(); err == nil {
+		t.Error("lazy function: expected an error")
+	}
+}
+
+// benchmarkProgram returns a resolved program of about 40KB of source.
+func benchmarkProgram(b *testing.B) (string, *ast.Program) {
+	src := strings.Repeat(strings.Join(binarySources, "\n")+"\n", 80)
+	program, err := parser.ParseFile(src)
+	if err != nil {
+		b.Fatal(err)
+	}
+	resolver.Resolve(program)
+	return src, program
+}
+
+// BenchmarkParse is the baseline for BenchmarkUnmarshal: parsing the source
+// again instead of decoding it.
+func BenchmarkParse(b *testing.B) {
+	src, _ := benchmarkProgram(b)
+	b.SetBytes(int64(len(src)))
+	for b.Loop() {
+		if _, err := parser.ParseFile(src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	src, program := benchmarkProgram(b)
+	b.SetBytes(int64(len(src)))
+	for b.Loop() {
+		data, err := program.MarshalBinary()
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ReportMetric(float64(len(data))/float64(len(src)), "size/src")
+	}
+}
+
+func BenchmarkUnmarshal(b *testing.B) {
+	src, program := benchmarkProgram(b)
+	data, err := program.MarshalBinary()
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(src)))
+	for b.Loop() {
+		var p ast.Program
+		if err := p.UnmarshalBinary(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
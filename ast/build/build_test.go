@@ -0,0 +1,62 @@
+package build_test
+
+import (
+	"testing"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/ast/build"
+	"github.com/t14raptor/go-fast/generator"
+	"github.com/t14raptor/go-fast/parser"
+	"github.com/t14raptor/go-fast/parser/scanner/token"
+)
+
+func TestBuild(t *testing.T) {
+	b := build.Ident("b")
+	tests := []struct {
+		node ast.Stmt
+		src  string
+	}{
+		{build.ExprStmt(build.Call(build.Member(build.Ident("console"), "log"), build.Str("x"), build.Num(1))), `console.log("x", 1);`},
+		{build.ExprStmt(build.Call(
+			build.Member(build.Ident("Object"), "defineProperty"),
+			build.This(), build.Str("k"), build.Object(build.Prop("value", build.Null()), build.ComputedProp(b, build.Bool(true))),
+		)), `Object.defineProperty(this, "k", {value: null, [b]: true});`},
+		{build.ExprStmt(build.Assign(build.Index(build.Ident("a"), build.Num(0)), build.Num(-2))), `a[0] = -2;`},
+		{build.ExprStmt(build.Cond(build.Not(b), build.Array(b, nil, build.Spread(b)), build.Undefined())), `!b ? [b, , ...b] : void 0;`},
+		{build.ExprStmt(build.Seq(
+			build.Binary(ast.BinaryAddition, b, build.Num(1)),
+			build.Logical(ast.LogicalAnd, b, build.New(build.Ident("C"))),
+			build.AssignOp(ast.AssignmentAddition, b, build.Num(2)),
+		)), `b + 1, b && new C(), b += 2;`},
+		{build.Var("x", nil), `var x;`},
+		{build.Let("x", build.Arrow(build.Params("a", "b"), build.Ident("a"))), `let x = (a, b) => a;`},
+		{build.Const("f", build.ArrowBlock(nil, build.Return(nil))), `const f = () => { return; };`},
+		{build.Declare(token.Let,
+			build.Declarator(build.Ident("a"), build.Num(1)),
+			build.Declarator(build.Ident("b"), nil),
+		), `let a = 1, b;`},
+		{build.FunctionDecl("f", build.Params("x"),
+			build.If(build.Ident("x"), build.Throw(build.Ident("x")), build.Block(build.Return(b))),
+		), `function f(x) { if (x) throw x; else { return b; } }`},
+		{build.ExprStmt(func() ast.Expr {
+			f := build.Arrow(nil, build.Await(b))
+			f.Async = true
+			return f
+		}()), `async () => await b;`},
+		{build.While(build.Bool(true), build.Block(build.Break(""), build.Continue(""))), `while (true) { break; continue; }`},
+		{build.For(build.Let("i", build.Num(0)), build.Binary(ast.BinaryLessThan, build.Ident("i"), build.Num(3)), nil, build.Block()), `for (let i = 0; i < 3;) {}`},
+		{build.For(nil, nil, nil, build.ExprStmt(b)), `for (;;) b;`},
+		{build.ForOf(build.Const("v", nil), b, build.ExprStmt(build.Ident("v"))), `for (const v of b) v;`},
+		{build.ForIn(build.Expression(build.Ident("k")), b, build.Block()), `for (k in b) {}`},
+	}
+	for _, tt := range tests {
+		program, err := parser.ParseFile(tt.src)
+		if err != nil {
+			t.Fatalf("parse %q: %v", tt.src, err)
+		}
+		built := build.Program(tt.node)
+		if !ast.Equal(built, program, ast.EqualOptions{}) {
+			t.Errorf("built %q; want %q", generator.GenerateMinified(built), tt.src)
+		}
+	}
+}
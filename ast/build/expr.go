@@ -0,0 +1,244 @@
+// Package build constructs syntax trees without spelling out the wrappers
+// around every node:
+//
+//	build.Call(build.Member(build.Ident("console"), "log"), build.Str("hi"))
+//
+// Builders take and return nodes, wrapping them in Expression, Statement
+// and the other wrappers as the tree requires. All positions are zero and
+// identifiers have no scope context; run the resolver on the result before
+// relying on either.
+package build
+
+import (
+	"math"
+
+	"github.com/t14raptor/go-fast/ast"
+)
+
+// Expression wraps e. The wrapper also serves as a ForLoopInit, Into or
+// arrow function Body.
+func Expression(e ast.Expr) *ast.Expression {
+	return &ast.Expression{Expr: e}
+}
+
+// optional wraps e for an optional field, which stays nil if e is.
+func optional(e ast.Expr) *ast.Expression {
+	if e == nil {
+		return nil
+	}
+	return Expression(e)
+}
+
+// Expressions wraps each of es. A nil element becomes an array hole.
+func Expressions(es ...ast.Expr) ast.Expressions {
+	list := make(ast.Expressions, len(es))
+	for i, e := range es {
+		list[i].Expr = e
+	}
+	return list
+}
+
+// Ident returns a reference to name.
+func Ident(name string) *ast.Identifier {
+	return &ast.Identifier{Name: name}
+}
+
+// Str returns a string literal.
+func Str(s string) *ast.StringLiteral {
+	return &ast.StringLiteral{Value: s}
+}
+
+// Num returns a number literal. Negative numbers become a unary minus
+// applied to their absolute value, as the parser produces them, and NaN a
+// reference to NaN.
+func Num(v float64) ast.Expr {
+	switch {
+	case math.IsNaN(v):
+		return Ident("NaN")
+	case math.Signbit(v):
+		return Unary(ast.UnaryNegation, &ast.NumberLiteral{Value: -v})
+	}
+	return &ast.NumberLiteral{Value: v}
+}
+
+// Bool returns true or false.
+func Bool(v bool) *ast.BooleanLiteral {
+	return &ast.BooleanLiteral{Value: v}
+}
+
+// Null returns null.
+func Null() *ast.NullLiteral {
+	return &ast.NullLiteral{}
+}
+
+// Undefined returns void 0, which cannot be shadowed like undefined.
+func Undefined() *ast.UnaryExpression {
+	return Unary(ast.UnaryVoid, &ast.NumberLiteral{Value: 0})
+}
+
+// This returns this.
+func This() *ast.ThisExpression {
+	return &ast.ThisExpression{}
+}
+
+// Array returns an array literal. A nil element becomes a hole.
+func Array(elems ...ast.Expr) *ast.ArrayLiteral {
+	return &ast.ArrayLiteral{Value: Expressions(elems...)}
+}
+
+// Object returns an object literal with props, as made by Prop,
+// ComputedProp and Spread.
+func Object(props ...ast.Prop) *ast.ObjectLiteral {
+	list := make(ast.Properties, len(props))
+	for i, p := range props {
+		list[i].Prop = p
+	}
+	return &ast.ObjectLiteral{Value: list}
+}
+
+// Prop returns the property key: value.
+func Prop(key string, value ast.Expr) *ast.PropertyKeyed {
+	return &ast.PropertyKeyed{
+		Key:   Expression(Str(key)),
+		Kind:  ast.PropertyKindValue,
+		Value: Expression(value),
+	}
+}
+
+// ComputedProp returns the property [key]: value.
+func ComputedProp(key, value ast.Expr) *ast.PropertyKeyed {
+	return &ast.PropertyKeyed{
+		Key:      Expression(key),
+		Kind:     ast.PropertyKindValue,
+		Value:    Expression(value),
+		Computed: true,
+	}
+}
+
+// Spread returns ...e, for use in array literals, calls and object literals.
+func Spread(e ast.Expr) *ast.SpreadElement {
+	return &ast.SpreadElement{Expression: Expression(e)}
+}
+
+// Member returns obj.prop.
+func Member(obj ast.Expr, prop string) *ast.MemberExpression {
+	return &ast.MemberExpression{
+		Object:   Expression(obj),
+		Property: &ast.MemberProperty{Prop: Ident(prop)},
+	}
+}
+
+// Index returns obj[prop].
+func Index(obj, prop ast.Expr) *ast.MemberExpression {
+	return &ast.MemberExpression{
+		Object:   Expression(obj),
+		Property: &ast.MemberProperty{Prop: &ast.ComputedProperty{Expr: Expression(prop)}},
+	}
+}
+
+// Call returns callee(args...).
+func Call(callee ast.Expr, args ...ast.Expr) *ast.CallExpression {
+	return &ast.CallExpression{Callee: Expression(callee), ArgumentList: Expressions(args...)}
+}
+
+// New returns new callee(args...).
+func New(callee ast.Expr, args ...ast.Expr) *ast.NewExpression {
+	return &ast.NewExpression{Callee: Expression(callee), ArgumentList: Expressions(args...)}
+}
+
+// Unary returns op x.
+func Unary(op ast.UnaryOperator, x ast.Expr) *ast.UnaryExpression {
+	return &ast.UnaryExpression{Operator: op, Operand: Expression(x)}
+}
+
+// Not returns !x.
+func Not(x ast.Expr) *ast.UnaryExpression {
+	return Unary(ast.UnaryLogicalNot, x)
+}
+
+// Binary returns left op right.
+func Binary(op ast.BinaryOperator, left, right ast.Expr) *ast.BinaryExpression {
+	return &ast.BinaryExpression{Operator: op, Left: Expression(left), Right: Expression(right)}
+}
+
+// Logical returns left op right for &&, || and ??.
+func Logical(op ast.LogicalOperator, left, right ast.Expr) *ast.LogicalExpression {
+	return &ast.LogicalExpression{Operator: op, Left: Expression(left), Right: Expression(right)}
+}
+
+// Assign returns target = value.
+func Assign(target, value ast.Expr) *ast.AssignExpression {
+	return AssignOp(ast.AssignmentAssign, target, value)
+}
+
+// AssignOp returns target op value, as in x += 1.
+func AssignOp(op ast.AssignmentOperator, target, value ast.Expr) *ast.AssignExpression {
+	return &ast.AssignExpression{Operator: op, Left: Expression(target), Right: Expression(value)}
+}
+
+// Cond returns test ? consequent : alternate.
+func Cond(test, consequent, alternate ast.Expr) *ast.ConditionalExpression {
+	return &ast.ConditionalExpression{
+		Test:       Expression(test),
+		Consequent: Expression(consequent),
+		Alternate:  Expression(alternate),
+	}
+}
+
+// Seq returns the sequence expression a, b, ...
+func Seq(exprs ...ast.Expr) *ast.SequenceExpression {
+	return &ast.SequenceExpression{Sequence: Expressions(exprs...)}
+}
+
+// Await returns await x.
+func Await(x ast.Expr) *ast.AwaitExpression {
+	return &ast.AwaitExpression{Argument: Expression(x)}
+}
+
+// Params returns identifiers naming function parameters, for Function and
+// Arrow.
+func Params(names ...string) []ast.Target {
+	params := make([]ast.Target, len(names))
+	for i, name := range names {
+		params[i] = Ident(name)
+	}
+	return params
+}
+
+func parameterList(params []ast.Target) *ast.ParameterList {
+	list := make(ast.VariableDeclarators, len(params))
+	for i, p := range params {
+		list[i].Target = &ast.BindingTarget{Target: p}
+	}
+	return &ast.ParameterList{List: list}
+}
+
+// Function returns a function expression. An empty name makes it
+// anonymous.
+func Function(name string, params []ast.Target, body ...ast.Stmt) *ast.FunctionLiteral {
+	f := &ast.FunctionLiteral{
+		ParameterList: parameterList(params),
+		Body:          Block(body...),
+	}
+	if name != "" {
+		f.Name = Ident(name)
+	}
+	return f
+}
+
+// Arrow returns an arrow function with an expression body: (params) => body.
+func Arrow(params []ast.Target, body ast.Expr) *ast.ArrowFunctionLiteral {
+	return &ast.ArrowFunctionLiteral{
+		ParameterList: parameterList(params),
+		Body:          &ast.ConciseBody{Body: Expression(body)},
+	}
+}
+
+// ArrowBlock returns an arrow function with a block body:
+// (params) => { body }.
+func ArrowBlock(params []ast.Target, body ...ast.Stmt) *ast.ArrowFunctionLiteral {
+	return &ast.ArrowFunctionLiteral{
+		ParameterList: parameterList(params),
+		Body:          &ast.ConciseBody{Body: Block(body...)},
+	}
+}
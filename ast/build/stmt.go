@@ -0,0 +1,138 @@
+package build
+
+import (
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/parser/scanner/token"
+)
+
+// Statement wraps s.
+func Statement(s ast.Stmt) *ast.Statement {
+	return &ast.Statement{Stmt: s}
+}
+
+// Statements wraps each of stmts.
+func Statements(stmts ...ast.Stmt) ast.Statements {
+	list := make(ast.Statements, len(stmts))
+	for i, s := range stmts {
+		list[i].Stmt = s
+	}
+	return list
+}
+
+// Program returns a program of stmts.
+func Program(stmts ...ast.Stmt) *ast.Program {
+	return &ast.Program{Body: Statements(stmts...)}
+}
+
+// Block returns { stmts }.
+func Block(stmts ...ast.Stmt) *ast.BlockStatement {
+	return &ast.BlockStatement{List: Statements(stmts...)}
+}
+
+// ExprStmt returns the statement e;.
+func ExprStmt(e ast.Expr) *ast.ExpressionStatement {
+	return &ast.ExpressionStatement{Expression: Expression(e)}
+}
+
+// If returns if (test) consequent else alternate. A nil alternate leaves
+// out the else branch.
+func If(test ast.Expr, consequent, alternate ast.Stmt) *ast.IfStatement {
+	n := &ast.IfStatement{Test: Expression(test), Consequent: Statement(consequent)}
+	if alternate != nil {
+		n.Alternate = Statement(alternate)
+	}
+	return n
+}
+
+// Return returns return x;, or a bare return for a nil x.
+func Return(x ast.Expr) *ast.ReturnStatement {
+	return &ast.ReturnStatement{Argument: optional(x)}
+}
+
+// Throw returns throw x;.
+func Throw(x ast.Expr) *ast.ThrowStatement {
+	return &ast.ThrowStatement{Argument: Expression(x)}
+}
+
+// Break returns break label;, or a bare break for an empty label.
+func Break(label string) *ast.BreakStatement {
+	n := &ast.BreakStatement{}
+	if label != "" {
+		n.Label = Ident(label)
+	}
+	return n
+}
+
+// Continue returns continue label;, or a bare continue for an empty label.
+func Continue(label string) *ast.ContinueStatement {
+	n := &ast.ContinueStatement{}
+	if label != "" {
+		n.Label = Ident(label)
+	}
+	return n
+}
+
+// Var returns var name = init;. A nil init leaves the variable
+// uninitialised.
+func Var(name string, init ast.Expr) *ast.VariableDeclaration {
+	return Declare(token.Var, Declarator(Ident(name), init))
+}
+
+// Let returns let name = init;. A nil init leaves the variable
+// uninitialised.
+func Let(name string, init ast.Expr) *ast.VariableDeclaration {
+	return Declare(token.Let, Declarator(Ident(name), init))
+}
+
+// Const returns const name = init;.
+func Const(name string, init ast.Expr) *ast.VariableDeclaration {
+	return Declare(token.Const, Declarator(Ident(name), init))
+}
+
+// Declare returns a declaration of kind tok, which is token.Var, token.Let
+// or token.Const, holding decls.
+func Declare(tok token.Token, decls ...*ast.VariableDeclarator) *ast.VariableDeclaration {
+	list := make(ast.VariableDeclarators, len(decls))
+	for i, d := range decls {
+		list[i] = *d
+	}
+	return &ast.VariableDeclaration{Token: tok, List: list}
+}
+
+// Declarator returns target = init for Declare. target is an identifier or
+// a pattern; a nil init leaves it uninitialised.
+func Declarator(target ast.Target, init ast.Expr) *ast.VariableDeclarator {
+	return &ast.VariableDeclarator{Target: &ast.BindingTarget{Target: target}, Initializer: optional(init)}
+}
+
+// FunctionDecl returns a function declaration.
+func FunctionDecl(name string, params []ast.Target, body ...ast.Stmt) *ast.FunctionDeclaration {
+	return &ast.FunctionDeclaration{Function: Function(name, params, body...)}
+}
+
+// While returns while (test) body.
+func While(test ast.Expr, body ast.Stmt) *ast.WhileStatement {
+	return &ast.WhileStatement{Test: Expression(test), Body: Statement(body)}
+}
+
+// For returns for (init; test; update) body. init is a declaration or an
+// Expression; nil init, test and update are left out.
+func For(init ast.ForLoopInit, test, update ast.Expr, body ast.Stmt) *ast.ForStatement {
+	n := &ast.ForStatement{Test: Expression(test), Update: Expression(update), Body: Statement(body)}
+	if init != nil {
+		n.Initializer = &ast.ForLoopInitializer{Initializer: init}
+	}
+	return n
+}
+
+// ForIn returns for (into in source) body. into is a declaration without
+// initialiser, such as Const("k", nil), or an Expression.
+func ForIn(into ast.Into, source ast.Expr, body ast.Stmt) *ast.ForInStatement {
+	return &ast.ForInStatement{Into: &ast.ForInto{Into: into}, Source: Expression(source), Body: Statement(body)}
+}
+
+// ForOf returns for (into of source) body. into is a declaration without
+// initialiser, such as Const("v", nil), or an Expression.
+func ForOf(into ast.Into, source ast.Expr, body ast.Stmt) *ast.ForOfStatement {
+	return &ast.ForOfStatement{Into: &ast.ForInto{Into: into}, Source: Expression(source), Body: Statement(body)}
+}
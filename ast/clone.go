@@ -62,7 +62,7 @@ func (n *CaseStatement) Clone() *CaseStatement {
 	if n.Test != nil {
 		test = n.Test.Clone()
 	}
-	return &CaseStatement{Test: test, Consequent: *n.Consequent.Clone(), Case: n.Case}
+	return &CaseStatement{Test: test, Consequent: *n.Consequent.Clone(), Case: n.Case, Colon: n.Colon}
 }
 func (n *CaseStatements) Clone() *CaseStatements {
 	ns := make(CaseStatements, len(*n))
@@ -115,7 +115,7 @@ func (n *ClassStaticBlock) Clone() *ClassStaticBlock {
 	return &ClassStaticBlock{Block: n.Block.Clone(), Static: n.Static}
 }
 func (n *ComputedProperty) Clone() *ComputedProperty {
-	return &ComputedProperty{Expr: n.Expr.Clone()}
+	return &ComputedProperty{Expr: n.Expr.Clone(), RightBracket: n.RightBracket}
 }
 func (n *ConciseBody) Clone() *ConciseBody {
 	var clonedBody Body
@@ -141,7 +141,7 @@ func (n *DebuggerStatement) Clone() *DebuggerStatement {
 	return &DebuggerStatement{Debugger: n.Debugger}
 }
 func (n *DoWhileStatement) Clone() *DoWhileStatement {
-	return &DoWhileStatement{Test: n.Test.Clone(), Body: n.Body.Clone(), Do: n.Do}
+	return &DoWhileStatement{Test: n.Test.Clone(), Body: n.Body.Clone(), Do: n.Do, RightParenthesis: n.RightParenthesis}
 }
 func (n *EmptyStatement) Clone() *EmptyStatement {
 	return &EmptyStatement{Semicolon: n.Semicolon}
@@ -288,7 +288,11 @@ func (n *FunctionLiteral) Clone() *FunctionLiteral {
 	if n.Name != nil {
 		name = n.Name.Clone()
 	}
-	return &FunctionLiteral{Name: name, ParameterList: n.ParameterList.Clone(), Body: n.Body.Clone(), ScopeContext: n.ScopeContext, Function: n.Function, Async: n.Async}
+	var lazy *LazyBody
+	if n.Lazy != nil {
+		lazy = n.Lazy.Clone()
+	}
+	return &FunctionLiteral{Name: name, ParameterList: n.ParameterList.Clone(), Body: n.Body.Clone(), ScopeContext: n.ScopeContext, Function: n.Function, Async: n.Async, Generator: n.Generator, Lazy: lazy}
 }
 func (n *Identifier) Clone() *Identifier {
 	return &Identifier{Name: n.Name, ScopeContext: n.ScopeContext, Idx: n.Idx}
@@ -323,7 +327,7 @@ func (n *MemberProperty) Clone() *MemberProperty {
 	return &MemberProperty{Prop: clonedMemberProp}
 }
 func (n *MetaProperty) Clone() *MetaProperty {
-	return &MetaProperty{Meta: n.Meta.Clone(), Idx: n.Idx}
+	return &MetaProperty{Meta: n.Meta.Clone(), Property: n.Property.Clone(), Idx: n.Idx}
 }
 func (n *MethodDefinition) Clone() *MethodDefinition {
 	return &MethodDefinition{Key: n.Key.Clone(), Kind: n.Kind, Body: n.Body.Clone(), Idx: n.Idx, Computed: n.Computed, Static: n.Static}
@@ -632,7 +636,7 @@ func (n *SuperExpression) Clone() *SuperExpression {
 	return &SuperExpression{Idx: n.Idx}
 }
 func (n *SwitchStatement) Clone() *SwitchStatement {
-	return &SwitchStatement{Discriminant: n.Discriminant.Clone(), Default: n.Default, Body: *n.Body.Clone(), Switch: n.Switch}
+	return &SwitchStatement{Discriminant: n.Discriminant.Clone(), Default: n.Default, Body: *n.Body.Clone(), Switch: n.Switch, RightBrace: n.RightBrace}
 }
 func (n *TemplateElement) Clone() *TemplateElement {
 	return &TemplateElement{Literal: n.Literal, Parsed: n.Parsed, Idx: n.Idx}
@@ -0,0 +1,2148 @@
+// Code generated by gen_codec.go; DO NOT EDIT.
+
+package ast
+
+const binarySchema = 0x080c88d7e2089e32
+
+type binaryNodes struct {
+	ArrayLiteral          slab[ArrayLiteral]
+	ArrayPattern          slab[ArrayPattern]
+	ArrowFunctionLiteral  slab[ArrowFunctionLiteral]
+	AssignExpression      slab[AssignExpression]
+	AwaitExpression       slab[AwaitExpression]
+	BadStatement          slab[BadStatement]
+	BigIntLiteral         slab[BigIntLiteral]
+	BinaryExpression      slab[BinaryExpression]
+	BindingTarget         slab[BindingTarget]
+	BlockStatement        slab[BlockStatement]
+	BooleanLiteral        slab[BooleanLiteral]
+	BreakStatement        slab[BreakStatement]
+	CallExpression        slab[CallExpression]
+	CaseStatement         slab[CaseStatement]
+	CatchStatement        slab[CatchStatement]
+	ClassDeclaration      slab[ClassDeclaration]
+	ClassElement          slab[ClassElement]
+	ClassLiteral          slab[ClassLiteral]
+	ClassStaticBlock      slab[ClassStaticBlock]
+	ComputedProperty      slab[ComputedProperty]
+	ConciseBody           slab[ConciseBody]
+	ConditionalExpression slab[ConditionalExpression]
+	ContinueStatement     slab[ContinueStatement]
+	DebuggerStatement     slab[DebuggerStatement]
+	DoWhileStatement      slab[DoWhileStatement]
+	EmptyStatement        slab[EmptyStatement]
+	Expression            slab[Expression]
+	ExpressionStatement   slab[ExpressionStatement]
+	FieldDefinition       slab[FieldDefinition]
+	ForInStatement        slab[ForInStatement]
+	ForInto               slab[ForInto]
+	ForLoopInitializer    slab[ForLoopInitializer]
+	ForOfStatement        slab[ForOfStatement]
+	ForStatement          slab[ForStatement]
+	FunctionDeclaration   slab[FunctionDeclaration]
+	FunctionLiteral       slab[FunctionLiteral]
+	Identifier            slab[Identifier]
+	IfStatement           slab[IfStatement]
+	InvalidExpression     slab[InvalidExpression]
+	LabelledStatement     slab[LabelledStatement]
+	LogicalExpression     slab[LogicalExpression]
+	MemberExpression      slab[MemberExpression]
+	MemberProperty        slab[MemberProperty]
+	MetaProperty          slab[MetaProperty]
+	MethodDefinition      slab[MethodDefinition]
+	NewExpression         slab[NewExpression]
+	NullLiteral           slab[NullLiteral]
+	NumberLiteral         slab[NumberLiteral]
+	ObjectLiteral         slab[ObjectLiteral]
+	ObjectPattern         slab[ObjectPattern]
+	Optional              slab[Optional]
+	OptionalChain         slab[OptionalChain]
+	ParameterList         slab[ParameterList]
+	PrivateDotExpression  slab[PrivateDotExpression]
+	PrivateIdentifier     slab[PrivateIdentifier]
+	Program               slab[Program]
+	Property              slab[Property]
+	PropertyKeyed         slab[PropertyKeyed]
+	PropertyShort         slab[PropertyShort]
+	RegExpLiteral         slab[RegExpLiteral]
+	ReturnStatement       slab[ReturnStatement]
+	SequenceExpression    slab[SequenceExpression]
+	SpreadElement         slab[SpreadElement]
+	Statement             slab[Statement]
+	StringLiteral         slab[StringLiteral]
+	SuperExpression       slab[SuperExpression]
+	SwitchStatement       slab[SwitchStatement]
+	TemplateElement       slab[TemplateElement]
+	TemplateLiteral       slab[TemplateLiteral]
+	ThisExpression        slab[ThisExpression]
+	ThrowStatement        slab[ThrowStatement]
+	TryStatement          slab[TryStatement]
+	UnaryExpression       slab[UnaryExpression]
+	UpdateExpression      slab[UpdateExpression]
+	VariableDeclaration   slab[VariableDeclaration]
+	VariableDeclarator    slab[VariableDeclarator]
+	WhileStatement        slab[WhileStatement]
+	WithStatement         slab[WithStatement]
+	YieldExpression       slab[YieldExpression]
+}
+
+func (w *binaryWriter) writeArrayLiteral(n *ArrayLiteral) {
+	w.writeExpressions(n.Value)
+	w.idx(n.LeftBracket)
+	w.idx(n.RightBracket)
+}
+func (r *binaryReader) readArrayLiteral(n *ArrayLiteral) {
+	n.Value = r.readExpressions()
+	n.LeftBracket = r.idx()
+	n.RightBracket = r.idx()
+}
+func (w *binaryWriter) writeArrayPattern(n *ArrayPattern) {
+	w.writeExpressions(n.Elements)
+	if w.present(n.Rest != nil) {
+		w.writeExpression(n.Rest)
+	}
+	w.idx(n.LeftBracket)
+	w.idx(n.RightBracket)
+}
+func (r *binaryReader) readArrayPattern(n *ArrayPattern) {
+	n.Elements = r.readExpressions()
+	if r.present() {
+		n.Rest = r.nodes.Expression.new()
+		r.readExpression(n.Rest)
+	}
+	n.LeftBracket = r.idx()
+	n.RightBracket = r.idx()
+}
+func (w *binaryWriter) writeArrowFunctionLiteral(n *ArrowFunctionLiteral) {
+	if w.present(n.ParameterList != nil) {
+		w.writeParameterList(n.ParameterList)
+	}
+	if w.present(n.Body != nil) {
+		w.writeConciseBody(n.Body)
+	}
+	w.scope(n.ScopeContext)
+	w.idx(n.Start)
+	w.bool(n.Async)
+}
+func (r *binaryReader) readArrowFunctionLiteral(n *ArrowFunctionLiteral) {
+	if r.present() {
+		n.ParameterList = r.nodes.ParameterList.new()
+		r.readParameterList(n.ParameterList)
+	}
+	if r.present() {
+		n.Body = r.nodes.ConciseBody.new()
+		r.readConciseBody(n.Body)
+	}
+	n.ScopeContext = r.scope()
+	n.Start = r.idx()
+	n.Async = r.bool()
+}
+func (w *binaryWriter) writeAssignExpression(n *AssignExpression) {
+	if w.present(n.Left != nil) {
+		w.writeExpression(n.Left)
+	}
+	if w.present(n.Right != nil) {
+		w.writeExpression(n.Right)
+	}
+	w.u8(uint8(n.Operator))
+}
+func (r *binaryReader) readAssignExpression(n *AssignExpression) {
+	if r.present() {
+		n.Left = r.nodes.Expression.new()
+		r.readExpression(n.Left)
+	}
+	if r.present() {
+		n.Right = r.nodes.Expression.new()
+		r.readExpression(n.Right)
+	}
+	n.Operator = AssignmentOperator(r.u8())
+}
+func (w *binaryWriter) writeAwaitExpression(n *AwaitExpression) {
+	if w.present(n.Argument != nil) {
+		w.writeExpression(n.Argument)
+	}
+	w.idx(n.Await)
+}
+func (r *binaryReader) readAwaitExpression(n *AwaitExpression) {
+	if r.present() {
+		n.Argument = r.nodes.Expression.new()
+		r.readExpression(n.Argument)
+	}
+	n.Await = r.idx()
+}
+func (w *binaryWriter) writeBadStatement(n *BadStatement) {
+	w.idx(n.From)
+	w.idx(n.To)
+}
+func (r *binaryReader) readBadStatement(n *BadStatement) {
+	n.From = r.idx()
+	n.To = r.idx()
+}
+func (w *binaryWriter) writeBigIntLiteral(n *BigIntLiteral) {
+	w.bigInt(n.Value)
+	w.strPtr(n.Raw)
+	w.idx(n.Idx)
+}
+func (r *binaryReader) readBigIntLiteral(n *BigIntLiteral) {
+	n.Value = r.bigInt()
+	n.Raw = r.strPtr()
+	n.Idx = r.idx()
+}
+func (w *binaryWriter) writeBinaryExpression(n *BinaryExpression) {
+	if w.present(n.Left != nil) {
+		w.writeExpression(n.Left)
+	}
+	if w.present(n.Right != nil) {
+		w.writeExpression(n.Right)
+	}
+	w.u8(uint8(n.Operator))
+}
+func (r *binaryReader) readBinaryExpression(n *BinaryExpression) {
+	if r.present() {
+		n.Left = r.nodes.Expression.new()
+		r.readExpression(n.Left)
+	}
+	if r.present() {
+		n.Right = r.nodes.Expression.new()
+		r.readExpression(n.Right)
+	}
+	n.Operator = BinaryOperator(r.u8())
+}
+func (w *binaryWriter) writeBindingTarget(n *BindingTarget) {
+	w.writeTarget(n.Target)
+}
+func (r *binaryReader) readBindingTarget(n *BindingTarget) {
+	n.Target = r.readTarget()
+}
+func (w *binaryWriter) writeBlockStatement(n *BlockStatement) {
+	w.writeStatements(n.List)
+	w.scope(n.ScopeContext)
+	w.idx(n.LeftBrace)
+	w.idx(n.RightBrace)
+}
+func (r *binaryReader) readBlockStatement(n *BlockStatement) {
+	n.List = r.readStatements()
+	n.ScopeContext = r.scope()
+	n.LeftBrace = r.idx()
+	n.RightBrace = r.idx()
+}
+func (w *binaryWriter) writeBooleanLiteral(n *BooleanLiteral) {
+	w.idx(n.Idx)
+	w.bool(n.Value)
+}
+func (r *binaryReader) readBooleanLiteral(n *BooleanLiteral) {
+	n.Idx = r.idx()
+	n.Value = r.bool()
+}
+func (w *binaryWriter) writeBreakStatement(n *BreakStatement) {
+	if w.present(n.Label != nil) {
+		w.writeIdentifier(n.Label)
+	}
+	w.idx(n.Idx)
+}
+func (r *binaryReader) readBreakStatement(n *BreakStatement) {
+	if r.present() {
+		n.Label = r.nodes.Identifier.new()
+		r.readIdentifier(n.Label)
+	}
+	n.Idx = r.idx()
+}
+func (w *binaryWriter) writeCallExpression(n *CallExpression) {
+	if w.present(n.Callee != nil) {
+		w.writeExpression(n.Callee)
+	}
+	w.writeExpressions(n.ArgumentList)
+	w.idx(n.LeftParenthesis)
+	w.idx(n.RightParenthesis)
+}
+func (r *binaryReader) readCallExpression(n *CallExpression) {
+	if r.present() {
+		n.Callee = r.nodes.Expression.new()
+		r.readExpression(n.Callee)
+	}
+	n.ArgumentList = r.readExpressions()
+	n.LeftParenthesis = r.idx()
+	n.RightParenthesis = r.idx()
+}
+func (w *binaryWriter) writeCaseStatement(n *CaseStatement) {
+	if w.present(n.Test != nil) {
+		w.writeExpression(n.Test)
+	}
+	w.writeStatements(n.Consequent)
+	w.idx(n.Case)
+	w.idx(n.Colon)
+}
+func (r *binaryReader) readCaseStatement(n *CaseStatement) {
+	if r.present() {
+		n.Test = r.nodes.Expression.new()
+		r.readExpression(n.Test)
+	}
+	n.Consequent = r.readStatements()
+	n.Case = r.idx()
+	n.Colon = r.idx()
+}
+func (w *binaryWriter) writeCaseStatements(n CaseStatements) {
+	w.uvarint(uint64(len(n)))
+	for i := range n {
+		w.writeCaseStatement(&n[i])
+	}
+}
+func (r *binaryReader) readCaseStatements() CaseStatements {
+	l := r.len()
+	if l == 0 {
+		return nil
+	}
+	n := CaseStatements(r.nodes.CaseStatement.make(l))
+	for i := range n {
+		r.readCaseStatement(&n[i])
+	}
+	return n
+}
+func (w *binaryWriter) writeCatchStatement(n *CatchStatement) {
+	if w.present(n.Parameter != nil) {
+		w.writeBindingTarget(n.Parameter)
+	}
+	if w.present(n.Body != nil) {
+		w.writeBlockStatement(n.Body)
+	}
+	w.idx(n.Catch)
+}
+func (r *binaryReader) readCatchStatement(n *CatchStatement) {
+	if r.present() {
+		n.Parameter = r.nodes.BindingTarget.new()
+		r.readBindingTarget(n.Parameter)
+	}
+	if r.present() {
+		n.Body = r.nodes.BlockStatement.new()
+		r.readBlockStatement(n.Body)
+	}
+	n.Catch = r.idx()
+}
+func (w *binaryWriter) writeClassDeclaration(n *ClassDeclaration) {
+	if w.present(n.Class != nil) {
+		w.writeClassLiteral(n.Class)
+	}
+}
+func (r *binaryReader) readClassDeclaration(n *ClassDeclaration) {
+	if r.present() {
+		n.Class = r.nodes.ClassLiteral.new()
+		r.readClassLiteral(n.Class)
+	}
+}
+func (w *binaryWriter) writeClassElement(n *ClassElement) {
+	w.writeElement(n.Element)
+}
+func (r *binaryReader) readClassElement(n *ClassElement) {
+	n.Element = r.readElement()
+}
+func (w *binaryWriter) writeClassElements(n ClassElements) {
+	w.uvarint(uint64(len(n)))
+	for i := range n {
+		w.writeClassElement(&n[i])
+	}
+}
+func (r *binaryReader) readClassElements() ClassElements {
+	l := r.len()
+	if l == 0 {
+		return nil
+	}
+	n := ClassElements(r.nodes.ClassElement.make(l))
+	for i := range n {
+		r.readClassElement(&n[i])
+	}
+	return n
+}
+func (w *binaryWriter) writeClassLiteral(n *ClassLiteral) {
+	if w.present(n.Name != nil) {
+		w.writeIdentifier(n.Name)
+	}
+	if w.present(n.SuperClass != nil) {
+		w.writeExpression(n.SuperClass)
+	}
+	w.writeClassElements(n.Body)
+	w.idx(n.Class)
+	w.idx(n.RightBrace)
+}
+func (r *binaryReader) readClassLiteral(n *ClassLiteral) {
+	if r.present() {
+		n.Name = r.nodes.Identifier.new()
+		r.readIdentifier(n.Name)
+	}
+	if r.present() {
+		n.SuperClass = r.nodes.Expression.new()
+		r.readExpression(n.SuperClass)
+	}
+	n.Body = r.readClassElements()
+	n.Class = r.idx()
+	n.RightBrace = r.idx()
+}
+func (w *binaryWriter) writeClassStaticBlock(n *ClassStaticBlock) {
+	if w.present(n.Block != nil) {
+		w.writeBlockStatement(n.Block)
+	}
+	w.idx(n.Static)
+}
+func (r *binaryReader) readClassStaticBlock(n *ClassStaticBlock) {
+	if r.present() {
+		n.Block = r.nodes.BlockStatement.new()
+		r.readBlockStatement(n.Block)
+	}
+	n.Static = r.idx()
+}
+func (w *binaryWriter) writeComputedProperty(n *ComputedProperty) {
+	if w.present(n.Expr != nil) {
+		w.writeExpression(n.Expr)
+	}
+	w.idx(n.RightBracket)
+}
+func (r *binaryReader) readComputedProperty(n *ComputedProperty) {
+	if r.present() {
+		n.Expr = r.nodes.Expression.new()
+		r.readExpression(n.Expr)
+	}
+	n.RightBracket = r.idx()
+}
+func (w *binaryWriter) writeConciseBody(n *ConciseBody) {
+	w.writeBody(n.Body)
+}
+func (r *binaryReader) readConciseBody(n *ConciseBody) {
+	n.Body = r.readBody()
+}
+func (w *binaryWriter) writeConditionalExpression(n *ConditionalExpression) {
+	if w.present(n.Test != nil) {
+		w.writeExpression(n.Test)
+	}
+	if w.present(n.Consequent != nil) {
+		w.writeExpression(n.Consequent)
+	}
+	if w.present(n.Alternate != nil) {
+		w.writeExpression(n.Alternate)
+	}
+}
+func (r *binaryReader) readConditionalExpression(n *ConditionalExpression) {
+	if r.present() {
+		n.Test = r.nodes.Expression.new()
+		r.readExpression(n.Test)
+	}
+	if r.present() {
+		n.Consequent = r.nodes.Expression.new()
+		r.readExpression(n.Consequent)
+	}
+	if r.present() {
+		n.Alternate = r.nodes.Expression.new()
+		r.readExpression(n.Alternate)
+	}
+}
+func (w *binaryWriter) writeContinueStatement(n *ContinueStatement) {
+	if w.present(n.Label != nil) {
+		w.writeIdentifier(n.Label)
+	}
+	w.idx(n.Idx)
+}
+func (r *binaryReader) readContinueStatement(n *ContinueStatement) {
+	if r.present() {
+		n.Label = r.nodes.Identifier.new()
+		r.readIdentifier(n.Label)
+	}
+	n.Idx = r.idx()
+}
+func (w *binaryWriter) writeDebuggerStatement(n *DebuggerStatement) {
+	w.idx(n.Debugger)
+}
+func (r *binaryReader) readDebuggerStatement(n *DebuggerStatement) {
+	n.Debugger = r.idx()
+}
+func (w *binaryWriter) writeDoWhileStatement(n *DoWhileStatement) {
+	if w.present(n.Test != nil) {
+		w.writeExpression(n.Test)
+	}
+	if w.present(n.Body != nil) {
+		w.writeStatement(n.Body)
+	}
+	w.idx(n.Do)
+	w.idx(n.RightParenthesis)
+}
+func (r *binaryReader) readDoWhileStatement(n *DoWhileStatement) {
+	if r.present() {
+		n.Test = r.nodes.Expression.new()
+		r.readExpression(n.Test)
+	}
+	if r.present() {
+		n.Body = r.nodes.Statement.new()
+		r.readStatement(n.Body)
+	}
+	n.Do = r.idx()
+	n.RightParenthesis = r.idx()
+}
+func (w *binaryWriter) writeEmptyStatement(n *EmptyStatement) {
+	w.idx(n.Semicolon)
+}
+func (r *binaryReader) readEmptyStatement(n *EmptyStatement) {
+	n.Semicolon = r.idx()
+}
+func (w *binaryWriter) writeExpression(n *Expression) {
+	w.writeExpr(n.Expr)
+}
+func (r *binaryReader) readExpression(n *Expression) {
+	n.Expr = r.readExpr()
+}
+func (w *binaryWriter) writeExpressionStatement(n *ExpressionStatement) {
+	if w.present(n.Expression != nil) {
+		w.writeExpression(n.Expression)
+	}
+	w.str(n.Comment)
+}
+func (r *binaryReader) readExpressionStatement(n *ExpressionStatement) {
+	if r.present() {
+		n.Expression = r.nodes.Expression.new()
+		r.readExpression(n.Expression)
+	}
+	n.Comment = r.str()
+}
+func (w *binaryWriter) writeExpressions(n Expressions) {
+	w.uvarint(uint64(len(n)))
+	for i := range n {
+		w.writeExpression(&n[i])
+	}
+}
+func (r *binaryReader) readExpressions() Expressions {
+	l := r.len()
+	if l == 0 {
+		return nil
+	}
+	n := Expressions(r.nodes.Expression.make(l))
+	for i := range n {
+		r.readExpression(&n[i])
+	}
+	return n
+}
+func (w *binaryWriter) writeFieldDefinition(n *FieldDefinition) {
+	if w.present(n.Key != nil) {
+		w.writeExpression(n.Key)
+	}
+	if w.present(n.Initializer != nil) {
+		w.writeExpression(n.Initializer)
+	}
+	w.idx(n.Idx)
+	w.bool(n.Computed)
+	w.bool(n.Static)
+}
+func (r *binaryReader) readFieldDefinition(n *FieldDefinition) {
+	if r.present() {
+		n.Key = r.nodes.Expression.new()
+		r.readExpression(n.Key)
+	}
+	if r.present() {
+		n.Initializer = r.nodes.Expression.new()
+		r.readExpression(n.Initializer)
+	}
+	n.Idx = r.idx()
+	n.Computed = r.bool()
+	n.Static = r.bool()
+}
+func (w *binaryWriter) writeForInStatement(n *ForInStatement) {
+	if w.present(n.Into != nil) {
+		w.writeForInto(n.Into)
+	}
+	if w.present(n.Source != nil) {
+		w.writeExpression(n.Source)
+	}
+	if w.present(n.Body != nil) {
+		w.writeStatement(n.Body)
+	}
+	w.idx(n.For)
+}
+func (r *binaryReader) readForInStatement(n *ForInStatement) {
+	if r.present() {
+		n.Into = r.nodes.ForInto.new()
+		r.readForInto(n.Into)
+	}
+	if r.present() {
+		n.Source = r.nodes.Expression.new()
+		r.readExpression(n.Source)
+	}
+	if r.present() {
+		n.Body = r.nodes.Statement.new()
+		r.readStatement(n.Body)
+	}
+	n.For = r.idx()
+}
+func (w *binaryWriter) writeForInto(n *ForInto) {
+	w.writeInto(n.Into)
+}
+func (r *binaryReader) readForInto(n *ForInto) {
+	n.Into = r.readInto()
+}
+func (w *binaryWriter) writeForLoopInitializer(n *ForLoopInitializer) {
+	w.writeForLoopInit(n.Initializer)
+}
+func (r *binaryReader) readForLoopInitializer(n *ForLoopInitializer) {
+	n.Initializer = r.readForLoopInit()
+}
+func (w *binaryWriter) writeForOfStatement(n *ForOfStatement) {
+	if w.present(n.Into != nil) {
+		w.writeForInto(n.Into)
+	}
+	if w.present(n.Source != nil) {
+		w.writeExpression(n.Source)
+	}
+	if w.present(n.Body != nil) {
+		w.writeStatement(n.Body)
+	}
+	w.idx(n.For)
+	w.bool(n.Await)
+}
+func (r *binaryReader) readForOfStatement(n *ForOfStatement) {
+	if r.present() {
+		n.Into = r.nodes.ForInto.new()
+		r.readForInto(n.Into)
+	}
+	if r.present() {
+		n.Source = r.nodes.Expression.new()
+		r.readExpression(n.Source)
+	}
+	if r.present() {
+		n.Body = r.nodes.Statement.new()
+		r.readStatement(n.Body)
+	}
+	n.For = r.idx()
+	n.Await = r.bool()
+}
+func (w *binaryWriter) writeForStatement(n *ForStatement) {
+	if w.present(n.Initializer != nil) {
+		w.writeForLoopInitializer(n.Initializer)
+	}
+	if w.present(n.Update != nil) {
+		w.writeExpression(n.Update)
+	}
+	if w.present(n.Test != nil) {
+		w.writeExpression(n.Test)
+	}
+	if w.present(n.Body != nil) {
+		w.writeStatement(n.Body)
+	}
+	w.idx(n.For)
+}
+func (r *binaryReader) readForStatement(n *ForStatement) {
+	if r.present() {
+		n.Initializer = r.nodes.ForLoopInitializer.new()
+		r.readForLoopInitializer(n.Initializer)
+	}
+	if r.present() {
+		n.Update = r.nodes.Expression.new()
+		r.readExpression(n.Update)
+	}
+	if r.present() {
+		n.Test = r.nodes.Expression.new()
+		r.readExpression(n.Test)
+	}
+	if r.present() {
+		n.Body = r.nodes.Statement.new()
+		r.readStatement(n.Body)
+	}
+	n.For = r.idx()
+}
+func (w *binaryWriter) writeFunctionDeclaration(n *FunctionDeclaration) {
+	if w.present(n.Function != nil) {
+		w.writeFunctionLiteral(n.Function)
+	}
+}
+func (r *binaryReader) readFunctionDeclaration(n *FunctionDeclaration) {
+	if r.present() {
+		n.Function = r.nodes.FunctionLiteral.new()
+		r.readFunctionLiteral(n.Function)
+	}
+}
+func (w *binaryWriter) writeFunctionLiteral(n *FunctionLiteral) {
+	if w.present(n.Name != nil) {
+		w.writeIdentifier(n.Name)
+	}
+	if w.present(n.ParameterList != nil) {
+		w.writeParameterList(n.ParameterList)
+	}
+	if w.present(n.Body != nil) {
+		w.writeBlockStatement(n.Body)
+	}
+	w.scope(n.ScopeContext)
+	w.idx(n.Function)
+	w.bool(n.Async)
+	w.bool(n.Generator)
+	w.lazyBody(n.Lazy)
+}
+func (r *binaryReader) readFunctionLiteral(n *FunctionLiteral) {
+	if r.present() {
+		n.Name = r.nodes.Identifier.new()
+		r.readIdentifier(n.Name)
+	}
+	if r.present() {
+		n.ParameterList = r.nodes.ParameterList.new()
+		r.readParameterList(n.ParameterList)
+	}
+	if r.present() {
+		n.Body = r.nodes.BlockStatement.new()
+		r.readBlockStatement(n.Body)
+	}
+	n.ScopeContext = r.scope()
+	n.Function = r.idx()
+	n.Async = r.bool()
+	n.Generator = r.bool()
+	n.Lazy = r.lazyBody()
+}
+func (w *binaryWriter) writeIdentifier(n *Identifier) {
+	w.str(n.Name)
+	w.scope(n.ScopeContext)
+	w.idx(n.Idx)
+}
+func (r *binaryReader) readIdentifier(n *Identifier) {
+	n.Name = r.str()
+	n.ScopeContext = r.scope()
+	n.Idx = r.idx()
+}
+func (w *binaryWriter) writeIfStatement(n *IfStatement) {
+	if w.present(n.Test != nil) {
+		w.writeExpression(n.Test)
+	}
+	if w.present(n.Consequent != nil) {
+		w.writeStatement(n.Consequent)
+	}
+	if w.present(n.Alternate != nil) {
+		w.writeStatement(n.Alternate)
+	}
+	w.idx(n.If)
+}
+func (r *binaryReader) readIfStatement(n *IfStatement) {
+	if r.present() {
+		n.Test = r.nodes.Expression.new()
+		r.readExpression(n.Test)
+	}
+	if r.present() {
+		n.Consequent = r.nodes.Statement.new()
+		r.readStatement(n.Consequent)
+	}
+	if r.present() {
+		n.Alternate = r.nodes.Statement.new()
+		r.readStatement(n.Alternate)
+	}
+	n.If = r.idx()
+}
+func (w *binaryWriter) writeInvalidExpression(n *InvalidExpression) {
+	w.idx(n.From)
+	w.idx(n.To)
+}
+func (r *binaryReader) readInvalidExpression(n *InvalidExpression) {
+	n.From = r.idx()
+	n.To = r.idx()
+}
+func (w *binaryWriter) writeLabelledStatement(n *LabelledStatement) {
+	if w.present(n.Label != nil) {
+		w.writeIdentifier(n.Label)
+	}
+	if w.present(n.Statement != nil) {
+		w.writeStatement(n.Statement)
+	}
+	w.idx(n.Colon)
+}
+func (r *binaryReader) readLabelledStatement(n *LabelledStatement) {
+	if r.present() {
+		n.Label = r.nodes.Identifier.new()
+		r.readIdentifier(n.Label)
+	}
+	if r.present() {
+		n.Statement = r.nodes.Statement.new()
+		r.readStatement(n.Statement)
+	}
+	n.Colon = r.idx()
+}
+func (w *binaryWriter) writeLogicalExpression(n *LogicalExpression) {
+	if w.present(n.Left != nil) {
+		w.writeExpression(n.Left)
+	}
+	if w.present(n.Right != nil) {
+		w.writeExpression(n.Right)
+	}
+	w.u8(uint8(n.Operator))
+}
+func (r *binaryReader) readLogicalExpression(n *LogicalExpression) {
+	if r.present() {
+		n.Left = r.nodes.Expression.new()
+		r.readExpression(n.Left)
+	}
+	if r.present() {
+		n.Right = r.nodes.Expression.new()
+		r.readExpression(n.Right)
+	}
+	n.Operator = LogicalOperator(r.u8())
+}
+func (w *binaryWriter) writeMemberExpression(n *MemberExpression) {
+	if w.present(n.Object != nil) {
+		w.writeExpression(n.Object)
+	}
+	if w.present(n.Property != nil) {
+		w.writeMemberProperty(n.Property)
+	}
+}
+func (r *binaryReader) readMemberExpression(n *MemberExpression) {
+	if r.present() {
+		n.Object = r.nodes.Expression.new()
+		r.readExpression(n.Object)
+	}
+	if r.present() {
+		n.Property = r.nodes.MemberProperty.new()
+		r.readMemberProperty(n.Property)
+	}
+}
+func (w *binaryWriter) writeMemberProperty(n *MemberProperty) {
+	w.writeMemberProp(n.Prop)
+}
+func (r *binaryReader) readMemberProperty(n *MemberProperty) {
+	n.Prop = r.readMemberProp()
+}
+func (w *binaryWriter) writeMetaProperty(n *MetaProperty) {
+	if w.present(n.Meta != nil) {
+		w.writeIdentifier(n.Meta)
+	}
+	if w.present(n.Property != nil) {
+		w.writeIdentifier(n.Property)
+	}
+	w.idx(n.Idx)
+}
+func (r *binaryReader) readMetaProperty(n *MetaProperty) {
+	if r.present() {
+		n.Meta = r.nodes.Identifier.new()
+		r.readIdentifier(n.Meta)
+	}
+	if r.present() {
+		n.Property = r.nodes.Identifier.new()
+		r.readIdentifier(n.Property)
+	}
+	n.Idx = r.idx()
+}
+func (w *binaryWriter) writeMethodDefinition(n *MethodDefinition) {
+	if w.present(n.Key != nil) {
+		w.writeExpression(n.Key)
+	}
+	w.str(string(n.Kind))
+	if w.present(n.Body != nil) {
+		w.writeFunctionLiteral(n.Body)
+	}
+	w.idx(n.Idx)
+	w.bool(n.Computed)
+	w.bool(n.Static)
+}
+func (r *binaryReader) readMethodDefinition(n *MethodDefinition) {
+	if r.present() {
+		n.Key = r.nodes.Expression.new()
+		r.readExpression(n.Key)
+	}
+	n.Kind = PropertyKind(r.str())
+	if r.present() {
+		n.Body = r.nodes.FunctionLiteral.new()
+		r.readFunctionLiteral(n.Body)
+	}
+	n.Idx = r.idx()
+	n.Computed = r.bool()
+	n.Static = r.bool()
+}
+func (w *binaryWriter) writeNewExpression(n *NewExpression) {
+	if w.present(n.Callee != nil) {
+		w.writeExpression(n.Callee)
+	}
+	w.writeExpressions(n.ArgumentList)
+	w.idx(n.New)
+	w.idx(n.LeftParenthesis)
+	w.idx(n.RightParenthesis)
+}
+func (r *binaryReader) readNewExpression(n *NewExpression) {
+	if r.present() {
+		n.Callee = r.nodes.Expression.new()
+		r.readExpression(n.Callee)
+	}
+	n.ArgumentList = r.readExpressions()
+	n.New = r.idx()
+	n.LeftParenthesis = r.idx()
+	n.RightParenthesis = r.idx()
+}
+func (w *binaryWriter) writeNullLiteral(n *NullLiteral) {
+	w.idx(n.Idx)
+}
+func (r *binaryReader) readNullLiteral(n *NullLiteral) {
+	n.Idx = r.idx()
+}
+func (w *binaryWriter) writeNumberLiteral(n *NumberLiteral) {
+	w.float(n.Value)
+	w.strPtr(n.Raw)
+	w.idx(n.Idx)
+}
+func (r *binaryReader) readNumberLiteral(n *NumberLiteral) {
+	n.Value = r.float()
+	n.Raw = r.strPtr()
+	n.Idx = r.idx()
+}
+func (w *binaryWriter) writeObjectLiteral(n *ObjectLiteral) {
+	w.writeProperties(n.Value)
+	w.idx(n.LeftBrace)
+	w.idx(n.RightBrace)
+}
+func (r *binaryReader) readObjectLiteral(n *ObjectLiteral) {
+	n.Value = r.readProperties()
+	n.LeftBrace = r.idx()
+	n.RightBrace = r.idx()
+}
+func (w *binaryWriter) writeObjectPattern(n *ObjectPattern) {
+	w.writeProperties(n.Properties)
+	w.writeExpr(n.Rest)
+	w.idx(n.LeftBrace)
+	w.idx(n.RightBrace)
+}
+func (r *binaryReader) readObjectPattern(n *ObjectPattern) {
+	n.Properties = r.readProperties()
+	n.Rest = r.readExpr()
+	n.LeftBrace = r.idx()
+	n.RightBrace = r.idx()
+}
+func (w *binaryWriter) writeOptional(n *Optional) {
+	if w.present(n.Expr != nil) {
+		w.writeExpression(n.Expr)
+	}
+}
+func (r *binaryReader) readOptional(n *Optional) {
+	if r.present() {
+		n.Expr = r.nodes.Expression.new()
+		r.readExpression(n.Expr)
+	}
+}
+func (w *binaryWriter) writeOptionalChain(n *OptionalChain) {
+	if w.present(n.Base != nil) {
+		w.writeExpression(n.Base)
+	}
+}
+func (r *binaryReader) readOptionalChain(n *OptionalChain) {
+	if r.present() {
+		n.Base = r.nodes.Expression.new()
+		r.readExpression(n.Base)
+	}
+}
+func (w *binaryWriter) writeParameterList(n *ParameterList) {
+	w.writeVariableDeclarators(n.List)
+	w.writeExpr(n.Rest)
+	w.idx(n.Opening)
+	w.idx(n.Closing)
+}
+func (r *binaryReader) readParameterList(n *ParameterList) {
+	n.List = r.readVariableDeclarators()
+	n.Rest = r.readExpr()
+	n.Opening = r.idx()
+	n.Closing = r.idx()
+}
+func (w *binaryWriter) writePrivateDotExpression(n *PrivateDotExpression) {
+	if w.present(n.Left != nil) {
+		w.writeExpression(n.Left)
+	}
+	if w.present(n.Identifier != nil) {
+		w.writePrivateIdentifier(n.Identifier)
+	}
+}
+func (r *binaryReader) readPrivateDotExpression(n *PrivateDotExpression) {
+	if r.present() {
+		n.Left = r.nodes.Expression.new()
+		r.readExpression(n.Left)
+	}
+	if r.present() {
+		n.Identifier = r.nodes.PrivateIdentifier.new()
+		r.readPrivateIdentifier(n.Identifier)
+	}
+}
+func (w *binaryWriter) writePrivateIdentifier(n *PrivateIdentifier) {
+	if w.present(n.Identifier != nil) {
+		w.writeIdentifier(n.Identifier)
+	}
+}
+func (r *binaryReader) readPrivateIdentifier(n *PrivateIdentifier) {
+	if r.present() {
+		n.Identifier = r.nodes.Identifier.new()
+		r.readIdentifier(n.Identifier)
+	}
+}
+func (w *binaryWriter) writeProgram(n *Program) {
+	w.writeStatements(n.Body)
+}
+func (r *binaryReader) readProgram(n *Program) {
+	n.Body = r.readStatements()
+}
+func (w *binaryWriter) writeProperties(n Properties) {
+	w.uvarint(uint64(len(n)))
+	for i := range n {
+		w.writeProperty(&n[i])
+	}
+}
+func (r *binaryReader) readProperties() Properties {
+	l := r.len()
+	if l == 0 {
+		return nil
+	}
+	n := Properties(r.nodes.Property.make(l))
+	for i := range n {
+		r.readProperty(&n[i])
+	}
+	return n
+}
+func (w *binaryWriter) writeProperty(n *Property) {
+	w.writeProp(n.Prop)
+}
+func (r *binaryReader) readProperty(n *Property) {
+	n.Prop = r.readProp()
+}
+func (w *binaryWriter) writePropertyKeyed(n *PropertyKeyed) {
+	if w.present(n.Key != nil) {
+		w.writeExpression(n.Key)
+	}
+	w.str(string(n.Kind))
+	if w.present(n.Value != nil) {
+		w.writeExpression(n.Value)
+	}
+	w.bool(n.Computed)
+}
+func (r *binaryReader) readPropertyKeyed(n *PropertyKeyed) {
+	if r.present() {
+		n.Key = r.nodes.Expression.new()
+		r.readExpression(n.Key)
+	}
+	n.Kind = PropertyKind(r.str())
+	if r.present() {
+		n.Value = r.nodes.Expression.new()
+		r.readExpression(n.Value)
+	}
+	n.Computed = r.bool()
+}
+func (w *binaryWriter) writePropertyShort(n *PropertyShort) {
+	if w.present(n.Name != nil) {
+		w.writeIdentifier(n.Name)
+	}
+	if w.present(n.Initializer != nil) {
+		w.writeExpression(n.Initializer)
+	}
+}
+func (r *binaryReader) readPropertyShort(n *PropertyShort) {
+	if r.present() {
+		n.Name = r.nodes.Identifier.new()
+		r.readIdentifier(n.Name)
+	}
+	if r.present() {
+		n.Initializer = r.nodes.Expression.new()
+		r.readExpression(n.Initializer)
+	}
+}
+func (w *binaryWriter) writeRegExpLiteral(n *RegExpLiteral) {
+	w.str(n.Literal)
+	w.str(n.Pattern)
+	w.str(n.Flags)
+	w.idx(n.Idx)
+}
+func (r *binaryReader) readRegExpLiteral(n *RegExpLiteral) {
+	n.Literal = r.str()
+	n.Pattern = r.str()
+	n.Flags = r.str()
+	n.Idx = r.idx()
+}
+func (w *binaryWriter) writeReturnStatement(n *ReturnStatement) {
+	if w.present(n.Argument != nil) {
+		w.writeExpression(n.Argument)
+	}
+	w.idx(n.Return)
+}
+func (r *binaryReader) readReturnStatement(n *ReturnStatement) {
+	if r.present() {
+		n.Argument = r.nodes.Expression.new()
+		r.readExpression(n.Argument)
+	}
+	n.Return = r.idx()
+}
+func (w *binaryWriter) writeSequenceExpression(n *SequenceExpression) {
+	w.writeExpressions(n.Sequence)
+}
+func (r *binaryReader) readSequenceExpression(n *SequenceExpression) {
+	n.Sequence = r.readExpressions()
+}
+func (w *binaryWriter) writeSpreadElement(n *SpreadElement) {
+	if w.present(n.Expression != nil) {
+		w.writeExpression(n.Expression)
+	}
+}
+func (r *binaryReader) readSpreadElement(n *SpreadElement) {
+	if r.present() {
+		n.Expression = r.nodes.Expression.new()
+		r.readExpression(n.Expression)
+	}
+}
+func (w *binaryWriter) writeStatement(n *Statement) {
+	w.writeStmt(n.Stmt)
+}
+func (r *binaryReader) readStatement(n *Statement) {
+	n.Stmt = r.readStmt()
+}
+func (w *binaryWriter) writeStatements(n Statements) {
+	w.uvarint(uint64(len(n)))
+	for i := range n {
+		w.writeStatement(&n[i])
+	}
+}
+func (r *binaryReader) readStatements() Statements {
+	l := r.len()
+	if l == 0 {
+		return nil
+	}
+	n := Statements(r.nodes.Statement.make(l))
+	for i := range n {
+		r.readStatement(&n[i])
+	}
+	return n
+}
+func (w *binaryWriter) writeStringLiteral(n *StringLiteral) {
+	w.str(n.Value)
+	w.strPtr(n.Raw)
+	w.idx(n.Idx)
+}
+func (r *binaryReader) readStringLiteral(n *StringLiteral) {
+	n.Value = r.str()
+	n.Raw = r.strPtr()
+	n.Idx = r.idx()
+}
+func (w *binaryWriter) writeSuperExpression(n *SuperExpression) {
+	w.idx(n.Idx)
+}
+func (r *binaryReader) readSuperExpression(n *SuperExpression) {
+	n.Idx = r.idx()
+}
+func (w *binaryWriter) writeSwitchStatement(n *SwitchStatement) {
+	if w.present(n.Discriminant != nil) {
+		w.writeExpression(n.Discriminant)
+	}
+	w.int(n.Default)
+	w.writeCaseStatements(n.Body)
+	w.idx(n.Switch)
+	w.idx(n.RightBrace)
+}
+func (r *binaryReader) readSwitchStatement(n *SwitchStatement) {
+	if r.present() {
+		n.Discriminant = r.nodes.Expression.new()
+		r.readExpression(n.Discriminant)
+	}
+	n.Default = r.int()
+	n.Body = r.readCaseStatements()
+	n.Switch = r.idx()
+	n.RightBrace = r.idx()
+}
+func (w *binaryWriter) writeTemplateElement(n *TemplateElement) {
+	w.str(n.Literal)
+	w.str(n.Parsed)
+	w.idx(n.Idx)
+}
+func (r *binaryReader) readTemplateElement(n *TemplateElement) {
+	n.Literal = r.str()
+	n.Parsed = r.str()
+	n.Idx = r.idx()
+}
+func (w *binaryWriter) writeTemplateElements(n TemplateElements) {
+	w.uvarint(uint64(len(n)))
+	for i := range n {
+		w.writeTemplateElement(&n[i])
+	}
+}
+func (r *binaryReader) readTemplateElements() TemplateElements {
+	l := r.len()
+	if l == 0 {
+		return nil
+	}
+	n := TemplateElements(r.nodes.TemplateElement.make(l))
+	for i := range n {
+		r.readTemplateElement(&n[i])
+	}
+	return n
+}
+func (w *binaryWriter) writeTemplateLiteral(n *TemplateLiteral) {
+	if w.present(n.Tag != nil) {
+		w.writeExpression(n.Tag)
+	}
+	w.writeTemplateElements(n.Elements)
+	w.writeExpressions(n.Expressions)
+	w.idx(n.OpenQuote)
+	w.idx(n.CloseQuote)
+}
+func (r *binaryReader) readTemplateLiteral(n *TemplateLiteral) {
+	if r.present() {
+		n.Tag = r.nodes.Expression.new()
+		r.readExpression(n.Tag)
+	}
+	n.Elements = r.readTemplateElements()
+	n.Expressions = r.readExpressions()
+	n.OpenQuote = r.idx()
+	n.CloseQuote = r.idx()
+}
+func (w *binaryWriter) writeThisExpression(n *ThisExpression) {
+	w.idx(n.Idx)
+}
+func (r *binaryReader) readThisExpression(n *ThisExpression) {
+	n.Idx = r.idx()
+}
+func (w *binaryWriter) writeThrowStatement(n *ThrowStatement) {
+	if w.present(n.Argument != nil) {
+		w.writeExpression(n.Argument)
+	}
+	w.idx(n.Throw)
+}
+func (r *binaryReader) readThrowStatement(n *ThrowStatement) {
+	if r.present() {
+		n.Argument = r.nodes.Expression.new()
+		r.readExpression(n.Argument)
+	}
+	n.Throw = r.idx()
+}
+func (w *binaryWriter) writeTryStatement(n *TryStatement) {
+	if w.present(n.Body != nil) {
+		w.writeBlockStatement(n.Body)
+	}
+	if w.present(n.Catch != nil) {
+		w.writeCatchStatement(n.Catch)
+	}
+	if w.present(n.Finally != nil) {
+		w.writeBlockStatement(n.Finally)
+	}
+	w.idx(n.Try)
+}
+func (r *binaryReader) readTryStatement(n *TryStatement) {
+	if r.present() {
+		n.Body = r.nodes.BlockStatement.new()
+		r.readBlockStatement(n.Body)
+	}
+	if r.present() {
+		n.Catch = r.nodes.CatchStatement.new()
+		r.readCatchStatement(n.Catch)
+	}
+	if r.present() {
+		n.Finally = r.nodes.BlockStatement.new()
+		r.readBlockStatement(n.Finally)
+	}
+	n.Try = r.idx()
+}
+func (w *binaryWriter) writeUnaryExpression(n *UnaryExpression) {
+	if w.present(n.Operand != nil) {
+		w.writeExpression(n.Operand)
+	}
+	w.u8(uint8(n.Operator))
+	w.idx(n.Idx)
+}
+func (r *binaryReader) readUnaryExpression(n *UnaryExpression) {
+	if r.present() {
+		n.Operand = r.nodes.Expression.new()
+		r.readExpression(n.Operand)
+	}
+	n.Operator = UnaryOperator(r.u8())
+	n.Idx = r.idx()
+}
+func (w *binaryWriter) writeUpdateExpression(n *UpdateExpression) {
+	if w.present(n.Operand != nil) {
+		w.writeExpression(n.Operand)
+	}
+	w.u8(uint8(n.Operator))
+	w.bool(n.Postfix)
+	w.idx(n.Idx)
+}
+func (r *binaryReader) readUpdateExpression(n *UpdateExpression) {
+	if r.present() {
+		n.Operand = r.nodes.Expression.new()
+		r.readExpression(n.Operand)
+	}
+	n.Operator = UpdateOperator(r.u8())
+	n.Postfix = r.bool()
+	n.Idx = r.idx()
+}
+func (w *binaryWriter) writeVariableDeclaration(n *VariableDeclaration) {
+	w.idx(n.Idx)
+	w.token(n.Token)
+	w.writeVariableDeclarators(n.List)
+	w.str(n.Comment)
+}
+func (r *binaryReader) readVariableDeclaration(n *VariableDeclaration) {
+	n.Idx = r.idx()
+	n.Token = r.token()
+	n.List = r.readVariableDeclarators()
+	n.Comment = r.str()
+}
+func (w *binaryWriter) writeVariableDeclarator(n *VariableDeclarator) {
+	if w.present(n.Target != nil) {
+		w.writeBindingTarget(n.Target)
+	}
+	if w.present(n.Initializer != nil) {
+		w.writeExpression(n.Initializer)
+	}
+}
+func (r *binaryReader) readVariableDeclarator(n *VariableDeclarator) {
+	if r.present() {
+		n.Target = r.nodes.BindingTarget.new()
+		r.readBindingTarget(n.Target)
+	}
+	if r.present() {
+		n.Initializer = r.nodes.Expression.new()
+		r.readExpression(n.Initializer)
+	}
+}
+func (w *binaryWriter) writeVariableDeclarators(n VariableDeclarators) {
+	w.uvarint(uint64(len(n)))
+	for i := range n {
+		w.writeVariableDeclarator(&n[i])
+	}
+}
+func (r *binaryReader) readVariableDeclarators() VariableDeclarators {
+	l := r.len()
+	if l == 0 {
+		return nil
+	}
+	n := VariableDeclarators(r.nodes.VariableDeclarator.make(l))
+	for i := range n {
+		r.readVariableDeclarator(&n[i])
+	}
+	return n
+}
+func (w *binaryWriter) writeWhileStatement(n *WhileStatement) {
+	if w.present(n.Test != nil) {
+		w.writeExpression(n.Test)
+	}
+	if w.present(n.Body != nil) {
+		w.writeStatement(n.Body)
+	}
+	w.idx(n.While)
+}
+func (r *binaryReader) readWhileStatement(n *WhileStatement) {
+	if r.present() {
+		n.Test = r.nodes.Expression.new()
+		r.readExpression(n.Test)
+	}
+	if r.present() {
+		n.Body = r.nodes.Statement.new()
+		r.readStatement(n.Body)
+	}
+	n.While = r.idx()
+}
+func (w *binaryWriter) writeWithStatement(n *WithStatement) {
+	if w.present(n.Object != nil) {
+		w.writeExpression(n.Object)
+	}
+	if w.present(n.Body != nil) {
+		w.writeStatement(n.Body)
+	}
+	w.idx(n.With)
+}
+func (r *binaryReader) readWithStatement(n *WithStatement) {
+	if r.present() {
+		n.Object = r.nodes.Expression.new()
+		r.readExpression(n.Object)
+	}
+	if r.present() {
+		n.Body = r.nodes.Statement.new()
+		r.readStatement(n.Body)
+	}
+	n.With = r.idx()
+}
+func (w *binaryWriter) writeYieldExpression(n *YieldExpression) {
+	if w.present(n.Argument != nil) {
+		w.writeExpression(n.Argument)
+	}
+	w.idx(n.Yield)
+	w.bool(n.Delegate)
+}
+func (r *binaryReader) readYieldExpression(n *YieldExpression) {
+	if r.present() {
+		n.Argument = r.nodes.Expression.new()
+		r.readExpression(n.Argument)
+	}
+	n.Yield = r.idx()
+	n.Delegate = r.bool()
+}
+func (w *binaryWriter) writeBody(n Body) {
+	w.enter()
+	switch n := n.(type) {
+	case nil:
+		w.uvarint(0)
+	case *BlockStatement:
+		w.uvarint(1)
+		w.writeBlockStatement(n)
+	case *Expression:
+		w.uvarint(2)
+		w.writeExpression(n)
+	default:
+		w.unknown("Body", n)
+	}
+	w.depth--
+}
+func (r *binaryReader) readBody() (n Body) {
+	r.enter()
+	switch r.uvarint() {
+	case 0:
+	case 1:
+		x := r.nodes.BlockStatement.new()
+		r.readBlockStatement(x)
+		n = x
+	case 2:
+		x := r.nodes.Expression.new()
+		r.readExpression(x)
+		n = x
+	default:
+		r.corrupt()
+	}
+	r.depth--
+	return n
+}
+func (w *binaryWriter) writeElement(n Element) {
+	w.enter()
+	switch n := n.(type) {
+	case nil:
+		w.uvarint(0)
+	case *ClassStaticBlock:
+		w.uvarint(1)
+		w.writeClassStaticBlock(n)
+	case *FieldDefinition:
+		w.uvarint(2)
+		w.writeFieldDefinition(n)
+	case *MethodDefinition:
+		w.uvarint(3)
+		w.writeMethodDefinition(n)
+	default:
+		w.unknown("Element", n)
+	}
+	w.depth--
+}
+func (r *binaryReader) readElement() (n Element) {
+	r.enter()
+	switch r.uvarint() {
+	case 0:
+	case 1:
+		x := r.nodes.ClassStaticBlock.new()
+		r.readClassStaticBlock(x)
+		n = x
+	case 2:
+		x := r.nodes.FieldDefinition.new()
+		r.readFieldDefinition(x)
+		n = x
+	case 3:
+		x := r.nodes.MethodDefinition.new()
+		r.readMethodDefinition(x)
+		n = x
+	default:
+		r.corrupt()
+	}
+	r.depth--
+	return n
+}
+func (w *binaryWriter) writeExpr(n Expr) {
+	w.enter()
+	switch n := n.(type) {
+	case nil:
+		w.uvarint(0)
+	case *ArrayLiteral:
+		w.uvarint(1)
+		w.writeArrayLiteral(n)
+	case *ArrayPattern:
+		w.uvarint(2)
+		w.writeArrayPattern(n)
+	case *ArrowFunctionLiteral:
+		w.uvarint(3)
+		w.writeArrowFunctionLiteral(n)
+	case *AssignExpression:
+		w.uvarint(4)
+		w.writeAssignExpression(n)
+	case *AwaitExpression:
+		w.uvarint(5)
+		w.writeAwaitExpression(n)
+	case *BigIntLiteral:
+		w.uvarint(6)
+		w.writeBigIntLiteral(n)
+	case *BinaryExpression:
+		w.uvarint(7)
+		w.writeBinaryExpression(n)
+	case *BooleanLiteral:
+		w.uvarint(8)
+		w.writeBooleanLiteral(n)
+	case *CallExpression:
+		w.uvarint(9)
+		w.writeCallExpression(n)
+	case *ClassLiteral:
+		w.uvarint(10)
+		w.writeClassLiteral(n)
+	case *ConditionalExpression:
+		w.uvarint(11)
+		w.writeConditionalExpression(n)
+	case *FunctionLiteral:
+		w.uvarint(12)
+		w.writeFunctionLiteral(n)
+	case *Identifier:
+		w.uvarint(13)
+		w.writeIdentifier(n)
+	case *InvalidExpression:
+		w.uvarint(14)
+		w.writeInvalidExpression(n)
+	case *LogicalExpression:
+		w.uvarint(15)
+		w.writeLogicalExpression(n)
+	case *MemberExpression:
+		w.uvarint(16)
+		w.writeMemberExpression(n)
+	case *MetaProperty:
+		w.uvarint(17)
+		w.writeMetaProperty(n)
+	case *NewExpression:
+		w.uvarint(18)
+		w.writeNewExpression(n)
+	case *NullLiteral:
+		w.uvarint(19)
+		w.writeNullLiteral(n)
+	case *NumberLiteral:
+		w.uvarint(20)
+		w.writeNumberLiteral(n)
+	case *ObjectLiteral:
+		w.uvarint(21)
+		w.writeObjectLiteral(n)
+	case *ObjectPattern:
+		w.uvarint(22)
+		w.writeObjectPattern(n)
+	case *Optional:
+		w.uvarint(23)
+		w.writeOptional(n)
+	case *OptionalChain:
+		w.uvarint(24)
+		w.writeOptionalChain(n)
+	case *PrivateDotExpression:
+		w.uvarint(25)
+		w.writePrivateDotExpression(n)
+	case *PrivateIdentifier:
+		w.uvarint(26)
+		w.writePrivateIdentifier(n)
+	case *PropertyKeyed:
+		w.uvarint(27)
+		w.writePropertyKeyed(n)
+	case *PropertyShort:
+		w.uvarint(28)
+		w.writePropertyShort(n)
+	case *RegExpLiteral:
+		w.uvarint(29)
+		w.writeRegExpLiteral(n)
+	case *SequenceExpression:
+		w.uvarint(30)
+		w.writeSequenceExpression(n)
+	case *SpreadElement:
+		w.uvarint(31)
+		w.writeSpreadElement(n)
+	case *StringLiteral:
+		w.uvarint(32)
+		w.writeStringLiteral(n)
+	case *SuperExpression:
+		w.uvarint(33)
+		w.writeSuperExpression(n)
+	case *TemplateLiteral:
+		w.uvarint(34)
+		w.writeTemplateLiteral(n)
+	case *ThisExpression:
+		w.uvarint(35)
+		w.writeThisExpression(n)
+	case *UnaryExpression:
+		w.uvarint(36)
+		w.writeUnaryExpression(n)
+	case *UpdateExpression:
+		w.uvarint(37)
+		w.writeUpdateExpression(n)
+	case *VariableDeclarator:
+		w.uvarint(38)
+		w.writeVariableDeclarator(n)
+	case *YieldExpression:
+		w.uvarint(39)
+		w.writeYieldExpression(n)
+	default:
+		w.unknown("Expr", n)
+	}
+	w.depth--
+}
+func (r *binaryReader) readExpr() (n Expr) {
+	r.enter()
+	switch r.uvarint() {
+	case 0:
+	case 1:
+		x := r.nodes.ArrayLiteral.new()
+		r.readArrayLiteral(x)
+		n = x
+	case 2:
+		x := r.nodes.ArrayPattern.new()
+		r.readArrayPattern(x)
+		n = x
+	case 3:
+		x := r.nodes.ArrowFunctionLiteral.new()
+		r.readArrowFunctionLiteral(x)
+		n = x
+	case 4:
+		x := r.nodes.AssignExpression.new()
+		r.readAssignExpression(x)
+		n = x
+	case 5:
+		x := r.nodes.AwaitExpression.new()
+		r.readAwaitExpression(x)
+		n = x
+	case 6:
+		x := r.nodes.BigIntLiteral.new()
+		r.readBigIntLiteral(x)
+		n = x
+	case 7:
+		x := r.nodes.BinaryExpression.new()
+		r.readBinaryExpression(x)
+		n = x
+	case 8:
+		x := r.nodes.BooleanLiteral.new()
+		r.readBooleanLiteral(x)
+		n = x
+	case 9:
+		x := r.nodes.CallExpression.new()
+		r.readCallExpression(x)
+		n = x
+	case 10:
+		x := r.nodes.ClassLiteral.new()
+		r.readClassLiteral(x)
+		n = x
+	case 11:
+		x := r.nodes.ConditionalExpression.new()
+		r.readConditionalExpression(x)
+		n = x
+	case 12:
+		x := r.nodes.FunctionLiteral.new()
+		r.readFunctionLiteral(x)
+		n = x
+	case 13:
+		x := r.nodes.Identifier.new()
+		r.readIdentifier(x)
+		n = x
+	case 14:
+		x := r.nodes.InvalidExpression.new()
+		r.readInvalidExpression(x)
+		n = x
+	case 15:
+		x := r.nodes.LogicalExpression.new()
+		r.readLogicalExpression(x)
+		n = x
+	case 16:
+		x := r.nodes.MemberExpression.new()
+		r.readMemberExpression(x)
+		n = x
+	case 17:
+		x := r.nodes.MetaProperty.new()
+		r.readMetaProperty(x)
+		n = x
+	case 18:
+		x := r.nodes.NewExpression.new()
+		r.readNewExpression(x)
+		n = x
+	case 19:
+		x := r.nodes.NullLiteral.new()
+		r.readNullLiteral(x)
+		n = x
+	case 20:
+		x := r.nodes.NumberLiteral.new()
+		r.readNumberLiteral(x)
+		n = x
+	case 21:
+		x := r.nodes.ObjectLiteral.new()
+		r.readObjectLiteral(x)
+		n = x
+	case 22:
+		x := r.nodes.ObjectPattern.new()
+		r.readObjectPattern(x)
+		n = x
+	case 23:
+		x := r.nodes.Optional.new()
+		r.readOptional(x)
+		n = x
+	case 24:
+		x := r.nodes.OptionalChain.new()
+		r.readOptionalChain(x)
+		n = x
+	case 25:
+		x := r.nodes.PrivateDotExpression.new()
+		r.readPrivateDotExpression(x)
+		n = x
+	case 26:
+		x := r.nodes.PrivateIdentifier.new()
+		r.readPrivateIdentifier(x)
+		n = x
+	case 27:
+		x := r.nodes.PropertyKeyed.new()
+		r.readPropertyKeyed(x)
+		n = x
+	case 28:
+		x := r.nodes.PropertyShort.new()
+		r.readPropertyShort(x)
+		n = x
+	case 29:
+		x := r.nodes.RegExpLiteral.new()
+		r.readRegExpLiteral(x)
+		n = x
+	case 30:
+		x := r.nodes.SequenceExpression.new()
+		r.readSequenceExpression(x)
+		n = x
+	case 31:
+		x := r.nodes.SpreadElement.new()
+		r.readSpreadElement(x)
+		n = x
+	case 32:
+		x := r.nodes.StringLiteral.new()
+		r.readStringLiteral(x)
+		n = x
+	case 33:
+		x := r.nodes.SuperExpression.new()
+		r.readSuperExpression(x)
+		n = x
+	case 34:
+		x := r.nodes.TemplateLiteral.new()
+		r.readTemplateLiteral(x)
+		n = x
+	case 35:
+		x := r.nodes.ThisExpression.new()
+		r.readThisExpression(x)
+		n = x
+	case 36:
+		x := r.nodes.UnaryExpression.new()
+		r.readUnaryExpression(x)
+		n = x
+	case 37:
+		x := r.nodes.UpdateExpression.new()
+		r.readUpdateExpression(x)
+		n = x
+	case 38:
+		x := r.nodes.VariableDeclarator.new()
+		r.readVariableDeclarator(x)
+		n = x
+	case 39:
+		x := r.nodes.YieldExpression.new()
+		r.readYieldExpression(x)
+		n = x
+	default:
+		r.corrupt()
+	}
+	r.depth--
+	return n
+}
+func (w *binaryWriter) writeForLoopInit(n ForLoopInit) {
+	w.enter()
+	switch n := n.(type) {
+	case nil:
+		w.uvarint(0)
+	case *Expression:
+		w.uvarint(1)
+		w.writeExpression(n)
+	case *VariableDeclaration:
+		w.uvarint(2)
+		w.writeVariableDeclaration(n)
+	default:
+		w.unknown("ForLoopInit", n)
+	}
+	w.depth--
+}
+func (r *binaryReader) readForLoopInit() (n ForLoopInit) {
+	r.enter()
+	switch r.uvarint() {
+	case 0:
+	case 1:
+		x := r.nodes.Expression.new()
+		r.readExpression(x)
+		n = x
+	case 2:
+		x := r.nodes.VariableDeclaration.new()
+		r.readVariableDeclaration(x)
+		n = x
+	default:
+		r.corrupt()
+	}
+	r.depth--
+	return n
+}
+func (w *binaryWriter) writeInto(n Into) {
+	w.enter()
+	switch n := n.(type) {
+	case nil:
+		w.uvarint(0)
+	case *Expression:
+		w.uvarint(1)
+		w.writeExpression(n)
+	case *VariableDeclaration:
+		w.uvarint(2)
+		w.writeVariableDeclaration(n)
+	default:
+		w.unknown("Into", n)
+	}
+	w.depth--
+}
+func (r *binaryReader) readInto() (n Into) {
+	r.enter()
+	switch r.uvarint() {
+	case 0:
+	case 1:
+		x := r.nodes.Expression.new()
+		r.readExpression(x)
+		n = x
+	case 2:
+		x := r.nodes.VariableDeclaration.new()
+		r.readVariableDeclaration(x)
+		n = x
+	default:
+		r.corrupt()
+	}
+	r.depth--
+	return n
+}
+func (w *binaryWriter) writeMemberProp(n MemberProp) {
+	w.enter()
+	switch n := n.(type) {
+	case nil:
+		w.uvarint(0)
+	case *ComputedProperty:
+		w.uvarint(1)
+		w.writeComputedProperty(n)
+	case *Identifier:
+		w.uvarint(2)
+		w.writeIdentifier(n)
+	default:
+		w.unknown("MemberProp", n)
+	}
+	w.depth--
+}
+func (r *binaryReader) readMemberProp() (n MemberProp) {
+	r.enter()
+	switch r.uvarint() {
+	case 0:
+	case 1:
+		x := r.nodes.ComputedProperty.new()
+		r.readComputedProperty(x)
+		n = x
+	case 2:
+		x := r.nodes.Identifier.new()
+		r.readIdentifier(x)
+		n = x
+	default:
+		r.corrupt()
+	}
+	r.depth--
+	return n
+}
+func (w *binaryWriter) writePattern(n Pattern) {
+	w.enter()
+	switch n := n.(type) {
+	case nil:
+		w.uvarint(0)
+	case *ArrayPattern:
+		w.uvarint(1)
+		w.writeArrayPattern(n)
+	case *ObjectPattern:
+		w.uvarint(2)
+		w.writeObjectPattern(n)
+	default:
+		w.unknown("Pattern", n)
+	}
+	w.depth--
+}
+func (r *binaryReader) readPattern() (n Pattern) {
+	r.enter()
+	switch r.uvarint() {
+	case 0:
+	case 1:
+		x := r.nodes.ArrayPattern.new()
+		r.readArrayPattern(x)
+		n = x
+	case 2:
+		x := r.nodes.ObjectPattern.new()
+		r.readObjectPattern(x)
+		n = x
+	default:
+		r.corrupt()
+	}
+	r.depth--
+	return n
+}
+func (w *binaryWriter) writeProp(n Prop) {
+	w.enter()
+	switch n := n.(type) {
+	case nil:
+		w.uvarint(0)
+	case *PropertyKeyed:
+		w.uvarint(1)
+		w.writePropertyKeyed(n)
+	case *PropertyShort:
+		w.uvarint(2)
+		w.writePropertyShort(n)
+	case *SpreadElement:
+		w.uvarint(3)
+		w.writeSpreadElement(n)
+	default:
+		w.unknown("Prop", n)
+	}
+	w.depth--
+}
+func (r *binaryReader) readProp() (n Prop) {
+	r.enter()
+	switch r.uvarint() {
+	case 0:
+	case 1:
+		x := r.nodes.PropertyKeyed.new()
+		r.readPropertyKeyed(x)
+		n = x
+	case 2:
+		x := r.nodes.PropertyShort.new()
+		r.readPropertyShort(x)
+		n = x
+	case 3:
+		x := r.nodes.SpreadElement.new()
+		r.readSpreadElement(x)
+		n = x
+	default:
+		r.corrupt()
+	}
+	r.depth--
+	return n
+}
+func (w *binaryWriter) writeStmt(n Stmt) {
+	w.enter()
+	switch n := n.(type) {
+	case nil:
+		w.uvarint(0)
+	case *BadStatement:
+		w.uvarint(1)
+		w.writeBadStatement(n)
+	case *BlockStatement:
+		w.uvarint(2)
+		w.writeBlockStatement(n)
+	case *BreakStatement:
+		w.uvarint(3)
+		w.writeBreakStatement(n)
+	case *CaseStatement:
+		w.uvarint(4)
+		w.writeCaseStatement(n)
+	case *CatchStatement:
+		w.uvarint(5)
+		w.writeCatchStatement(n)
+	case *ClassDeclaration:
+		w.uvarint(6)
+		w.writeClassDeclaration(n)
+	case *ContinueStatement:
+		w.uvarint(7)
+		w.writeContinueStatement(n)
+	case *DebuggerStatement:
+		w.uvarint(8)
+		w.writeDebuggerStatement(n)
+	case *DoWhileStatement:
+		w.uvarint(9)
+		w.writeDoWhileStatement(n)
+	case *EmptyStatement:
+		w.uvarint(10)
+		w.writeEmptyStatement(n)
+	case *ExpressionStatement:
+		w.uvarint(11)
+		w.writeExpressionStatement(n)
+	case *ForInStatement:
+		w.uvarint(12)
+		w.writeForInStatement(n)
+	case *ForOfStatement:
+		w.uvarint(13)
+		w.writeForOfStatement(n)
+	case *ForStatement:
+		w.uvarint(14)
+		w.writeForStatement(n)
+	case *FunctionDeclaration:
+		w.uvarint(15)
+		w.writeFunctionDeclaration(n)
+	case *IfStatement:
+		w.uvarint(16)
+		w.writeIfStatement(n)
+	case *LabelledStatement:
+		w.uvarint(17)
+		w.writeLabelledStatement(n)
+	case *ReturnStatement:
+		w.uvarint(18)
+		w.writeReturnStatement(n)
+	case *SwitchStatement:
+		w.uvarint(19)
+		w.writeSwitchStatement(n)
+	case *ThrowStatement:
+		w.uvarint(20)
+		w.writeThrowStatement(n)
+	case *TryStatement:
+		w.uvarint(21)
+		w.writeTryStatement(n)
+	case *VariableDeclaration:
+		w.uvarint(22)
+		w.writeVariableDeclaration(n)
+	case *WhileStatement:
+		w.uvarint(23)
+		w.writeWhileStatement(n)
+	case *WithStatement:
+		w.uvarint(24)
+		w.writeWithStatement(n)
+	default:
+		w.unknown("Stmt", n)
+	}
+	w.depth--
+}
+func (r *binaryReader) readStmt() (n Stmt) {
+	r.enter()
+	switch r.uvarint() {
+	case 0:
+	case 1:
+		x := r.nodes.BadStatement.new()
+		r.readBadStatement(x)
+		n = x
+	case 2:
+		x := r.nodes.BlockStatement.new()
+		r.readBlockStatement(x)
+		n = x
+	case 3:
+		x := r.nodes.BreakStatement.new()
+		r.readBreakStatement(x)
+		n = x
+	case 4:
+		x := r.nodes.CaseStatement.new()
+		r.readCaseStatement(x)
+		n = x
+	case 5:
+		x := r.nodes.CatchStatement.new()
+		r.readCatchStatement(x)
+		n = x
+	case 6:
+		x := r.nodes.ClassDeclaration.new()
+		r.readClassDeclaration(x)
+		n = x
+	case 7:
+		x := r.nodes.ContinueStatement.new()
+		r.readContinueStatement(x)
+		n = x
+	case 8:
+		x := r.nodes.DebuggerStatement.new()
+		r.readDebuggerStatement(x)
+		n = x
+	case 9:
+		x := r.nodes.DoWhileStatement.new()
+		r.readDoWhileStatement(x)
+		n = x
+	case 10:
+		x := r.nodes.EmptyStatement.new()
+		r.readEmptyStatement(x)
+		n = x
+	case 11:
+		x := r.nodes.ExpressionStatement.new()
+		r.readExpressionStatement(x)
+		n = x
+	case 12:
+		x := r.nodes.ForInStatement.new()
+		r.readForInStatement(x)
+		n = x
+	case 13:
+		x := r.nodes.ForOfStatement.new()
+		r.readForOfStatement(x)
+		n = x
+	case 14:
+		x := r.nodes.ForStatement.new()
+		r.readForStatement(x)
+		n = x
+	case 15:
+		x := r.nodes.FunctionDeclaration.new()
+		r.readFunctionDeclaration(x)
+		n = x
+	case 16:
+		x := r.nodes.IfStatement.new()
+		r.readIfStatement(x)
+		n = x
+	case 17:
+		x := r.nodes.LabelledStatement.new()
+		r.readLabelledStatement(x)
+		n = x
+	case 18:
+		x := r.nodes.ReturnStatement.new()
+		r.readReturnStatement(x)
+		n = x
+	case 19:
+		x := r.nodes.SwitchStatement.new()
+		r.readSwitchStatement(x)
+		n = x
+	case 20:
+		x := r.nodes.ThrowStatement.new()
+		r.readThrowStatement(x)
+		n = x
+	case 21:
+		x := r.nodes.TryStatement.new()
+		r.readTryStatement(x)
+		n = x
+	case 22:
+		x := r.nodes.VariableDeclaration.new()
+		r.readVariableDeclaration(x)
+		n = x
+	case 23:
+		x := r.nodes.WhileStatement.new()
+		r.readWhileStatement(x)
+		n = x
+	case 24:
+		x := r.nodes.WithStatement.new()
+		r.readWithStatement(x)
+		n = x
+	default:
+		r.corrupt()
+	}
+	r.depth--
+	return n
+}
+func (w *binaryWriter) writeTarget(n Target) {
+	w.enter()
+	switch n := n.(type) {
+	case nil:
+		w.uvarint(0)
+	case *ArrayPattern:
+		w.uvarint(1)
+		w.writeArrayPattern(n)
+	case *Identifier:
+		w.uvarint(2)
+		w.writeIdentifier(n)
+	case *InvalidExpression:
+		w.uvarint(3)
+		w.writeInvalidExpression(n)
+	case *MemberExpression:
+		w.uvarint(4)
+		w.writeMemberExpression(n)
+	case *ObjectPattern:
+		w.uvarint(5)
+		w.writeObjectPattern(n)
+	default:
+		w.unknown("Target", n)
+	}
+	w.depth--
+}
+func (r *binaryReader) readTarget() (n Target) {
+	r.enter()
+	switch r.uvarint() {
+	case 0:
+	case 1:
+		x := r.nodes.ArrayPattern.new()
+		r.readArrayPattern(x)
+		n = x
+	case 2:
+		x := r.nodes.Identifier.new()
+		r.readIdentifier(x)
+		n = x
+	case 3:
+		x := r.nodes.InvalidExpression.new()
+		r.readInvalidExpression(x)
+		n = x
+	case 4:
+		x := r.nodes.MemberExpression.new()
+		r.readMemberExpression(x)
+		n = x
+	case 5:
+		x := r.nodes.ObjectPattern.new()
+		r.readObjectPattern(x)
+		n = x
+	default:
+		r.corrupt()
+	}
+	r.depth--
+	return n
+}
@@ -0,0 +1,253 @@
+package ast
+
+import (
+	"math"
+	"math/big"
+)
+
+// EqualOptions configures Equal and Hash.
+type EqualOptions struct {
+	// IgnoreScopeContext compares identifiers by name alone, and ignores
+	// the scope contexts of functions and blocks.
+	IgnoreScopeContext bool
+	// AllowRenaming treats the bindings declared in the trees as equal if
+	// one tree consistently uses different names: a#2 may stand for b#5 as
+	// long as it does everywhere and nothing else stands for b#5. Other
+	// identifiers, such as globals, bindings declared outside the trees and
+	// property names, must match by name. Scope contexts of functions and
+	// blocks are ignored, since the identifiers already tie each use to its
+	// binding.
+	AllowRenaming bool
+}
+
+// Equal reports whether a and b are the same code: nodes of the same types
+// with the same children, operators and values. Positions, comments and the
+// source spelling of literals, such as 0x10 for 16, are ignored. Bodies
+// still skipped by lazy parsing are compared as text.
+func Equal(a, b VisitableNode, opts EqualOptions) bool {
+	e := &equaler{opts: opts}
+	if opts.AllowRenaming {
+		e.declared = [2]map[Id]bool{declarations(a), declarations(b)}
+	}
+	return e.equalNode(a, b)
+}
+
+// Hash returns a hash of n consistent with Equal under the same options:
+// nodes that are equal have the same hash.
+func Hash(n VisitableNode, opts EqualOptions) uint64 {
+	h := &hasher{opts: opts, sum: fnvOffset}
+	if opts.AllowRenaming {
+		h.declared = declarations(n)
+	}
+	h.hashNode(n)
+	return h.sum
+}
+
+type equaler struct {
+	opts EqualOptions
+	// renamed and renamedBack map the identifiers of a tree to those of the
+	// other when renaming is allowed.
+	renamed, renamedBack map[Id]Id
+	// declared holds the bindings declared in each tree when renaming is
+	// allowed.
+	declared [2]map[Id]bool
+}
+
+func (e *equaler) scope(a, b ScopeContext) bool {
+	return e.opts.IgnoreScopeContext || e.opts.AllowRenaming || a == b
+}
+
+func (e *equaler) equalIdentifier(a, b *Identifier) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if !e.opts.AllowRenaming {
+		return a.Name == b.Name && (e.opts.IgnoreScopeContext || a.ScopeContext == b.ScopeContext)
+	}
+	ia, ib := a.ToId(), b.ToId()
+	if da, db := e.declared[0][ia], e.declared[1][ib]; !da || !db {
+		return !da && !db && a.Name == b.Name
+	}
+	if id, ok := e.renamed[ia]; ok {
+		return id == ib
+	}
+	if _, ok := e.renamedBack[ib]; ok {
+		return false
+	}
+	if e.renamed == nil {
+		e.renamed, e.renamedBack = make(map[Id]Id), make(map[Id]Id)
+	}
+	e.renamed[ia], e.renamedBack[ib] = ib, ia
+	return true
+}
+
+func (e *equaler) bigInt(a, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
+
+func (e *equaler) lazyBody(a, b *LazyBody) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Source == b.Source
+}
+
+const (
+	fnvOffset = 14695981039346656037
+	fnvPrime  = 1099511628211
+)
+
+// hasher computes an FNV-1a hash of what Equal compares.
+type hasher struct {
+	opts EqualOptions
+	sum  uint64
+	// renamed numbers the declared bindings by first use when renaming is
+	// allowed, so that consistently renamed trees hash alike.
+	renamed  map[Id]int
+	declared map[Id]bool
+}
+
+func (h *hasher) u8(v uint8) {
+	h.sum ^= uint64(v)
+	h.sum *= fnvPrime
+}
+
+func (h *hasher) u64(v uint64) {
+	for i := 0; i < 64; i += 8 {
+		h.u8(uint8(v >> i))
+	}
+}
+
+func (h *hasher) int(v int) { h.u64(uint64(v)) }
+
+func (h *hasher) bool(v bool) {
+	if v {
+		h.u8(1)
+	} else {
+		h.u8(0)
+	}
+}
+
+// present hashes whether an optional value follows and returns ok.
+func (h *hasher) present(ok bool) bool {
+	h.bool(ok)
+	return ok
+}
+
+func (h *hasher) float(v float64) {
+	if v == 0 {
+		// -0 == 0.
+		v = 0
+	}
+	h.u64(math.Float64bits(v))
+}
+
+func (h *hasher) str(s string) {
+	h.int(len(s))
+	for i := 0; i < len(s); i++ {
+		h.u8(s[i])
+	}
+}
+
+func (h *hasher) scope(v ScopeContext) {
+	if !h.opts.IgnoreScopeContext && !h.opts.AllowRenaming {
+		h.int(int(v))
+	}
+}
+
+func (h *hasher) hashIdentifier(n *Identifier) {
+	if !h.present(n != nil) {
+		return
+	}
+	id := n.ToId()
+	if !h.opts.AllowRenaming || !h.declared[id] {
+		h.str(n.Name)
+		if !h.opts.IgnoreScopeContext && !h.opts.AllowRenaming {
+			h.int(int(n.ScopeContext))
+		}
+		return
+	}
+	i, ok := h.renamed[id]
+	if !ok {
+		if h.renamed == nil {
+			h.renamed = make(map[Id]int)
+		}
+		i = len(h.renamed)
+		h.renamed[id] = i
+	}
+	h.int(-1 - i)
+}
+
+// declarations returns the bindings declared in the tree n: the names of
+// variables, functions, classes and parameters, and the bindings of their
+// patterns, with the scope contexts the resolver gave them.
+func declarations(n VisitableNode) map[Id]bool {
+	declared := make(map[Id]bool)
+	if n == nil {
+		return declared
+	}
+	add := func(id *Identifier) {
+		if id != nil && id.ScopeContext != 0 {
+			declared[id.ToId()] = true
+		}
+	}
+	Inspect(n, func(n VisitableNode) bool {
+		switch n := n.(type) {
+		case *BindingTarget:
+			patternNames(n.Target, add)
+		case *ParameterList:
+			patternNames(n.Rest, add)
+		case *FunctionLiteral:
+			add(n.Name)
+		case *ClassLiteral:
+			add(n.Name)
+		}
+		return true
+	})
+	return declared
+}
+
+// patternNames calls f with the identifiers bound by the binding pattern e,
+// leaving out those of default values and computed keys.
+func patternNames(e Expr, f func(*Identifier)) {
+	switch e := e.(type) {
+	case *Identifier:
+		f(e)
+	case *ArrayPattern:
+		for _, el := range e.Elements {
+			patternNames(el.Expr, f)
+		}
+		if e.Rest != nil {
+			patternNames(e.Rest.Expr, f)
+		}
+	case *ObjectPattern:
+		for _, p := range e.Properties {
+			switch p := p.Prop.(type) {
+			case *PropertyShort:
+				f(p.Name)
+			case *PropertyKeyed:
+				patternNames(p.Value.Expr, f)
+			case *SpreadElement:
+				patternNames(p.Expression.Expr, f)
+			}
+		}
+		patternNames(e.Rest, f)
+	case *AssignExpression:
+		patternNames(e.Left.Expr, f)
+	}
+}
+
+func (h *hasher) bigInt(v *big.Int) {
+	if h.present(v != nil) {
+		h.str(v.Text(16))
+	}
+}
+
+func (h *hasher) lazyBody(v *LazyBody) {
+	if h.present(v != nil) {
+		h.str(v.Source)
+	}
+}
@@ -0,0 +1,82 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/resolver"
+)
+
+func TestEqual(t *testing.T) {
+	var (
+		exact    = ast.EqualOptions{}
+		ignore   = ast.EqualOptions{IgnoreScopeContext: true}
+		renaming = ast.EqualOptions{AllowRenaming: true}
+	)
+	tests := []struct {
+		// src holds the two statements to compare.
+		src  string
+		opts ast.EqualOptions
+		want bool
+	}{
+		{"a + 0x10; a  +  16;", exact, true},
+		{"'a'; \"a\";", exact, true},
+		{"a + b; a - b;", exact, false},
+		{"a.b; a.c;", exact, false},
+		{"f(a, b); f(a);", exact, false},
+		{"x => x; x => { return x; };", ignore, false},
+		{"if (a) b; if (a) b; else c;", exact, false},
+
+		{"(function (x) { return x; }); (function (x) { return x; });", exact, false},
+		{"(function (x) { return x; }); (function (x) { return x; });", ignore, true},
+		{"(function (x) { return x; }); (function (y) { return y; });", ignore, false},
+		{"(function (x) { return x; }); (function (y) { return y; });", renaming, true},
+		{"(function (x, y) { return x; }); (function (x, y) { return y; });", renaming, false},
+		{"(function (x, y) { return x + y; }); (function (a, b) { return b + a; });", renaming, false},
+		{"(function (x, y) { return x + x; }); (function (a, b) { return a + b; });", renaming, false},
+		{"(function (x) { return x.a; }); (function (y) { return y.b; });", renaming, false},
+		{"(function (x) { let y = x; return y; }); (function (p) { let q = p; return q; });", renaming, true},
+		{"alert(document.cookie); eval(document.cookie);", renaming, false},
+		{"(function () { return a; }); (function () { return b; });", renaming, false},
+		{"(function () { return a; }); (function () { return a; });", renaming, true},
+		{"(function (a) { return a; }); (function () { return a; });", renaming, false},
+		{"var a = f(); var b = f();", renaming, true},
+		{"var a = f(); var b = g();", renaming, false},
+		{"(function ({a, b: [c = d]}) { return c; }); (function ({a, b: [e = d]}) { return e; });", renaming, true},
+		{"(function ({a, b: [c = d]}) { return c; }); (function ({a, b: [e = c]}) { return e; });", renaming, false},
+	}
+	for _, tt := range tests {
+		program := parse(t, tt.src)
+		resolver.Resolve(program)
+		if len(program.Body) != 2 {
+			t.Fatalf("%q: parsed %d statements; want 2", tt.src, len(program.Body))
+		}
+		a, b := program.Body[0].Stmt, program.Body[1].Stmt
+		if got := ast.Equal(a, b, tt.opts); got != tt.want {
+			t.Errorf("%q: Equal(%+v) = %v; want %v", tt.src, tt.opts, got, tt.want)
+		}
+		if got := ast.Equal(b, a, tt.opts); got != tt.want {
+			t.Errorf("%q: reversed Equal(%+v) = %v; want %v", tt.src, tt.opts, got, tt.want)
+		}
+		ha, hb := ast.Hash(a, tt.opts), ast.Hash(b, tt.opts)
+		if tt.want && ha != hb {
+			t.Errorf("%q: Hash(%+v) differs for equal statements", tt.src, tt.opts)
+		}
+		if !tt.want && ha == hb {
+			t.Errorf("%q: Hash(%+v) is the same for different statements", tt.src, tt.opts)
+		}
+	}
+}
+
+func TestEqualTypes(t *testing.T) {
+	program := parse(t, "a;")
+	if ast.Equal(program, &program.Body, ast.EqualOptions{}) {
+		t.Error("a program equals its statement list")
+	}
+	if !ast.Equal(program, program.Clone(), ast.EqualOptions{}) {
+		t.Error("a program does not equal its clone")
+	}
+	if !ast.Equal(nil, nil, ast.EqualOptions{}) {
+		t.Error("nil does not equal nil")
+	}
+}
@@ -0,0 +1,197 @@
+package ast
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DumpOptions configures Dump.
+type DumpOptions struct {
+	// Source, if set, is the source the tree was parsed from. Spans are then
+	// printed as line:column pairs, both counted from 1, rather than as
+	// byte offsets.
+	Source string
+	// CollapseWrappers prints the node held by a wrapper such as Expression,
+	// Statement or BindingTarget in place of the wrapper.
+	CollapseWrappers bool
+}
+
+// Dump writes the tree rooted at n to w, one node per line and indented by
+// depth, for debugging. Each line holds the field the node is found in, its
+// type, its span and its scalar fields, such as names, values, operators
+// and scope contexts; nil fields are printed unless they are optional.
+//
+//	Program 1:1-1:5
+//	  Body: Statements [1]
+//	    [0]: Statement 1:1-1:5
+//	      Stmt: ExpressionStatement 1:1-1:5
+//	        Expression: Expression 1:1-1:5
+//	          Expr: CallExpression 1:1-1:5
+//	            Callee: Expression 1:1-1:2
+//	              Expr: Identifier 1:1-1:2 Name="f" ScopeContext=1
+//	            ...
+func Dump(w io.Writer, n VisitableNode, opts DumpOptions) error {
+	d := &dumper{w: w, opts: opts}
+	if opts.Source != "" {
+		d.lines = []int{0}
+		for i := 0; i < len(opts.Source); i++ {
+			if opts.Source[i] == '\n' {
+				d.lines = append(d.lines, i+1)
+			}
+		}
+	}
+	d.value("", reflect.ValueOf(n), 0)
+	return d.err
+}
+
+type dumper struct {
+	w    io.Writer
+	opts DumpOptions
+	// lines holds the offset at which each line of the source starts.
+	lines []int
+	err   error
+}
+
+var (
+	idxType          = reflect.TypeFor[Idx]()
+	scopeContextType = reflect.TypeFor[ScopeContext]()
+	nodeType         = reflect.TypeFor[Node]()
+	stringerType     = reflect.TypeFor[fmt.Stringer]()
+	lazyBodyType     = reflect.TypeFor[LazyBody]()
+)
+
+func (d *dumper) printf(depth int, format string, args ...any) {
+	if d.err != nil {
+		return
+	}
+	_, d.err = fmt.Fprintf(d.w, "%s"+format+"\n", append([]any{strings.Repeat("  ", depth)}, args...)...)
+}
+
+// value dumps v, found in the field label, at depth.
+func (d *dumper) value(label string, v reflect.Value, depth int) {
+	if v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			d.printf(depth, "%snil", label)
+			return
+		}
+		if v.Kind() == reflect.Interface {
+			v = v.Elem()
+		}
+	}
+	ptr := v
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if d.opts.CollapseWrappers && isWrapper(v.Type()) {
+		d.value(label, v.Field(0), depth)
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Slice:
+		d.printf(depth, "%s%s [%d]", label, v.Type().Name(), v.Len())
+		for i := range v.Len() {
+			d.value("["+strconv.Itoa(i)+"]: ", v.Index(i).Addr(), depth+1)
+		}
+		return
+	case reflect.Struct:
+	default:
+		d.printf(depth, "%s%s", label, scalar(v))
+		return
+	}
+	if v.Type() == lazyBodyType {
+		d.printf(depth, "%sLazyBody (%d bytes unparsed)", label, len(v.FieldByName("Source").String()))
+		return
+	}
+
+	line := label + v.Type().Name()
+	if ptr.Type().Implements(nodeType) {
+		line += " " + d.span(ptr.Interface().(Node))
+	}
+	var children []int
+	for i := range v.NumField() {
+		f := v.Type().Field(i)
+		fv := v.Field(i)
+		switch {
+		case !f.IsExported() || f.Type == idxType:
+		case f.Type == scopeContextType:
+			line += fmt.Sprintf(" %s=%v", f.Name, fv.Interface())
+		case isScalar(f.Type):
+			if !fv.IsZero() {
+				line += fmt.Sprintf(" %s=%s", f.Name, scalar(fv))
+			}
+		case (fv.Kind() == reflect.Pointer || fv.Kind() == reflect.Interface) && fv.IsNil() && f.Tag.Get("optional") == "true":
+		default:
+			children = append(children, i)
+		}
+	}
+	d.printf(depth, "%s", line)
+	for _, i := range children {
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Slice || fv.Kind() == reflect.Struct {
+			fv = fv.Addr()
+		}
+		d.value(v.Type().Field(i).Name+": ", fv, depth+1)
+	}
+}
+
+// span formats the span of n, or ? if the tree is too broken to find it.
+func (d *dumper) span(n Node) (s string) {
+	defer func() {
+		if recover() != nil {
+			s = "?"
+		}
+	}()
+	return d.position(n.Idx0()) + "-" + d.position(n.Idx1())
+}
+
+func (d *dumper) position(idx Idx) string {
+	if d.lines == nil {
+		return strconv.Itoa(int(idx))
+	}
+	line := sort.SearchInts(d.lines, int(idx)+1) - 1
+	return strconv.Itoa(line+1) + ":" + strconv.Itoa(int(idx)-d.lines[line]+1)
+}
+
+// isScalar reports whether values of t are printed on the line of their
+// node.
+func isScalar(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	case reflect.Pointer:
+		return t.Elem().Kind() == reflect.String || t == reflect.TypeFor[*big.Int]()
+	}
+	return false
+}
+
+func scalar(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "nil"
+		}
+		if v.Type().Elem().Kind() == reflect.String {
+			v = v.Elem()
+		}
+	}
+	switch {
+	case v.Type().Implements(stringerType):
+		return strconv.Quote(v.Interface().(fmt.Stringer).String())
+	case v.Kind() == reflect.String:
+		return strconv.Quote(v.String())
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// isWrapper reports whether t is a struct holding only an interface, such
+// as Expression.
+func isWrapper(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.NumField() == 1 && t.Field(0).Type.Kind() == reflect.Interface
+}
@@ -0,0 +1,76 @@
+package ast_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/resolver"
+)
+
+func TestDump(t *testing.T) {
+	src := "let x = 1;\nf(x);"
+	program := parse(t, src)
+	resolver.Resolve(program)
+	tests := []struct {
+		opts ast.DumpOptions
+		want string
+	}{
+		{ast.DumpOptions{Source: src, CollapseWrappers: true}, `
+Program 1:1-2:5
+  Body: Statements [2]
+    [0]: VariableDeclaration 1:1-1:10 Token="let"
+      List: VariableDeclarators [1]
+        [0]: VariableDeclarator 1:5-1:10
+          Target: Identifier 1:5-1:6 Name="x" ScopeContext=1
+          Initializer: NumberLiteral 1:9-1:10 Value=1 Raw="1"
+    [1]: ExpressionStatement 2:1-2:5
+      Expression: CallExpression 2:1-2:5
+        Callee: Identifier 2:1-2:2 Name="f" ScopeContext=1
+        ArgumentList: Expressions [1]
+          [0]: Identifier 2:3-2:4 Name="x" ScopeContext=1
+`},
+		{ast.DumpOptions{}, `
+Program 0-15
+  Body: Statements [2]
+    [0]: Statement 0-9
+      Stmt: VariableDeclaration 0-9 Token="let"
+        List: VariableDeclarators [1]
+          [0]: VariableDeclarator 4-9
+            Target: BindingTarget 4-5
+              Target: Identifier 4-5 Name="x" ScopeContext=1
+            Initializer: Expression 8-9
+              Expr: NumberLiteral 8-9 Value=1 Raw="1"
+    [1]: Statement 11-15
+      Stmt: ExpressionStatement 11-15
+        Expression: Expression 11-15
+          Expr: CallExpression 11-15
+            Callee: Expression 11-12
+              Expr: Identifier 11-12 Name="f" ScopeContext=1
+            ArgumentList: Expressions [1]
+              [0]: Expression 13-14
+                Expr: Identifier 13-14 Name="x" ScopeContext=1
+`},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		if err := ast.Dump(&b, program, tt.opts); err != nil {
+			t.Fatal(err)
+		}
+		if got := "\n" + b.String(); got != tt.want {
+			t.Errorf("Dump(%+v) =%s\nwant%s", tt.opts, got, tt.want)
+		}
+	}
+}
+
+func TestDumpBroken(t *testing.T) {
+	var b strings.Builder
+	n := &ast.ExpressionStatement{Expression: &ast.Expression{Expr: &ast.SequenceExpression{}}}
+	if err := ast.Dump(&b, n, ast.DumpOptions{CollapseWrappers: true}); err != nil {
+		t.Fatal(err)
+	}
+	want := "ExpressionStatement ?\n  Expression: SequenceExpression ?\n    Sequence: Expressions [0]\n"
+	if b.String() != want {
+		t.Errorf("Dump of an empty sequence =\n%s\nwant\n%s", b.String(), want)
+	}
+}
@@ -0,0 +1,2390 @@
+// Code generated by gen_equal.go; DO NOT EDIT.
+
+package ast
+
+func (e *equaler) equalNode(a, b VisitableNode) bool {
+	switch a := a.(type) {
+	case *ArrayLiteral:
+		b, ok := b.(*ArrayLiteral)
+		return ok && e.equalArrayLiteral(a, b)
+	case *ArrayPattern:
+		b, ok := b.(*ArrayPattern)
+		return ok && e.equalArrayPattern(a, b)
+	case *ArrowFunctionLiteral:
+		b, ok := b.(*ArrowFunctionLiteral)
+		return ok && e.equalArrowFunctionLiteral(a, b)
+	case *AssignExpression:
+		b, ok := b.(*AssignExpression)
+		return ok && e.equalAssignExpression(a, b)
+	case *AwaitExpression:
+		b, ok := b.(*AwaitExpression)
+		return ok && e.equalAwaitExpression(a, b)
+	case *BadStatement:
+		b, ok := b.(*BadStatement)
+		return ok && e.equalBadStatement(a, b)
+	case *BigIntLiteral:
+		b, ok := b.(*BigIntLiteral)
+		return ok && e.equalBigIntLiteral(a, b)
+	case *BinaryExpression:
+		b, ok := b.(*BinaryExpression)
+		return ok && e.equalBinaryExpression(a, b)
+	case *BindingTarget:
+		b, ok := b.(*BindingTarget)
+		return ok && e.equalBindingTarget(a, b)
+	case *BlockStatement:
+		b, ok := b.(*BlockStatement)
+		return ok && e.equalBlockStatement(a, b)
+	case *BooleanLiteral:
+		b, ok := b.(*BooleanLiteral)
+		return ok && e.equalBooleanLiteral(a, b)
+	case *BreakStatement:
+		b, ok := b.(*BreakStatement)
+		return ok && e.equalBreakStatement(a, b)
+	case *CallExpression:
+		b, ok := b.(*CallExpression)
+		return ok && e.equalCallExpression(a, b)
+	case *CaseStatement:
+		b, ok := b.(*CaseStatement)
+		return ok && e.equalCaseStatement(a, b)
+	case *CaseStatements:
+		b, ok := b.(*CaseStatements)
+		if !ok || a == nil || b == nil {
+			return ok && a == b
+		}
+		return e.equalCaseStatements(*a, *b)
+	case *CatchStatement:
+		b, ok := b.(*CatchStatement)
+		return ok && e.equalCatchStatement(a, b)
+	case *ClassDeclaration:
+		b, ok := b.(*ClassDeclaration)
+		return ok && e.equalClassDeclaration(a, b)
+	case *ClassElement:
+		b, ok := b.(*ClassElement)
+		return ok && e.equalClassElement(a, b)
+	case *ClassElements:
+		b, ok := b.(*ClassElements)
+		if !ok || a == nil || b == nil {
+			return ok && a == b
+		}
+		return e.equalClassElements(*a, *b)
+	case *ClassLiteral:
+		b, ok := b.(*ClassLiteral)
+		return ok && e.equalClassLiteral(a, b)
+	case *ClassStaticBlock:
+		b, ok := b.(*ClassStaticBlock)
+		return ok && e.equalClassStaticBlock(a, b)
+	case *ComputedProperty:
+		b, ok := b.(*ComputedProperty)
+		return ok && e.equalComputedProperty(a, b)
+	case *ConciseBody:
+		b, ok := b.(*ConciseBody)
+		return ok && e.equalConciseBody(a, b)
+	case *ConditionalExpression:
+		b, ok := b.(*ConditionalExpression)
+		return ok && e.equalConditionalExpression(a, b)
+	case *ContinueStatement:
+		b, ok := b.(*ContinueStatement)
+		return ok && e.equalContinueStatement(a, b)
+	case *DebuggerStatement:
+		b, ok := b.(*DebuggerStatement)
+		return ok && e.equalDebuggerStatement(a, b)
+	case *DoWhileStatement:
+		b, ok := b.(*DoWhileStatement)
+		return ok && e.equalDoWhileStatement(a, b)
+	case *EmptyStatement:
+		b, ok := b.(*EmptyStatement)
+		return ok && e.equalEmptyStatement(a, b)
+	case *Expression:
+		b, ok := b.(*Expression)
+		return ok && e.equalExpression(a, b)
+	case *ExpressionStatement:
+		b, ok := b.(*ExpressionStatement)
+		return ok && e.equalExpressionStatement(a, b)
+	case *Expressions:
+		b, ok := b.(*Expressions)
+		if !ok || a == nil || b == nil {
+			return ok && a == b
+		}
+		return e.equalExpressions(*a, *b)
+	case *FieldDefinition:
+		b, ok := b.(*FieldDefinition)
+		return ok && e.equalFieldDefinition(a, b)
+	case *ForInStatement:
+		b, ok := b.(*ForInStatement)
+		return ok && e.equalForInStatement(a, b)
+	case *ForInto:
+		b, ok := b.(*ForInto)
+		return ok && e.equalForInto(a, b)
+	case *ForLoopInitializer:
+		b, ok := b.(*ForLoopInitializer)
+		return ok && e.equalForLoopInitializer(a, b)
+	case *ForOfStatement:
+		b, ok := b.(*ForOfStatement)
+		return ok && e.equalForOfStatement(a, b)
+	case *ForStatement:
+		b, ok := b.(*ForStatement)
+		return ok && e.equalForStatement(a, b)
+	case *FunctionDeclaration:
+		b, ok := b.(*FunctionDeclaration)
+		return ok && e.equalFunctionDeclaration(a, b)
+	case *FunctionLiteral:
+		b, ok := b.(*FunctionLiteral)
+		return ok && e.equalFunctionLiteral(a, b)
+	case *Identifier:
+		b, ok := b.(*Identifier)
+		return ok && e.equalIdentifier(a, b)
+	case *IfStatement:
+		b, ok := b.(*IfStatement)
+		return ok && e.equalIfStatement(a, b)
+	case *InvalidExpression:
+		b, ok := b.(*InvalidExpression)
+		return ok && e.equalInvalidExpression(a, b)
+	case *LabelledStatement:
+		b, ok := b.(*LabelledStatement)
+		return ok && e.equalLabelledStatement(a, b)
+	case *LogicalExpression:
+		b, ok := b.(*LogicalExpression)
+		return ok && e.equalLogicalExpression(a, b)
+	case *MemberExpression:
+		b, ok := b.(*MemberExpression)
+		return ok && e.equalMemberExpression(a, b)
+	case *MemberProperty:
+		b, ok := b.(*MemberProperty)
+		return ok && e.equalMemberProperty(a, b)
+	case *MetaProperty:
+		b, ok := b.(*MetaProperty)
+		return ok && e.equalMetaProperty(a, b)
+	case *MethodDefinition:
+		b, ok := b.(*MethodDefinition)
+		return ok && e.equalMethodDefinition(a, b)
+	case *NewExpression:
+		b, ok := b.(*NewExpression)
+		return ok && e.equalNewExpression(a, b)
+	case *NullLiteral:
+		b, ok := b.(*NullLiteral)
+		return ok && e.equalNullLiteral(a, b)
+	case *NumberLiteral:
+		b, ok := b.(*NumberLiteral)
+		return ok && e.equalNumberLiteral(a, b)
+	case *ObjectLiteral:
+		b, ok := b.(*ObjectLiteral)
+		return ok && e.equalObjectLiteral(a, b)
+	case *ObjectPattern:
+		b, ok := b.(*ObjectPattern)
+		return ok && e.equalObjectPattern(a, b)
+	case *Optional:
+		b, ok := b.(*Optional)
+		return ok && e.equalOptional(a, b)
+	case *OptionalChain:
+		b, ok := b.(*OptionalChain)
+		return ok && e.equalOptionalChain(a, b)
+	case *ParameterList:
+		b, ok := b.(*ParameterList)
+		return ok && e.equalParameterList(a, b)
+	case *PrivateDotExpression:
+		b, ok := b.(*PrivateDotExpression)
+		return ok && e.equalPrivateDotExpression(a, b)
+	case *PrivateIdentifier:
+		b, ok := b.(*PrivateIdentifier)
+		return ok && e.equalPrivateIdentifier(a, b)
+	case *Program:
+		b, ok := b.(*Program)
+		return ok && e.equalProgram(a, b)
+	case *Properties:
+		b, ok := b.(*Properties)
+		if !ok || a == nil || b == nil {
+			return ok && a == b
+		}
+		return e.equalProperties(*a, *b)
+	case *Property:
+		b, ok := b.(*Property)
+		return ok && e.equalProperty(a, b)
+	case *PropertyKeyed:
+		b, ok := b.(*PropertyKeyed)
+		return ok && e.equalPropertyKeyed(a, b)
+	case *PropertyShort:
+		b, ok := b.(*PropertyShort)
+		return ok && e.equalPropertyShort(a, b)
+	case *RegExpLiteral:
+		b, ok := b.(*RegExpLiteral)
+		return ok && e.equalRegExpLiteral(a, b)
+	case *ReturnStatement:
+		b, ok := b.(*ReturnStatement)
+		return ok && e.equalReturnStatement(a, b)
+	case *SequenceExpression:
+		b, ok := b.(*SequenceExpression)
+		return ok && e.equalSequenceExpression(a, b)
+	case *SpreadElement:
+		b, ok := b.(*SpreadElement)
+		return ok && e.equalSpreadElement(a, b)
+	case *Statement:
+		b, ok := b.(*Statement)
+		return ok && e.equalStatement(a, b)
+	case *Statements:
+		b, ok := b.(*Statements)
+		if !ok || a == nil || b == nil {
+			return ok && a == b
+		}
+		return e.equalStatements(*a, *b)
+	case *StringLiteral:
+		b, ok := b.(*StringLiteral)
+		return ok && e.equalStringLiteral(a, b)
+	case *SuperExpression:
+		b, ok := b.(*SuperExpression)
+		return ok && e.equalSuperExpression(a, b)
+	case *SwitchStatement:
+		b, ok := b.(*SwitchStatement)
+		return ok && e.equalSwitchStatement(a, b)
+	case *TemplateElement:
+		b, ok := b.(*TemplateElement)
+		return ok && e.equalTemplateElement(a, b)
+	case *TemplateElements:
+		b, ok := b.(*TemplateElements)
+		if !ok || a == nil || b == nil {
+			return ok && a == b
+		}
+		return e.equalTemplateElements(*a, *b)
+	case *TemplateLiteral:
+		b, ok := b.(*TemplateLiteral)
+		return ok && e.equalTemplateLiteral(a, b)
+	case *ThisExpression:
+		b, ok := b.(*ThisExpression)
+		return ok && e.equalThisExpression(a, b)
+	case *ThrowStatement:
+		b, ok := b.(*ThrowStatement)
+		return ok && e.equalThrowStatement(a, b)
+	case *TryStatement:
+		b, ok := b.(*TryStatement)
+		return ok && e.equalTryStatement(a, b)
+	case *UnaryExpression:
+		b, ok := b.(*UnaryExpression)
+		return ok && e.equalUnaryExpression(a, b)
+	case *UpdateExpression:
+		b, ok := b.(*UpdateExpression)
+		return ok && e.equalUpdateExpression(a, b)
+	case *VariableDeclaration:
+		b, ok := b.(*VariableDeclaration)
+		return ok && e.equalVariableDeclaration(a, b)
+	case *VariableDeclarator:
+		b, ok := b.(*VariableDeclarator)
+		return ok && e.equalVariableDeclarator(a, b)
+	case *VariableDeclarators:
+		b, ok := b.(*VariableDeclarators)
+		if !ok || a == nil || b == nil {
+			return ok && a == b
+		}
+		return e.equalVariableDeclarators(*a, *b)
+	case *WhileStatement:
+		b, ok := b.(*WhileStatement)
+		return ok && e.equalWhileStatement(a, b)
+	case *WithStatement:
+		b, ok := b.(*WithStatement)
+		return ok && e.equalWithStatement(a, b)
+	case *YieldExpression:
+		b, ok := b.(*YieldExpression)
+		return ok && e.equalYieldExpression(a, b)
+	}
+	return a == nil && b == nil
+}
+
+func (h *hasher) hashNode(n VisitableNode) {
+	switch n := n.(type) {
+	case *ArrayLiteral:
+		h.int(1)
+		h.hashArrayLiteral(n)
+	case *ArrayPattern:
+		h.int(2)
+		h.hashArrayPattern(n)
+	case *ArrowFunctionLiteral:
+		h.int(3)
+		h.hashArrowFunctionLiteral(n)
+	case *AssignExpression:
+		h.int(4)
+		h.hashAssignExpression(n)
+	case *AwaitExpression:
+		h.int(5)
+		h.hashAwaitExpression(n)
+	case *BadStatement:
+		h.int(6)
+		h.hashBadStatement(n)
+	case *BigIntLiteral:
+		h.int(7)
+		h.hashBigIntLiteral(n)
+	case *BinaryExpression:
+		h.int(8)
+		h.hashBinaryExpression(n)
+	case *BindingTarget:
+		h.int(9)
+		h.hashBindingTarget(n)
+	case *BlockStatement:
+		h.int(10)
+		h.hashBlockStatement(n)
+	case *BooleanLiteral:
+		h.int(11)
+		h.hashBooleanLiteral(n)
+	case *BreakStatement:
+		h.int(12)
+		h.hashBreakStatement(n)
+	case *CallExpression:
+		h.int(13)
+		h.hashCallExpression(n)
+	case *CaseStatement:
+		h.int(14)
+		h.hashCaseStatement(n)
+	case *CaseStatements:
+		h.int(15)
+		if h.present(n != nil) {
+			h.hashCaseStatements(*n)
+		}
+	case *CatchStatement:
+		h.int(16)
+		h.hashCatchStatement(n)
+	case *ClassDeclaration:
+		h.int(17)
+		h.hashClassDeclaration(n)
+	case *ClassElement:
+		h.int(18)
+		h.hashClassElement(n)
+	case *ClassElements:
+		h.int(19)
+		if h.present(n != nil) {
+			h.hashClassElements(*n)
+		}
+	case *ClassLiteral:
+		h.int(20)
+		h.hashClassLiteral(n)
+	case *ClassStaticBlock:
+		h.int(21)
+		h.hashClassStaticBlock(n)
+	case *ComputedProperty:
+		h.int(22)
+		h.hashComputedProperty(n)
+	case *ConciseBody:
+		h.int(23)
+		h.hashConciseBody(n)
+	case *ConditionalExpression:
+		h.int(24)
+		h.hashConditionalExpression(n)
+	case *ContinueStatement:
+		h.int(25)
+		h.hashContinueStatement(n)
+	case *DebuggerStatement:
+		h.int(26)
+		h.hashDebuggerStatement(n)
+	case *DoWhileStatement:
+		h.int(27)
+		h.hashDoWhileStatement(n)
+	case *EmptyStatement:
+		h.int(28)
+		h.hashEmptyStatement(n)
+	case *Expression:
+		h.int(29)
+		h.hashExpression(n)
+	case *ExpressionStatement:
+		h.int(30)
+		h.hashExpressionStatement(n)
+	case *Expressions:
+		h.int(31)
+		if h.present(n != nil) {
+			h.hashExpressions(*n)
+		}
+	case *FieldDefinition:
+		h.int(32)
+		h.hashFieldDefinition(n)
+	case *ForInStatement:
+		h.int(33)
+		h.hashForInStatement(n)
+	case *ForInto:
+		h.int(34)
+		h.hashForInto(n)
+	case *ForLoopInitializer:
+		h.int(35)
+		h.hashForLoopInitializer(n)
+	case *ForOfStatement:
+		h.int(36)
+		h.hashForOfStatement(n)
+	case *ForStatement:
+		h.int(37)
+		h.hashForStatement(n)
+	case *FunctionDeclaration:
+		h.int(38)
+		h.hashFunctionDeclaration(n)
+	case *FunctionLiteral:
+		h.int(39)
+		h.hashFunctionLiteral(n)
+	case *Identifier:
+		h.int(40)
+		h.hashIdentifier(n)
+	case *IfStatement:
+		h.int(41)
+		h.hashIfStatement(n)
+	case *InvalidExpression:
+		h.int(42)
+		h.hashInvalidExpression(n)
+	case *LabelledStatement:
+		h.int(43)
+		h.hashLabelledStatement(n)
+	case *LogicalExpression:
+		h.int(44)
+		h.hashLogicalExpression(n)
+	case *MemberExpression:
+		h.int(45)
+		h.hashMemberExpression(n)
+	case *MemberProperty:
+		h.int(46)
+		h.hashMemberProperty(n)
+	case *MetaProperty:
+		h.int(47)
+		h.hashMetaProperty(n)
+	case *MethodDefinition:
+		h.int(48)
+		h.hashMethodDefinition(n)
+	case *NewExpression:
+		h.int(49)
+		h.hashNewExpression(n)
+	case *NullLiteral:
+		h.int(50)
+		h.hashNullLiteral(n)
+	case *NumberLiteral:
+		h.int(51)
+		h.hashNumberLiteral(n)
+	case *ObjectLiteral:
+		h.int(52)
+		h.hashObjectLiteral(n)
+	case *ObjectPattern:
+		h.int(53)
+		h.hashObjectPattern(n)
+	case *Optional:
+		h.int(54)
+		h.hashOptional(n)
+	case *OptionalChain:
+		h.int(55)
+		h.hashOptionalChain(n)
+	case *ParameterList:
+		h.int(56)
+		h.hashParameterList(n)
+	case *PrivateDotExpression:
+		h.int(57)
+		h.hashPrivateDotExpression(n)
+	case *PrivateIdentifier:
+		h.int(58)
+		h.hashPrivateIdentifier(n)
+	case *Program:
+		h.int(59)
+		h.hashProgram(n)
+	case *Properties:
+		h.int(60)
+		if h.present(n != nil) {
+			h.hashProperties(*n)
+		}
+	case *Property:
+		h.int(61)
+		h.hashProperty(n)
+	case *PropertyKeyed:
+		h.int(62)
+		h.hashPropertyKeyed(n)
+	case *PropertyShort:
+		h.int(63)
+		h.hashPropertyShort(n)
+	case *RegExpLiteral:
+		h.int(64)
+		h.hashRegExpLiteral(n)
+	case *ReturnStatement:
+		h.int(65)
+		h.hashReturnStatement(n)
+	case *SequenceExpression:
+		h.int(66)
+		h.hashSequenceExpression(n)
+	case *SpreadElement:
+		h.int(67)
+		h.hashSpreadElement(n)
+	case *Statement:
+		h.int(68)
+		h.hashStatement(n)
+	case *Statements:
+		h.int(69)
+		if h.present(n != nil) {
+			h.hashStatements(*n)
+		}
+	case *StringLiteral:
+		h.int(70)
+		h.hashStringLiteral(n)
+	case *SuperExpression:
+		h.int(71)
+		h.hashSuperExpression(n)
+	case *SwitchStatement:
+		h.int(72)
+		h.hashSwitchStatement(n)
+	case *TemplateElement:
+		h.int(73)
+		h.hashTemplateElement(n)
+	case *TemplateElements:
+		h.int(74)
+		if h.present(n != nil) {
+			h.hashTemplateElements(*n)
+		}
+	case *TemplateLiteral:
+		h.int(75)
+		h.hashTemplateLiteral(n)
+	case *ThisExpression:
+		h.int(76)
+		h.hashThisExpression(n)
+	case *ThrowStatement:
+		h.int(77)
+		h.hashThrowStatement(n)
+	case *TryStatement:
+		h.int(78)
+		h.hashTryStatement(n)
+	case *UnaryExpression:
+		h.int(79)
+		h.hashUnaryExpression(n)
+	case *UpdateExpression:
+		h.int(80)
+		h.hashUpdateExpression(n)
+	case *VariableDeclaration:
+		h.int(81)
+		h.hashVariableDeclaration(n)
+	case *VariableDeclarator:
+		h.int(82)
+		h.hashVariableDeclarator(n)
+	case *VariableDeclarators:
+		h.int(83)
+		if h.present(n != nil) {
+			h.hashVariableDeclarators(*n)
+		}
+	case *WhileStatement:
+		h.int(84)
+		h.hashWhileStatement(n)
+	case *WithStatement:
+		h.int(85)
+		h.hashWithStatement(n)
+	case *YieldExpression:
+		h.int(86)
+		h.hashYieldExpression(n)
+	default:
+		h.int(0)
+	}
+}
+
+func (e *equaler) equalArrayLiteral(a, b *ArrayLiteral) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpressions(a.Value, b.Value)
+}
+func (h *hasher) hashArrayLiteral(n *ArrayLiteral) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpressions(n.Value)
+}
+func (e *equaler) equalArrayPattern(a, b *ArrayPattern) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpressions(a.Elements, b.Elements) &&
+		e.equalExpression(a.Rest, b.Rest)
+}
+func (h *hasher) hashArrayPattern(n *ArrayPattern) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpressions(n.Elements)
+	h.hashExpression(n.Rest)
+}
+func (e *equaler) equalArrowFunctionLiteral(a, b *ArrowFunctionLiteral) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalParameterList(a.ParameterList, b.ParameterList) &&
+		e.equalConciseBody(a.Body, b.Body) &&
+		e.scope(a.ScopeContext, b.ScopeContext) &&
+		a.Async == b.Async
+}
+func (h *hasher) hashArrowFunctionLiteral(n *ArrowFunctionLiteral) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashParameterList(n.ParameterList)
+	h.hashConciseBody(n.Body)
+	h.scope(n.ScopeContext)
+	h.bool(n.Async)
+}
+func (e *equaler) equalAssignExpression(a, b *AssignExpression) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Left, b.Left) &&
+		e.equalExpression(a.Right, b.Right) &&
+		a.Operator == b.Operator
+}
+func (h *hasher) hashAssignExpression(n *AssignExpression) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Left)
+	h.hashExpression(n.Right)
+	h.u64(uint64(n.Operator))
+}
+func (e *equaler) equalAwaitExpression(a, b *AwaitExpression) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Argument, b.Argument)
+}
+func (h *hasher) hashAwaitExpression(n *AwaitExpression) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Argument)
+}
+func (e *equaler) equalBadStatement(a, b *BadStatement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return true
+}
+func (h *hasher) hashBadStatement(n *BadStatement) {
+	if !h.present(n != nil) {
+		return
+	}
+}
+func (e *equaler) equalBigIntLiteral(a, b *BigIntLiteral) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.bigInt(a.Value, b.Value)
+}
+func (h *hasher) hashBigIntLiteral(n *BigIntLiteral) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.bigInt(n.Value)
+}
+func (e *equaler) equalBinaryExpression(a, b *BinaryExpression) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Left, b.Left) &&
+		e.equalExpression(a.Right, b.Right) &&
+		a.Operator == b.Operator
+}
+func (h *hasher) hashBinaryExpression(n *BinaryExpression) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Left)
+	h.hashExpression(n.Right)
+	h.u64(uint64(n.Operator))
+}
+func (e *equaler) equalBindingTarget(a, b *BindingTarget) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalTarget(a.Target, b.Target)
+}
+func (h *hasher) hashBindingTarget(n *BindingTarget) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashTarget(n.Target)
+}
+func (e *equaler) equalBlockStatement(a, b *BlockStatement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalStatements(a.List, b.List) &&
+		e.scope(a.ScopeContext, b.ScopeContext)
+}
+func (h *hasher) hashBlockStatement(n *BlockStatement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashStatements(n.List)
+	h.scope(n.ScopeContext)
+}
+func (e *equaler) equalBooleanLiteral(a, b *BooleanLiteral) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Value == b.Value
+}
+func (h *hasher) hashBooleanLiteral(n *BooleanLiteral) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.bool(n.Value)
+}
+func (e *equaler) equalBreakStatement(a, b *BreakStatement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalIdentifier(a.Label, b.Label)
+}
+func (h *hasher) hashBreakStatement(n *BreakStatement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashIdentifier(n.Label)
+}
+func (e *equaler) equalCallExpression(a, b *CallExpression) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Callee, b.Callee) &&
+		e.equalExpressions(a.ArgumentList, b.ArgumentList)
+}
+func (h *hasher) hashCallExpression(n *CallExpression) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Callee)
+	h.hashExpressions(n.ArgumentList)
+}
+func (e *equaler) equalCaseStatement(a, b *CaseStatement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Test, b.Test) &&
+		e.equalStatements(a.Consequent, b.Consequent)
+}
+func (h *hasher) hashCaseStatement(n *CaseStatement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Test)
+	h.hashStatements(n.Consequent)
+}
+func (e *equaler) equalCaseStatements(a, b CaseStatements) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !e.equalCaseStatement(&a[i], &b[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (h *hasher) hashCaseStatements(n CaseStatements) {
+	h.int(len(n))
+	for i := range n {
+		h.hashCaseStatement(&n[i])
+	}
+}
+func (e *equaler) equalCatchStatement(a, b *CatchStatement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalBindingTarget(a.Parameter, b.Parameter) &&
+		e.equalBlockStatement(a.Body, b.Body)
+}
+func (h *hasher) hashCatchStatement(n *CatchStatement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashBindingTarget(n.Parameter)
+	h.hashBlockStatement(n.Body)
+}
+func (e *equaler) equalClassDeclaration(a, b *ClassDeclaration) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalClassLiteral(a.Class, b.Class)
+}
+func (h *hasher) hashClassDeclaration(n *ClassDeclaration) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashClassLiteral(n.Class)
+}
+func (e *equaler) equalClassElement(a, b *ClassElement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalElement(a.Element, b.Element)
+}
+func (h *hasher) hashClassElement(n *ClassElement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashElement(n.Element)
+}
+func (e *equaler) equalClassElements(a, b ClassElements) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !e.equalClassElement(&a[i], &b[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (h *hasher) hashClassElements(n ClassElements) {
+	h.int(len(n))
+	for i := range n {
+		h.hashClassElement(&n[i])
+	}
+}
+func (e *equaler) equalClassLiteral(a, b *ClassLiteral) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalIdentifier(a.Name, b.Name) &&
+		e.equalExpression(a.SuperClass, b.SuperClass) &&
+		e.equalClassElements(a.Body, b.Body)
+}
+func (h *hasher) hashClassLiteral(n *ClassLiteral) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashIdentifier(n.Name)
+	h.hashExpression(n.SuperClass)
+	h.hashClassElements(n.Body)
+}
+func (e *equaler) equalClassStaticBlock(a, b *ClassStaticBlock) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalBlockStatement(a.Block, b.Block)
+}
+func (h *hasher) hashClassStaticBlock(n *ClassStaticBlock) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashBlockStatement(n.Block)
+}
+func (e *equaler) equalComputedProperty(a, b *ComputedProperty) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Expr, b.Expr)
+}
+func (h *hasher) hashComputedProperty(n *ComputedProperty) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Expr)
+}
+func (e *equaler) equalConciseBody(a, b *ConciseBody) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalBody(a.Body, b.Body)
+}
+func (h *hasher) hashConciseBody(n *ConciseBody) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashBody(n.Body)
+}
+func (e *equaler) equalConditionalExpression(a, b *ConditionalExpression) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Test, b.Test) &&
+		e.equalExpression(a.Consequent, b.Consequent) &&
+		e.equalExpression(a.Alternate, b.Alternate)
+}
+func (h *hasher) hashConditionalExpression(n *ConditionalExpression) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Test)
+	h.hashExpression(n.Consequent)
+	h.hashExpression(n.Alternate)
+}
+func (e *equaler) equalContinueStatement(a, b *ContinueStatement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalIdentifier(a.Label, b.Label)
+}
+func (h *hasher) hashContinueStatement(n *ContinueStatement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashIdentifier(n.Label)
+}
+func (e *equaler) equalDebuggerStatement(a, b *DebuggerStatement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return true
+}
+func (h *hasher) hashDebuggerStatement(n *DebuggerStatement) {
+	if !h.present(n != nil) {
+		return
+	}
+}
+func (e *equaler) equalDoWhileStatement(a, b *DoWhileStatement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Test, b.Test) &&
+		e.equalStatement(a.Body, b.Body)
+}
+func (h *hasher) hashDoWhileStatement(n *DoWhileStatement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Test)
+	h.hashStatement(n.Body)
+}
+func (e *equaler) equalEmptyStatement(a, b *EmptyStatement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return true
+}
+func (h *hasher) hashEmptyStatement(n *EmptyStatement) {
+	if !h.present(n != nil) {
+		return
+	}
+}
+func (e *equaler) equalExpression(a, b *Expression) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpr(a.Expr, b.Expr)
+}
+func (h *hasher) hashExpression(n *Expression) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpr(n.Expr)
+}
+func (e *equaler) equalExpressionStatement(a, b *ExpressionStatement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Expression, b.Expression)
+}
+func (h *hasher) hashExpressionStatement(n *ExpressionStatement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Expression)
+}
+func (e *equaler) equalExpressions(a, b Expressions) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !e.equalExpression(&a[i], &b[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (h *hasher) hashExpressions(n Expressions) {
+	h.int(len(n))
+	for i := range n {
+		h.hashExpression(&n[i])
+	}
+}
+func (e *equaler) equalFieldDefinition(a, b *FieldDefinition) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Key, b.Key) &&
+		e.equalExpression(a.Initializer, b.Initializer) &&
+		a.Computed == b.Computed &&
+		a.Static == b.Static
+}
+func (h *hasher) hashFieldDefinition(n *FieldDefinition) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Key)
+	h.hashExpression(n.Initializer)
+	h.bool(n.Computed)
+	h.bool(n.Static)
+}
+func (e *equaler) equalForInStatement(a, b *ForInStatement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalForInto(a.Into, b.Into) &&
+		e.equalExpression(a.Source, b.Source) &&
+		e.equalStatement(a.Body, b.Body)
+}
+func (h *hasher) hashForInStatement(n *ForInStatement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashForInto(n.Into)
+	h.hashExpression(n.Source)
+	h.hashStatement(n.Body)
+}
+func (e *equaler) equalForInto(a, b *ForInto) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalInto(a.Into, b.Into)
+}
+func (h *hasher) hashForInto(n *ForInto) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashInto(n.Into)
+}
+func (e *equaler) equalForLoopInitializer(a, b *ForLoopInitializer) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalForLoopInit(a.Initializer, b.Initializer)
+}
+func (h *hasher) hashForLoopInitializer(n *ForLoopInitializer) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashForLoopInit(n.Initializer)
+}
+func (e *equaler) equalForOfStatement(a, b *ForOfStatement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalForInto(a.Into, b.Into) &&
+		e.equalExpression(a.Source, b.Source) &&
+		e.equalStatement(a.Body, b.Body) &&
+		a.Await == b.Await
+}
+func (h *hasher) hashForOfStatement(n *ForOfStatement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashForInto(n.Into)
+	h.hashExpression(n.Source)
+	h.hashStatement(n.Body)
+	h.bool(n.Await)
+}
+func (e *equaler) equalForStatement(a, b *ForStatement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalForLoopInitializer(a.Initializer, b.Initializer) &&
+		e.equalExpression(a.Update, b.Update) &&
+		e.equalExpression(a.Test, b.Test) &&
+		e.equalStatement(a.Body, b.Body)
+}
+func (h *hasher) hashForStatement(n *ForStatement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashForLoopInitializer(n.Initializer)
+	h.hashExpression(n.Update)
+	h.hashExpression(n.Test)
+	h.hashStatement(n.Body)
+}
+func (e *equaler) equalFunctionDeclaration(a, b *FunctionDeclaration) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalFunctionLiteral(a.Function, b.Function)
+}
+func (h *hasher) hashFunctionDeclaration(n *FunctionDeclaration) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashFunctionLiteral(n.Function)
+}
+func (e *equaler) equalFunctionLiteral(a, b *FunctionLiteral) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalIdentifier(a.Name, b.Name) &&
+		e.equalParameterList(a.ParameterList, b.ParameterList) &&
+		e.equalBlockStatement(a.Body, b.Body) &&
+		e.scope(a.ScopeContext, b.ScopeContext) &&
+		a.Async == b.Async &&
+		a.Generator == b.Generator &&
+		e.lazyBody(a.Lazy, b.Lazy)
+}
+func (h *hasher) hashFunctionLiteral(n *FunctionLiteral) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashIdentifier(n.Name)
+	h.hashParameterList(n.ParameterList)
+	h.hashBlockStatement(n.Body)
+	h.scope(n.ScopeContext)
+	h.bool(n.Async)
+	h.bool(n.Generator)
+	h.lazyBody(n.Lazy)
+}
+func (e *equaler) equalIfStatement(a, b *IfStatement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Test, b.Test) &&
+		e.equalStatement(a.Consequent, b.Consequent) &&
+		e.equalStatement(a.Alternate, b.Alternate)
+}
+func (h *hasher) hashIfStatement(n *IfStatement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Test)
+	h.hashStatement(n.Consequent)
+	h.hashStatement(n.Alternate)
+}
+func (e *equaler) equalInvalidExpression(a, b *InvalidExpression) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return true
+}
+func (h *hasher) hashInvalidExpression(n *InvalidExpression) {
+	if !h.present(n != nil) {
+		return
+	}
+}
+func (e *equaler) equalLabelledStatement(a, b *LabelledStatement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalIdentifier(a.Label, b.Label) &&
+		e.equalStatement(a.Statement, b.Statement)
+}
+func (h *hasher) hashLabelledStatement(n *LabelledStatement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashIdentifier(n.Label)
+	h.hashStatement(n.Statement)
+}
+func (e *equaler) equalLogicalExpression(a, b *LogicalExpression) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Left, b.Left) &&
+		e.equalExpression(a.Right, b.Right) &&
+		a.Operator == b.Operator
+}
+func (h *hasher) hashLogicalExpression(n *LogicalExpression) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Left)
+	h.hashExpression(n.Right)
+	h.u64(uint64(n.Operator))
+}
+func (e *equaler) equalMemberExpression(a, b *MemberExpression) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Object, b.Object) &&
+		e.equalMemberProperty(a.Property, b.Property)
+}
+func (h *hasher) hashMemberExpression(n *MemberExpression) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Object)
+	h.hashMemberProperty(n.Property)
+}
+func (e *equaler) equalMemberProperty(a, b *MemberProperty) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalMemberProp(a.Prop, b.Prop)
+}
+func (h *hasher) hashMemberProperty(n *MemberProperty) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashMemberProp(n.Prop)
+}
+func (e *equaler) equalMetaProperty(a, b *MetaProperty) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalIdentifier(a.Meta, b.Meta) &&
+		e.equalIdentifier(a.Property, b.Property)
+}
+func (h *hasher) hashMetaProperty(n *MetaProperty) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashIdentifier(n.Meta)
+	h.hashIdentifier(n.Property)
+}
+func (e *equaler) equalMethodDefinition(a, b *MethodDefinition) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Key, b.Key) &&
+		a.Kind == b.Kind &&
+		e.equalFunctionLiteral(a.Body, b.Body) &&
+		a.Computed == b.Computed &&
+		a.Static == b.Static
+}
+func (h *hasher) hashMethodDefinition(n *MethodDefinition) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Key)
+	h.str(string(n.Kind))
+	h.hashFunctionLiteral(n.Body)
+	h.bool(n.Computed)
+	h.bool(n.Static)
+}
+func (e *equaler) equalNewExpression(a, b *NewExpression) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Callee, b.Callee) &&
+		e.equalExpressions(a.ArgumentList, b.ArgumentList)
+}
+func (h *hasher) hashNewExpression(n *NewExpression) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Callee)
+	h.hashExpressions(n.ArgumentList)
+}
+func (e *equaler) equalNullLiteral(a, b *NullLiteral) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return true
+}
+func (h *hasher) hashNullLiteral(n *NullLiteral) {
+	if !h.present(n != nil) {
+		return
+	}
+}
+func (e *equaler) equalNumberLiteral(a, b *NumberLiteral) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Value == b.Value
+}
+func (h *hasher) hashNumberLiteral(n *NumberLiteral) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.float(n.Value)
+}
+func (e *equaler) equalObjectLiteral(a, b *ObjectLiteral) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalProperties(a.Value, b.Value)
+}
+func (h *hasher) hashObjectLiteral(n *ObjectLiteral) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashProperties(n.Value)
+}
+func (e *equaler) equalObjectPattern(a, b *ObjectPattern) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalProperties(a.Properties, b.Properties) &&
+		e.equalExpr(a.Rest, b.Rest)
+}
+func (h *hasher) hashObjectPattern(n *ObjectPattern) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashProperties(n.Properties)
+	h.hashExpr(n.Rest)
+}
+func (e *equaler) equalOptional(a, b *Optional) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Expr, b.Expr)
+}
+func (h *hasher) hashOptional(n *Optional) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Expr)
+}
+func (e *equaler) equalOptionalChain(a, b *OptionalChain) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Base, b.Base)
+}
+func (h *hasher) hashOptionalChain(n *OptionalChain) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Base)
+}
+func (e *equaler) equalParameterList(a, b *ParameterList) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalVariableDeclarators(a.List, b.List) &&
+		e.equalExpr(a.Rest, b.Rest)
+}
+func (h *hasher) hashParameterList(n *ParameterList) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashVariableDeclarators(n.List)
+	h.hashExpr(n.Rest)
+}
+func (e *equaler) equalPrivateDotExpression(a, b *PrivateDotExpression) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Left, b.Left) &&
+		e.equalPrivateIdentifier(a.Identifier, b.Identifier)
+}
+func (h *hasher) hashPrivateDotExpression(n *PrivateDotExpression) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Left)
+	h.hashPrivateIdentifier(n.Identifier)
+}
+func (e *equaler) equalPrivateIdentifier(a, b *PrivateIdentifier) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalIdentifier(a.Identifier, b.Identifier)
+}
+func (h *hasher) hashPrivateIdentifier(n *PrivateIdentifier) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashIdentifier(n.Identifier)
+}
+func (e *equaler) equalProgram(a, b *Program) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalStatements(a.Body, b.Body)
+}
+func (h *hasher) hashProgram(n *Program) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashStatements(n.Body)
+}
+func (e *equaler) equalProperties(a, b Properties) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !e.equalProperty(&a[i], &b[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (h *hasher) hashProperties(n Properties) {
+	h.int(len(n))
+	for i := range n {
+		h.hashProperty(&n[i])
+	}
+}
+func (e *equaler) equalProperty(a, b *Property) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalProp(a.Prop, b.Prop)
+}
+func (h *hasher) hashProperty(n *Property) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashProp(n.Prop)
+}
+func (e *equaler) equalPropertyKeyed(a, b *PropertyKeyed) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Key, b.Key) &&
+		a.Kind == b.Kind &&
+		e.equalExpression(a.Value, b.Value) &&
+		a.Computed == b.Computed
+}
+func (h *hasher) hashPropertyKeyed(n *PropertyKeyed) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Key)
+	h.str(string(n.Kind))
+	h.hashExpression(n.Value)
+	h.bool(n.Computed)
+}
+func (e *equaler) equalPropertyShort(a, b *PropertyShort) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalIdentifier(a.Name, b.Name) &&
+		e.equalExpression(a.Initializer, b.Initializer)
+}
+func (h *hasher) hashPropertyShort(n *PropertyShort) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashIdentifier(n.Name)
+	h.hashExpression(n.Initializer)
+}
+func (e *equaler) equalRegExpLiteral(a, b *RegExpLiteral) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Literal == b.Literal &&
+		a.Pattern == b.Pattern &&
+		a.Flags == b.Flags
+}
+func (h *hasher) hashRegExpLiteral(n *RegExpLiteral) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.str(n.Literal)
+	h.str(n.Pattern)
+	h.str(n.Flags)
+}
+func (e *equaler) equalReturnStatement(a, b *ReturnStatement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Argument, b.Argument)
+}
+func (h *hasher) hashReturnStatement(n *ReturnStatement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Argument)
+}
+func (e *equaler) equalSequenceExpression(a, b *SequenceExpression) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpressions(a.Sequence, b.Sequence)
+}
+func (h *hasher) hashSequenceExpression(n *SequenceExpression) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpressions(n.Sequence)
+}
+func (e *equaler) equalSpreadElement(a, b *SpreadElement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Expression, b.Expression)
+}
+func (h *hasher) hashSpreadElement(n *SpreadElement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Expression)
+}
+func (e *equaler) equalStatement(a, b *Statement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalStmt(a.Stmt, b.Stmt)
+}
+func (h *hasher) hashStatement(n *Statement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashStmt(n.Stmt)
+}
+func (e *equaler) equalStatements(a, b Statements) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !e.equalStatement(&a[i], &b[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (h *hasher) hashStatements(n Statements) {
+	h.int(len(n))
+	for i := range n {
+		h.hashStatement(&n[i])
+	}
+}
+func (e *equaler) equalStringLiteral(a, b *StringLiteral) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Value == b.Value
+}
+func (h *hasher) hashStringLiteral(n *StringLiteral) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.str(n.Value)
+}
+func (e *equaler) equalSuperExpression(a, b *SuperExpression) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return true
+}
+func (h *hasher) hashSuperExpression(n *SuperExpression) {
+	if !h.present(n != nil) {
+		return
+	}
+}
+func (e *equaler) equalSwitchStatement(a, b *SwitchStatement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Discriminant, b.Discriminant) &&
+		a.Default == b.Default &&
+		e.equalCaseStatements(a.Body, b.Body)
+}
+func (h *hasher) hashSwitchStatement(n *SwitchStatement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Discriminant)
+	h.int(n.Default)
+	h.hashCaseStatements(n.Body)
+}
+func (e *equaler) equalTemplateElement(a, b *TemplateElement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Literal == b.Literal &&
+		a.Parsed == b.Parsed
+}
+func (h *hasher) hashTemplateElement(n *TemplateElement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.str(n.Literal)
+	h.str(n.Parsed)
+}
+func (e *equaler) equalTemplateElements(a, b TemplateElements) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !e.equalTemplateElement(&a[i], &b[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (h *hasher) hashTemplateElements(n TemplateElements) {
+	h.int(len(n))
+	for i := range n {
+		h.hashTemplateElement(&n[i])
+	}
+}
+func (e *equaler) equalTemplateLiteral(a, b *TemplateLiteral) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Tag, b.Tag) &&
+		e.equalTemplateElements(a.Elements, b.Elements) &&
+		e.equalExpressions(a.Expressions, b.Expressions)
+}
+func (h *hasher) hashTemplateLiteral(n *TemplateLiteral) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Tag)
+	h.hashTemplateElements(n.Elements)
+	h.hashExpressions(n.Expressions)
+}
+func (e *equaler) equalThisExpression(a, b *ThisExpression) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return true
+}
+func (h *hasher) hashThisExpression(n *ThisExpression) {
+	if !h.present(n != nil) {
+		return
+	}
+}
+func (e *equaler) equalThrowStatement(a, b *ThrowStatement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Argument, b.Argument)
+}
+func (h *hasher) hashThrowStatement(n *ThrowStatement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Argument)
+}
+func (e *equaler) equalTryStatement(a, b *TryStatement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalBlockStatement(a.Body, b.Body) &&
+		e.equalCatchStatement(a.Catch, b.Catch) &&
+		e.equalBlockStatement(a.Finally, b.Finally)
+}
+func (h *hasher) hashTryStatement(n *TryStatement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashBlockStatement(n.Body)
+	h.hashCatchStatement(n.Catch)
+	h.hashBlockStatement(n.Finally)
+}
+func (e *equaler) equalUnaryExpression(a, b *UnaryExpression) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Operand, b.Operand) &&
+		a.Operator == b.Operator
+}
+func (h *hasher) hashUnaryExpression(n *UnaryExpression) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Operand)
+	h.u64(uint64(n.Operator))
+}
+func (e *equaler) equalUpdateExpression(a, b *UpdateExpression) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Operand, b.Operand) &&
+		a.Operator == b.Operator &&
+		a.Postfix == b.Postfix
+}
+func (h *hasher) hashUpdateExpression(n *UpdateExpression) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Operand)
+	h.u64(uint64(n.Operator))
+	h.bool(n.Postfix)
+}
+func (e *equaler) equalVariableDeclaration(a, b *VariableDeclaration) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Token == b.Token &&
+		e.equalVariableDeclarators(a.List, b.List)
+}
+func (h *hasher) hashVariableDeclaration(n *VariableDeclaration) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.u64(uint64(n.Token))
+	h.hashVariableDeclarators(n.List)
+}
+func (e *equaler) equalVariableDeclarator(a, b *VariableDeclarator) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalBindingTarget(a.Target, b.Target) &&
+		e.equalExpression(a.Initializer, b.Initializer)
+}
+func (h *hasher) hashVariableDeclarator(n *VariableDeclarator) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashBindingTarget(n.Target)
+	h.hashExpression(n.Initializer)
+}
+func (e *equaler) equalVariableDeclarators(a, b VariableDeclarators) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !e.equalVariableDeclarator(&a[i], &b[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (h *hasher) hashVariableDeclarators(n VariableDeclarators) {
+	h.int(len(n))
+	for i := range n {
+		h.hashVariableDeclarator(&n[i])
+	}
+}
+func (e *equaler) equalWhileStatement(a, b *WhileStatement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Test, b.Test) &&
+		e.equalStatement(a.Body, b.Body)
+}
+func (h *hasher) hashWhileStatement(n *WhileStatement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Test)
+	h.hashStatement(n.Body)
+}
+func (e *equaler) equalWithStatement(a, b *WithStatement) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Object, b.Object) &&
+		e.equalStatement(a.Body, b.Body)
+}
+func (h *hasher) hashWithStatement(n *WithStatement) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Object)
+	h.hashStatement(n.Body)
+}
+func (e *equaler) equalYieldExpression(a, b *YieldExpression) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return e.equalExpression(a.Argument, b.Argument) &&
+		a.Delegate == b.Delegate
+}
+func (h *hasher) hashYieldExpression(n *YieldExpression) {
+	if !h.present(n != nil) {
+		return
+	}
+	h.hashExpression(n.Argument)
+	h.bool(n.Delegate)
+}
+func (e *equaler) equalBody(a, b Body) bool {
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+	case *BlockStatement:
+		b, ok := b.(*BlockStatement)
+		return ok && e.equalBlockStatement(a, b)
+	case *Expression:
+		b, ok := b.(*Expression)
+		return ok && e.equalExpression(a, b)
+	}
+	return false
+}
+func (h *hasher) hashBody(n Body) {
+	switch n := n.(type) {
+	case nil:
+		h.int(0)
+	case *BlockStatement:
+		h.int(1)
+		h.hashBlockStatement(n)
+	case *Expression:
+		h.int(2)
+		h.hashExpression(n)
+	}
+}
+func (e *equaler) equalElement(a, b Element) bool {
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+	case *ClassStaticBlock:
+		b, ok := b.(*ClassStaticBlock)
+		return ok && e.equalClassStaticBlock(a, b)
+	case *FieldDefinition:
+		b, ok := b.(*FieldDefinition)
+		return ok && e.equalFieldDefinition(a, b)
+	case *MethodDefinition:
+		b, ok := b.(*MethodDefinition)
+		return ok && e.equalMethodDefinition(a, b)
+	}
+	return false
+}
+func (h *hasher) hashElement(n Element) {
+	switch n := n.(type) {
+	case nil:
+		h.int(0)
+	case *ClassStaticBlock:
+		h.int(1)
+		h.hashClassStaticBlock(n)
+	case *FieldDefinition:
+		h.int(2)
+		h.hashFieldDefinition(n)
+	case *MethodDefinition:
+		h.int(3)
+		h.hashMethodDefinition(n)
+	}
+}
+func (e *equaler) equalExpr(a, b Expr) bool {
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+	case *ArrayLiteral:
+		b, ok := b.(*ArrayLiteral)
+		return ok && e.equalArrayLiteral(a, b)
+	case *ArrayPattern:
+		b, ok := b.(*ArrayPattern)
+		return ok && e.equalArrayPattern(a, b)
+	case *ArrowFunctionLiteral:
+		b, ok := b.(*ArrowFunctionLiteral)
+		return ok && e.equalArrowFunctionLiteral(a, b)
+	case *AssignExpression:
+		b, ok := b.(*AssignExpression)
+		return ok && e.equalAssignExpression(a, b)
+	case *AwaitExpression:
+		b, ok := b.(*AwaitExpression)
+		return ok && e.equalAwaitExpression(a, b)
+	case *BigIntLiteral:
+		b, ok := b.(*BigIntLiteral)
+		return ok && e.equalBigIntLiteral(a, b)
+	case *BinaryExpression:
+		b, ok := b.(*BinaryExpression)
+		return ok && e.equalBinaryExpression(a, b)
+	case *BooleanLiteral:
+		b, ok := b.(*BooleanLiteral)
+		return ok && e.equalBooleanLiteral(a, b)
+	case *CallExpression:
+		b, ok := b.(*CallExpression)
+		return ok && e.equalCallExpression(a, b)
+	case *ClassLiteral:
+		b, ok := b.(*ClassLiteral)
+		return ok && e.equalClassLiteral(a, b)
+	case *ConditionalExpression:
+		b, ok := b.(*ConditionalExpression)
+		return ok && e.equalConditionalExpression(a, b)
+	case *FunctionLiteral:
+		b, ok := b.(*FunctionLiteral)
+		return ok && e.equalFunctionLiteral(a, b)
+	case *Identifier:
+		b, ok := b.(*Identifier)
+		return ok && e.equalIdentifier(a, b)
+	case *InvalidExpression:
+		b, ok := b.(*InvalidExpression)
+		return ok && e.equalInvalidExpression(a, b)
+	case *LogicalExpression:
+		b, ok := b.(*LogicalExpression)
+		return ok && e.equalLogicalExpression(a, b)
+	case *MemberExpression:
+		b, ok := b.(*MemberExpression)
+		return ok && e.equalMemberExpression(a, b)
+	case *MetaProperty:
+		b, ok := b.(*MetaProperty)
+		return ok && e.equalMetaProperty(a, b)
+	case *NewExpression:
+		b, ok := b.(*NewExpression)
+		return ok && e.equalNewExpression(a, b)
+	case *NullLiteral:
+		b, ok := b.(*NullLiteral)
+		return ok && e.equalNullLiteral(a, b)
+	case *NumberLiteral:
+		b, ok := b.(*NumberLiteral)
+		return ok && e.equalNumberLiteral(a, b)
+	case *ObjectLiteral:
+		b, ok := b.(*ObjectLiteral)
+		return ok && e.equalObjectLiteral(a, b)
+	case *ObjectPattern:
+		b, ok := b.(*ObjectPattern)
+		return ok && e.equalObjectPattern(a, b)
+	case *Optional:
+		b, ok := b.(*Optional)
+		return ok && e.equalOptional(a, b)
+	case *OptionalChain:
+		b, ok := b.(*OptionalChain)
+		return ok && e.equalOptionalChain(a, b)
+	case *PrivateDotExpression:
+		b, ok := b.(*PrivateDotExpression)
+		return ok && e.equalPrivateDotExpression(a, b)
+	case *PrivateIdentifier:
+		b, ok := b.(*PrivateIdentifier)
+		return ok && e.equalPrivateIdentifier(a, b)
+	case *PropertyKeyed:
+		b, ok := b.(*PropertyKeyed)
+		return ok && e.equalPropertyKeyed(a, b)
+	case *PropertyShort:
+		b, ok := b.(*PropertyShort)
+		return ok && e.equalPropertyShort(a, b)
+	case *RegExpLiteral:
+		b, ok := b.(*RegExpLiteral)
+		return ok && e.equalRegExpLiteral(a, b)
+	case *SequenceExpression:
+		b, ok := b.(*SequenceExpression)
+		return ok && e.equalSequenceExpression(a, b)
+	case *SpreadElement:
+		b, ok := b.(*SpreadElement)
+		return ok && e.equalSpreadElement(a, b)
+	case *StringLiteral:
+		b, ok := b.(*StringLiteral)
+		return ok && e.equalStringLiteral(a, b)
+	case *SuperExpression:
+		b, ok := b.(*SuperExpression)
+		return ok && e.equalSuperExpression(a, b)
+	case *TemplateLiteral:
+		b, ok := b.(*TemplateLiteral)
+		return ok && e.equalTemplateLiteral(a, b)
+	case *ThisExpression:
+		b, ok := b.(*ThisExpression)
+		return ok && e.equalThisExpression(a, b)
+	case *UnaryExpression:
+		b, ok := b.(*UnaryExpression)
+		return ok && e.equalUnaryExpression(a, b)
+	case *UpdateExpression:
+		b, ok := b.(*UpdateExpression)
+		return ok && e.equalUpdateExpression(a, b)
+	case *VariableDeclarator:
+		b, ok := b.(*VariableDeclarator)
+		return ok && e.equalVariableDeclarator(a, b)
+	case *YieldExpression:
+		b, ok := b.(*YieldExpression)
+		return ok && e.equalYieldExpression(a, b)
+	}
+	return false
+}
+func (h *hasher) hashExpr(n Expr) {
+	switch n := n.(type) {
+	case nil:
+		h.int(0)
+	case *ArrayLiteral:
+		h.int(1)
+		h.hashArrayLiteral(n)
+	case *ArrayPattern:
+		h.int(2)
+		h.hashArrayPattern(n)
+	case *ArrowFunctionLiteral:
+		h.int(3)
+		h.hashArrowFunctionLiteral(n)
+	case *AssignExpression:
+		h.int(4)
+		h.hashAssignExpression(n)
+	case *AwaitExpression:
+		h.int(5)
+		h.hashAwaitExpression(n)
+	case *BigIntLiteral:
+		h.int(6)
+		h.hashBigIntLiteral(n)
+	case *BinaryExpression:
+		h.int(7)
+		h.hashBinaryExpression(n)
+	case *BooleanLiteral:
+		h.int(8)
+		h.hashBooleanLiteral(n)
+	case *CallExpression:
+		h.int(9)
+		h.hashCallExpression(n)
+	case *ClassLiteral:
+		h.int(10)
+		h.hashClassLiteral(n)
+	case *ConditionalExpression:
+		h.int(11)
+		h.hashConditionalExpression(n)
+	case *FunctionLiteral:
+		h.int(12)
+		h.hashFunctionLiteral(n)
+	case *Identifier:
+		h.int(13)
+		h.hashIdentifier(n)
+	case *InvalidExpression:
+		h.int(14)
+		h.hashInvalidExpression(n)
+	case *LogicalExpression:
+		h.int(15)
+		h.hashLogicalExpression(n)
+	case *MemberExpression:
+		h.int(16)
+		h.hashMemberExpression(n)
+	case *MetaProperty:
+		h.int(17)
+		h.hashMetaProperty(n)
+	case *NewExpression:
+		h.int(18)
+		h.hashNewExpression(n)
+	case *NullLiteral:
+		h.int(19)
+		h.hashNullLiteral(n)
+	case *NumberLiteral:
+		h.int(20)
+		h.hashNumberLiteral(n)
+	case *ObjectLiteral:
+		h.int(21)
+		h.hashObjectLiteral(n)
+	case *ObjectPattern:
+		h.int(22)
+		h.hashObjectPattern(n)
+	case *Optional:
+		h.int(23)
+		h.hashOptional(n)
+	case *OptionalChain:
+		h.int(24)
+		h.hashOptionalChain(n)
+	case *PrivateDotExpression:
+		h.int(25)
+		h.hashPrivateDotExpression(n)
+	case *PrivateIdentifier:
+		h.int(26)
+		h.hashPrivateIdentifier(n)
+	case *PropertyKeyed:
+		h.int(27)
+		h.hashPropertyKeyed(n)
+	case *PropertyShort:
+		h.int(28)
+		h.hashPropertyShort(n)
+	case *RegExpLiteral:
+		h.int(29)
+		h.hashRegExpLiteral(n)
+	case *SequenceExpression:
+		h.int(30)
+		h.hashSequenceExpression(n)
+	case *SpreadElement:
+		h.int(31)
+		h.hashSpreadElement(n)
+	case *StringLiteral:
+		h.int(32)
+		h.hashStringLiteral(n)
+	case *SuperExpression:
+		h.int(33)
+		h.hashSuperExpression(n)
+	case *TemplateLiteral:
+		h.int(34)
+		h.hashTemplateLiteral(n)
+	case *ThisExpression:
+		h.int(35)
+		h.hashThisExpression(n)
+	case *UnaryExpression:
+		h.int(36)
+		h.hashUnaryExpression(n)
+	case *UpdateExpression:
+		h.int(37)
+		h.hashUpdateExpression(n)
+	case *VariableDeclarator:
+		h.int(38)
+		h.hashVariableDeclarator(n)
+	case *YieldExpression:
+		h.int(39)
+		h.hashYieldExpression(n)
+	}
+}
+func (e *equaler) equalForLoopInit(a, b ForLoopInit) bool {
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+	case *Expression:
+		b, ok := b.(*Expression)
+		return ok && e.equalExpression(a, b)
+	case *VariableDeclaration:
+		b, ok := b.(*VariableDeclaration)
+		return ok && e.equalVariableDeclaration(a, b)
+	}
+	return false
+}
+func (h *hasher) hashForLoopInit(n ForLoopInit) {
+	switch n := n.(type) {
+	case nil:
+		h.int(0)
+	case *Expression:
+		h.int(1)
+		h.hashExpression(n)
+	case *VariableDeclaration:
+		h.int(2)
+		h.hashVariableDeclaration(n)
+	}
+}
+func (e *equaler) equalInto(a, b Into) bool {
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+	case *Expression:
+		b, ok := b.(*Expression)
+		return ok && e.equalExpression(a, b)
+	case *VariableDeclaration:
+		b, ok := b.(*VariableDeclaration)
+		return ok && e.equalVariableDeclaration(a, b)
+	}
+	return false
+}
+func (h *hasher) hashInto(n Into) {
+	switch n := n.(type) {
+	case nil:
+		h.int(0)
+	case *Expression:
+		h.int(1)
+		h.hashExpression(n)
+	case *VariableDeclaration:
+		h.int(2)
+		h.hashVariableDeclaration(n)
+	}
+}
+func (e *equaler) equalMemberProp(a, b MemberProp) bool {
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+	case *ComputedProperty:
+		b, ok := b.(*ComputedProperty)
+		return ok && e.equalComputedProperty(a, b)
+	case *Identifier:
+		b, ok := b.(*Identifier)
+		return ok && e.equalIdentifier(a, b)
+	}
+	return false
+}
+func (h *hasher) hashMemberProp(n MemberProp) {
+	switch n := n.(type) {
+	case nil:
+		h.int(0)
+	case *ComputedProperty:
+		h.int(1)
+		h.hashComputedProperty(n)
+	case *Identifier:
+		h.int(2)
+		h.hashIdentifier(n)
+	}
+}
+func (e *equaler) equalPattern(a, b Pattern) bool {
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+	case *ArrayPattern:
+		b, ok := b.(*ArrayPattern)
+		return ok && e.equalArrayPattern(a, b)
+	case *ObjectPattern:
+		b, ok := b.(*ObjectPattern)
+		return ok && e.equalObjectPattern(a, b)
+	}
+	return false
+}
+func (h *hasher) hashPattern(n Pattern) {
+	switch n := n.(type) {
+	case nil:
+		h.int(0)
+	case *ArrayPattern:
+		h.int(1)
+		h.hashArrayPattern(n)
+	case *ObjectPattern:
+		h.int(2)
+		h.hashObjectPattern(n)
+	}
+}
+func (e *equaler) equalProp(a, b Prop) bool {
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+	case *PropertyKeyed:
+		b, ok := b.(*PropertyKeyed)
+		return ok && e.equalPropertyKeyed(a, b)
+	case *PropertyShort:
+		b, ok := b.(*PropertyShort)
+		return ok && e.equalPropertyShort(a, b)
+	case *SpreadElement:
+		b, ok := b.(*SpreadElement)
+		return ok && e.equalSpreadElement(a, b)
+	}
+	return false
+}
+func (h *hasher) hashProp(n Prop) {
+	switch n := n.(type) {
+	case nil:
+		h.int(0)
+	case *PropertyKeyed:
+		h.int(1)
+		h.hashPropertyKeyed(n)
+	case *PropertyShort:
+		h.int(2)
+		h.hashPropertyShort(n)
+	case *SpreadElement:
+		h.int(3)
+		h.hashSpreadElement(n)
+	}
+}
+func (e *equaler) equalStmt(a, b Stmt) bool {
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+	case *BadStatement:
+		b, ok := b.(*BadStatement)
+		return ok && e.equalBadStatement(a, b)
+	case *BlockStatement:
+		b, ok := b.(*BlockStatement)
+		return ok && e.equalBlockStatement(a, b)
+	case *BreakStatement:
+		b, ok := b.(*BreakStatement)
+		return ok && e.equalBreakStatement(a, b)
+	case *CaseStatement:
+		b, ok := b.(*CaseStatement)
+		return ok && e.equalCaseStatement(a, b)
+	case *CatchStatement:
+		b, ok := b.(*CatchStatement)
+		return ok && e.equalCatchStatement(a, b)
+	case *ClassDeclaration:
+		b, ok := b.(*ClassDeclaration)
+		return ok && e.equalClassDeclaration(a, b)
+	case *ContinueStatement:
+		b, ok := b.(*ContinueStatement)
+		return ok && e.equalContinueStatement(a, b)
+	case *DebuggerStatement:
+		b, ok := b.(*DebuggerStatement)
+		return ok && e.equalDebuggerStatement(a, b)
+	case *DoWhileStatement:
+		b, ok := b.(*DoWhileStatement)
+		return ok && e.equalDoWhileStatement(a, b)
+	case *EmptyStatement:
+		b, ok := b.(*EmptyStatement)
+		return ok && e.equalEmptyStatement(a, b)
+	case *ExpressionStatement:
+		b, ok := b.(*ExpressionStatement)
+		return ok && e.equalExpressionStatement(a, b)
+	case *ForInStatement:
+		b, ok := b.(*ForInStatement)
+		return ok && e.equalForInStatement(a, b)
+	case *ForOfStatement:
+		b, ok := b.(*ForOfStatement)
+		return ok && e.equalForOfStatement(a, b)
+	case *ForStatement:
+		b, ok := b.(*ForStatement)
+		return ok && e.equalForStatement(a, b)
+	case *FunctionDeclaration:
+		b, ok := b.(*FunctionDeclaration)
+		return ok && e.equalFunctionDeclaration(a, b)
+	case *IfStatement:
+		b, ok := b.(*IfStatement)
+		return ok && e.equalIfStatement(a, b)
+	case *LabelledStatement:
+		b, ok := b.(*LabelledStatement)
+		return ok && e.equalLabelledStatement(a, b)
+	case *ReturnStatement:
+		b, ok := b.(*ReturnStatement)
+		return ok && e.equalReturnStatement(a, b)
+	case *SwitchStatement:
+		b, ok := b.(*SwitchStatement)
+		return ok && e.equalSwitchStatement(a, b)
+	case *ThrowStatement:
+		b, ok := b.(*ThrowStatement)
+		return ok && e.equalThrowStatement(a, b)
+	case *TryStatement:
+		b, ok := b.(*TryStatement)
+		return ok && e.equalTryStatement(a, b)
+	case *VariableDeclaration:
+		b, ok := b.(*VariableDeclaration)
+		return ok && e.equalVariableDeclaration(a, b)
+	case *WhileStatement:
+		b, ok := b.(*WhileStatement)
+		return ok && e.equalWhileStatement(a, b)
+	case *WithStatement:
+		b, ok := b.(*WithStatement)
+		return ok && e.equalWithStatement(a, b)
+	}
+	return false
+}
+func (h *hasher) hashStmt(n Stmt) {
+	switch n := n.(type) {
+	case nil:
+		h.int(0)
+	case *BadStatement:
+		h.int(1)
+		h.hashBadStatement(n)
+	case *BlockStatement:
+		h.int(2)
+		h.hashBlockStatement(n)
+	case *BreakStatement:
+		h.int(3)
+		h.hashBreakStatement(n)
+	case *CaseStatement:
+		h.int(4)
+		h.hashCaseStatement(n)
+	case *CatchStatement:
+		h.int(5)
+		h.hashCatchStatement(n)
+	case *ClassDeclaration:
+		h.int(6)
+		h.hashClassDeclaration(n)
+	case *ContinueStatement:
+		h.int(7)
+		h.hashContinueStatement(n)
+	case *DebuggerStatement:
+		h.int(8)
+		h.hashDebuggerStatement(n)
+	case *DoWhileStatement:
+		h.int(9)
+		h.hashDoWhileStatement(n)
+	case *EmptyStatement:
+		h.int(10)
+		h.hashEmptyStatement(n)
+	case *ExpressionStatement:
+		h.int(11)
+		h.hashExpressionStatement(n)
+	case *ForInStatement:
+		h.int(12)
+		h.hashForInStatement(n)
+	case *ForOfStatement:
+		h.int(13)
+		h.hashForOfStatement(n)
+	case *ForStatement:
+		h.int(14)
+		h.hashForStatement(n)
+	case *FunctionDeclaration:
+		h.int(15)
+		h.hashFunctionDeclaration(n)
+	case *IfStatement:
+		h.int(16)
+		h.hashIfStatement(n)
+	case *LabelledStatement:
+		h.int(17)
+		h.hashLabelledStatement(n)
+	case *ReturnStatement:
+		h.int(18)
+		h.hashReturnStatement(n)
+	case *SwitchStatement:
+		h.int(19)
+		h.hashSwitchStatement(n)
+	case *ThrowStatement:
+		h.int(20)
+		h.hashThrowStatement(n)
+	case *TryStatement:
+		h.int(21)
+		h.hashTryStatement(n)
+	case *VariableDeclaration:
+		h.int(22)
+		h.hashVariableDeclaration(n)
+	case *WhileStatement:
+		h.int(23)
+		h.hashWhileStatement(n)
+	case *WithStatement:
+		h.int(24)
+		h.hashWithStatement(n)
+	}
+}
+func (e *equaler) equalTarget(a, b Target) bool {
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+	case *ArrayPattern:
+		b, ok := b.(*ArrayPattern)
+		return ok && e.equalArrayPattern(a, b)
+	case *Identifier:
+		b, ok := b.(*Identifier)
+		return ok && e.equalIdentifier(a, b)
+	case *InvalidExpression:
+		b, ok := b.(*InvalidExpression)
+		return ok && e.equalInvalidExpression(a, b)
+	case *MemberExpression:
+		b, ok := b.(*MemberExpression)
+		return ok && e.equalMemberExpression(a, b)
+	case *ObjectPattern:
+		b, ok := b.(*ObjectPattern)
+		return ok && e.equalObjectPattern(a, b)
+	}
+	return false
+}
+func (h *hasher) hashTarget(n Target) {
+	switch n := n.(type) {
+	case nil:
+		h.int(0)
+	case *ArrayPattern:
+		h.int(1)
+		h.hashArrayPattern(n)
+	case *Identifier:
+		h.int(2)
+		h.hashIdentifier(n)
+	case *InvalidExpression:
+		h.int(3)
+		h.hashInvalidExpression(n)
+	case *MemberExpression:
+		h.int(4)
+		h.hashMemberExpression(n)
+	case *ObjectPattern:
+		h.int(5)
+		h.hashObjectPattern(n)
+	}
+}
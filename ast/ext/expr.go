@@ -584,6 +584,10 @@ func MayHaveSideEffects(expr *ast.Expression) bool {
 	}
 	switch e := expr.Expr.(type) {
 	case *ast.Identifier:
+		// A reference eval or with may redirect may run a getter.
+		if e.ScopeContext == resolver.DynamicMark {
+			return true
+		}
 		if e.ScopeContext == resolver.UnresolvedMark &&
 			!slices.Contains([]string{"Infinity", "NaN", "Math", "undefined",
 				"Object", "Array", "Promise", "Boolean", "Number", "String",
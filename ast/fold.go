@@ -0,0 +1,33 @@
+package ast
+
+// foldStmt folds a statement held on its own.
+func foldStmt(f Folder, s Stmt) Stmt {
+	switch folded := f.FoldStmt(s); len(folded) {
+	case 0:
+		return &EmptyStatement{Semicolon: s.Idx0()}
+	case 1:
+		return folded[0]
+	default:
+		block := &BlockStatement{List: make(Statements, len(folded))}
+		for i, s := range folded {
+			block.List[i].Stmt = s
+		}
+		return block
+	}
+}
+
+// foldStatements folds the statements of l, splicing in what FoldStmt
+// returns for each.
+func foldStatements(f Folder, l *Statements) {
+	list := make(Statements, 0, len(*l))
+	for _, s := range *l {
+		if s.Stmt == nil {
+			list = append(list, s)
+			continue
+		}
+		for _, s := range f.FoldStmt(s.Stmt) {
+			list = append(list, Statement{Stmt: s})
+		}
+	}
+	*l = list
+}
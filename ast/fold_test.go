@@ -0,0 +1,76 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/generator"
+)
+
+// statementSplitter turns "a ? b : c;" into an if statement and "a, b;" into
+// one statement per expression, removes "drop;", and renames "x" to "y".
+type statementSplitter struct {
+	ast.NoopFolder
+}
+
+func newStatementSplitter() *statementSplitter {
+	s := &statementSplitter{}
+	s.F = s
+	return s
+}
+
+func (s *statementSplitter) FoldStmt(n ast.Stmt) []ast.Stmt {
+	n.FoldChildrenWith(s)
+	stmt, ok := n.(*ast.ExpressionStatement)
+	if !ok {
+		return []ast.Stmt{n}
+	}
+	switch e := stmt.Expression.Expr.(type) {
+	case *ast.Identifier:
+		if e.Name == "drop" {
+			return nil
+		}
+	case *ast.ConditionalExpression:
+		return []ast.Stmt{&ast.IfStatement{
+			Test:       e.Test,
+			Consequent: &ast.Statement{Stmt: &ast.ExpressionStatement{Expression: e.Consequent}},
+			Alternate:  &ast.Statement{Stmt: &ast.ExpressionStatement{Expression: e.Alternate}},
+		}}
+	case *ast.SequenceExpression:
+		var stmts []ast.Stmt
+		for i := range e.Sequence {
+			stmts = append(stmts, &ast.ExpressionStatement{Expression: &e.Sequence[i]})
+		}
+		return stmts
+	}
+	return []ast.Stmt{n}
+}
+
+func (s *statementSplitter) FoldExpr(n ast.Expr) ast.Expr {
+	n.FoldChildrenWith(s)
+	if isIdent(n, "x") {
+		return &ast.Identifier{Name: "y"}
+	}
+	return n
+}
+
+func TestFolder(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"a ? b : c;", "if(a)b; else c;"},
+		{"a, b; c;", "a;b;c;"},
+		{"a; drop; b;", "a;b;"},
+		{"if (a) (b, c); else drop;", "if(a){b;c;} else ;"},
+		{"function f() { x ? f(x) : x; }", "function f(){if(y)f(y); else y;}"},
+		{"for (x in o) x;", "for(y in o)y;"},
+	}
+	for _, tt := range tests {
+		program := parse(t, tt.src)
+		program.FoldChildrenWith(newStatementSplitter())
+		if got := generator.GenerateMinified(program); got != tt.want {
+			t.Errorf("%q: generated %q; want %q", tt.src, got, tt.want)
+		}
+	}
+}
@@ -11,6 +11,18 @@ type (
 		Function Idx
 
 		Async, Generator bool
+
+		// Lazy is set when the parser skipped the body in lazy mode. Body
+		// then holds an empty block spanning the skipped braces.
+		Lazy *LazyBody `optional:"true"`
+	}
+
+	// LazyBody is the unparsed body of a function skipped by lazy parsing.
+	LazyBody struct {
+		// Source is the text of the body, braces included.
+		Source string
+
+		parse func() (*BlockStatement, error)
 	}
 
 	ParameterList struct {
@@ -23,3 +35,35 @@ type (
 )
 
 func (*FunctionLiteral) _expr() {}
+
+// NewLazyBody returns a LazyBody for src whose body is produced by parse.
+func NewLazyBody(src string, parse func() (*BlockStatement, error)) *LazyBody {
+	return &LazyBody{Source: src, parse: parse}
+}
+
+// Clone returns a copy of l, which can be parsed independently of l.
+func (l *LazyBody) Clone() *LazyBody {
+	c := *l
+	return &c
+}
+
+// IsLazy reports whether the body of f was skipped by lazy parsing and has
+// not been parsed yet.
+func (f *FunctionLiteral) IsLazy() bool {
+	return f.Lazy != nil
+}
+
+// ParseBody parses a body skipped by lazy parsing and stores it in Body. It
+// does nothing if the body is already parsed. The new body is unresolved.
+func (f *FunctionLiteral) ParseBody() error {
+	if f.Lazy == nil {
+		return nil
+	}
+	body, err := f.Lazy.parse()
+	if err != nil {
+		return err
+	}
+	f.Body = body
+	f.Lazy = nil
+	return nil
+}
@@ -331,8 +331,7 @@ func findCloneableNodes(f *ast.File) (types []CloneableNodeType) {
 				continue
 			}
 
-			switch typeSpec.Name.Name {
-			case "ScopeContext", "Id":
+			if !isNodeType(typeSpec) {
 				continue
 			}
 
@@ -361,9 +360,10 @@ func findStructChildren(fields []*ast.Field) (children []Child) {
 			fmt.Println(field.Names[0].Name)
 		}
 
+		var child Child
 		switch fieldType := field.Type.(type) {
 		case *ast.SelectorExpr:
-			children = append(children, newChild(field.Names[0].Name, "", false, false, optional))
+			child = newChild("", "", false, false, optional)
 		case *ast.Ident:
 			if len(field.Names) == 0 {
 				children = append(children, newChild(fieldType.Name, fieldType.Name, true, false, optional))
@@ -373,22 +373,30 @@ func findStructChildren(fields []*ast.Field) (children []Child) {
 			switch fieldType.Name {
 			case "Idx", "any", "bool", "int", "ScopeContext", "string", "PropertyKind", "Token",
 				"float64", "UnaryOperator", "AssignmentOperator", "BinaryOperator", "UpdateOperator", "LogicalOperator":
-				children = append(children, newChild(field.Names[0].Name, fieldType.Name, false, false, optional))
+				child = newChild("", fieldType.Name, false, false, optional)
 			default:
-				children = append(children, newChild(field.Names[0].Name, fieldType.Name, true, false, optional))
+				child = newChild("", fieldType.Name, true, false, optional)
 			}
 		case *ast.StarExpr:
 			if ident, ok := fieldType.X.(*ast.Ident); ok {
 				if ident.Name == "string" {
-					children = append(children, newChild(field.Names[0].Name, ident.Name, false, false, optional))
-					continue
+					child = newChild("", ident.Name, false, false, optional)
+				} else {
+					child = newChild("", ident.Name, true, true, optional)
 				}
-				children = append(children, newChild(field.Names[0].Name, ident.Name, true, true, optional))
 			} else {
 				// Pointer to a type from another package (e.g. *big.Int).
 				// Shallow-copy the pointer — not a cloneable AST node.
-				children = append(children, newChild(field.Names[0].Name, "", false, false, optional))
+				child = newChild("", "", false, false, optional)
 			}
+		default:
+			continue
+		}
+		// A field list such as "Async, Generator bool" declares several
+		// fields of the same type.
+		for _, name := range field.Names {
+			child.FieldName = name.Name
+			children = append(children, child)
 		}
 	}
 	return children
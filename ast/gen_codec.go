@@ -0,0 +1,383 @@
+//go:build ignore
+
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"hash/fnv"
+	"io/fs"
+	"log"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Generates codec.go, the binary encoder and decoder used by
+// Program.MarshalBinary and Program.UnmarshalBinary.
+
+type NodeType int
+
+const (
+	NodeTypeStruct NodeType = iota
+	NodeTypeSlice
+)
+
+type CodecNodeType struct {
+	Type     NodeType
+	Name     string
+	Elem     string
+	Children []Child
+}
+
+type CodecInterface struct {
+	Name       string
+	UniqueFunc string
+	Structs    []string
+}
+
+// FieldKind says how a field is encoded.
+type FieldKind int
+
+const (
+	// FieldScalar fields are written by a binaryWriter method of the same
+	// name as Codec and read back with a conversion to FieldType.
+	FieldScalar FieldKind = iota
+	FieldStruct
+	FieldPointer
+	FieldInterface
+	FieldSlice
+)
+
+type Child struct {
+	FieldName string
+	FieldType string
+	Kind      FieldKind
+	// Codec is the binaryWriter and binaryReader method for scalar fields.
+	Codec string
+	// Convert is the type the codec method works with when it differs
+	// from FieldType.
+	Convert string
+}
+
+var scalars = map[string][2]string{
+	"Idx":                {"idx", ""},
+	"bool":               {"bool", ""},
+	"int":                {"int", ""},
+	"float64":            {"float", ""},
+	"string":             {"str", ""},
+	"ScopeContext":       {"scope", ""},
+	"PropertyKind":       {"str", "string"},
+	"UnaryOperator":      {"u8", "uint8"},
+	"AssignmentOperator": {"u8", "uint8"},
+	"BinaryOperator":     {"u8", "uint8"},
+	"UpdateOperator":     {"u8", "uint8"},
+	"LogicalOperator":    {"u8", "uint8"},
+	"token.Token":        {"token", ""},
+	"*string":            {"strPtr", ""},
+	"*big.Int":           {"bigInt", ""},
+	"*LazyBody":          {"lazyBody", ""},
+}
+
+func main() {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, "./ast", func(info fs.FileInfo) bool {
+		switch info.Name() {
+		case "clone.go", "codec.go", "visit.go":
+			return false
+		}
+		return true
+	}, parser.ParseComments)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	var nodes []CodecNodeType
+	var interfaces []CodecInterface
+	for _, file := range pkgs["ast"].Files {
+		nodes = append(nodes, findCodecNodes(file)...)
+		interfaces = append(interfaces, findCodecInterfaces(file)...)
+	}
+	for _, file := range pkgs["ast"].Files {
+		findStructsForInterfaces(file, interfaces)
+	}
+	slices.SortFunc(nodes, func(a, b CodecNodeType) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	slices.SortFunc(interfaces, func(a, b CodecInterface) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	for _, i := range interfaces {
+		slices.Sort(i.Structs)
+	}
+
+	kinds := make(map[string]FieldKind)
+	for _, node := range nodes {
+		if node.Type == NodeTypeStruct {
+			kinds[node.Name] = FieldStruct
+		} else {
+			kinds[node.Name] = FieldSlice
+		}
+	}
+	for _, i := range interfaces {
+		kinds[i.Name] = FieldInterface
+	}
+	for i := range nodes {
+		for j := range nodes[i].Children {
+			child := &nodes[i].Children[j]
+			if child.Kind != FieldScalar {
+				continue
+			}
+			if codec, ok := scalars[child.FieldType]; ok {
+				child.Codec, child.Convert = codec[0], codec[1]
+				continue
+			}
+			name, pointer := strings.CutPrefix(child.FieldType, "*")
+			kind, ok := kinds[name]
+			switch {
+			case !ok || pointer && kind != FieldStruct:
+				log.Fatalf("%s.%s: cannot encode field of type %s", nodes[i].Name, child.FieldName, child.FieldType)
+			case pointer:
+				child.Kind = FieldPointer
+			default:
+				child.Kind = kind
+			}
+			child.FieldType = name
+		}
+	}
+
+	// The schema hash changes whenever a node gains, loses or reorders a
+	// field, or an interface gains an implementation, so data written by
+	// another build is rejected.
+	schema := fnv.New64a()
+	for _, node := range nodes {
+		fmt.Fprintf(schema, "%s %s{", node.Name, node.Elem)
+		for _, child := range node.Children {
+			fmt.Fprintf(schema, "%s %d %s;", child.FieldName, child.Kind, child.FieldType)
+		}
+		fmt.Fprint(schema, "}\n")
+	}
+	for _, i := range interfaces {
+		fmt.Fprintf(schema, "%s %v\n", i.Name, i.Structs)
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gen_codec.go; DO NOT EDIT.\n\npackage ast\n\n")
+	fmt.Fprintf(&b, "const binarySchema = 0x%016x\n\n", schema.Sum64())
+
+	b.WriteString("type binaryNodes struct {\n")
+	for _, node := range nodes {
+		if node.Type == NodeTypeStruct {
+			fmt.Fprintf(&b, "%s slab[%s]\n", node.Name, node.Name)
+		}
+	}
+	b.WriteString("}\n\n")
+
+	for _, node := range nodes {
+		switch node.Type {
+		case NodeTypeStruct:
+			fmt.Fprintf(&b, "func (w *binaryWriter) write%s(n *%s) {\n", node.Name, node.Name)
+			for _, child := range node.Children {
+				writeField(&b, child)
+			}
+			b.WriteString("}\n")
+			fmt.Fprintf(&b, "func (r *binaryReader) read%s(n *%s) {\n", node.Name, node.Name)
+			for _, child := range node.Children {
+				readField(&b, child)
+			}
+			b.WriteString("}\n")
+		case NodeTypeSlice:
+			fmt.Fprintf(&b, "func (w *binaryWriter) write%s(n %s) {\n", node.Name, node.Name)
+			b.WriteString("w.uvarint(uint64(len(n)))\n")
+			fmt.Fprintf(&b, "for i := range n {\nw.write%s(&n[i])\n}\n}\n", node.Elem)
+			fmt.Fprintf(&b, "func (r *binaryReader) read%s() %s {\n", node.Name, node.Name)
+			b.WriteString("l := r.len()\nif l == 0 {\nreturn nil\n}\n")
+			fmt.Fprintf(&b, "n := %s(r.nodes.%s.make(l))\n", node.Name, node.Elem)
+			fmt.Fprintf(&b, "for i := range n {\nr.read%s(&n[i])\n}\nreturn n\n}\n", node.Elem)
+		}
+	}
+
+	for _, i := range interfaces {
+		fmt.Fprintf(&b, "func (w *binaryWriter) write%s(n %s) {\n", i.Name, i.Name)
+		b.WriteString("w.enter()\nswitch n := n.(type) {\ncase nil:\nw.uvarint(0)\n")
+		for tag, s := range i.Structs {
+			fmt.Fprintf(&b, "case *%s:\nw.uvarint(%d)\nw.write%s(n)\n", s, tag+1, s)
+		}
+		fmt.Fprintf(&b, "default:\nw.unknown(%q, n)\n}\nw.depth--\n}\n", i.Name)
+
+		fmt.Fprintf(&b, "func (r *binaryReader) read%s() (n %s) {\n", i.Name, i.Name)
+		b.WriteString("r.enter()\nswitch r.uvarint() {\ncase 0:\n")
+		for tag, s := range i.Structs {
+			fmt.Fprintf(&b, "case %d:\nx := r.nodes.%s.new()\nr.read%s(x)\nn = x\n", tag+1, s, s)
+		}
+		b.WriteString("default:\nr.corrupt()\n}\nr.depth--\nreturn n\n}\n")
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatalf("format: %v", err)
+	}
+	if err := os.WriteFile("ast/codec.go", src, 0644); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
+
+func writeField(b *bytes.Buffer, child Child) {
+	field := "n." + child.FieldName
+	switch child.Kind {
+	case FieldScalar:
+		if child.Convert != "" {
+			field = child.Convert + "(" + field + ")"
+		}
+		fmt.Fprintf(b, "w.%s(%s)\n", child.Codec, field)
+	case FieldStruct:
+		fmt.Fprintf(b, "w.write%s(&%s)\n", child.FieldType, field)
+	case FieldPointer:
+		fmt.Fprintf(b, "if w.present(%s != nil) {\nw.write%s(%s)\n}\n", field, child.FieldType, field)
+	case FieldInterface, FieldSlice:
+		fmt.Fprintf(b, "w.write%s(%s)\n", child.FieldType, field)
+	}
+}
+
+func readField(b *bytes.Buffer, child Child) {
+	field := "n." + child.FieldName
+	switch child.Kind {
+	case FieldScalar:
+		value := "r." + child.Codec + "()"
+		if child.Convert != "" {
+			value = child.FieldType + "(" + value + ")"
+		}
+		fmt.Fprintf(b, "%s = %s\n", field, value)
+	case FieldStruct:
+		fmt.Fprintf(b, "r.read%s(&%s)\n", child.FieldType, field)
+	case FieldPointer:
+		fmt.Fprintf(b, "if r.present() {\n%s = r.nodes.%s.new()\nr.read%s(%s)\n}\n", field, child.FieldType, child.FieldType, field)
+	case FieldInterface, FieldSlice:
+		fmt.Fprintf(b, "%s = r.read%s()\n", field, child.FieldType)
+	}
+}
+
+func findCodecInterfaces(f *ast.File) []CodecInterface {
+	var interfaces []CodecInterface
+	for _, decl := range f.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			t, ok := typeSpec.Type.(*ast.InterfaceType)
+			if !ok {
+				continue
+			}
+			idx := slices.IndexFunc(t.Methods.List, func(a *ast.Field) bool {
+				return len(a.Names) != 0 && strings.HasPrefix(a.Names[0].Name, "_")
+			})
+			if idx == -1 {
+				continue
+			}
+			interfaces = append(interfaces, CodecInterface{
+				Name:       typeSpec.Name.Name,
+				UniqueFunc: t.Methods.List[idx].Names[0].Name,
+			})
+		}
+	}
+	return interfaces
+}
+
+func findStructsForInterfaces(f *ast.File, interfaces []CodecInterface) {
+	for _, decl := range f.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+			continue
+		}
+		starExpr, ok := funcDecl.Recv.List[0].Type.(*ast.StarExpr)
+		if !ok {
+			continue
+		}
+		ident, ok := starExpr.X.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		idx := slices.IndexFunc(interfaces, func(a CodecInterface) bool {
+			return a.UniqueFunc == funcDecl.Name.Name
+		})
+		if idx == -1 {
+			continue
+		}
+		interfaces[idx].Structs = append(interfaces[idx].Structs, ident.Name)
+	}
+}
+
+func findCodecNodes(f *ast.File) (types []CodecNodeType) {
+	for _, decl := range f.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+
+			if !isNodeType(typeSpec) {
+				continue
+			}
+
+			switch t := typeSpec.Type.(type) {
+			case *ast.StructType:
+				types = append(types, CodecNodeType{
+					Type:     NodeTypeStruct,
+					Name:     typeSpec.Name.Name,
+					Children: findStructChildren(typeSpec.Name.Name, t.Fields.List),
+				})
+			case *ast.ArrayType:
+				elem, ok := t.Elt.(*ast.Ident)
+				if !ok {
+					log.Fatalf("%s: unsupported element type", typeSpec.Name.Name)
+				}
+				types = append(types, CodecNodeType{
+					Type: NodeTypeSlice,
+					Name: typeSpec.Name.Name,
+					Elem: elem.Name,
+				})
+			}
+		}
+	}
+	return types
+}
+
+func findStructChildren(node string, fields []*ast.Field) (children []Child) {
+	for _, field := range fields {
+		var typ string
+		switch t := field.Type.(type) {
+		case *ast.Ident:
+			typ = t.Name
+		case *ast.StarExpr:
+			typ = "*" + types.ExprString(t.X)
+		case *ast.SelectorExpr:
+			typ = types.ExprString(t)
+		default:
+			log.Fatalf("%s: unsupported field type %s", node, types.ExprString(field.Type))
+		}
+		if len(field.Names) == 0 {
+			// Embedded interface, as in BindingTarget.
+			children = append(children, Child{FieldName: typ, FieldType: typ})
+			continue
+		}
+		for _, name := range field.Names {
+			children = append(children, Child{FieldName: name.Name, FieldType: typ})
+		}
+	}
+	return children
+}
@@ -0,0 +1,21 @@
+//go:build ignore
+
+// Helpers shared by the generators. Run each generator together with this
+// file, as in go run ast/gen_clone.go ast/gen_common.go.
+
+package main
+
+import "go/ast"
+
+// isNodeType reports whether spec declares a syntax node, or a list or
+// interface of them, which the generators write code for. Exported types
+// that are not part of the tree, such as the options and results of the
+// package's functions, must be listed here.
+func isNodeType(spec *ast.TypeSpec) bool {
+	switch spec.Name.Name {
+	case "ScopeContext", "Id", "LazyBody", "NodePath", "PathVisitor", "EqualOptions", "DumpOptions", "Problem":
+		return false
+	}
+	// Unexported types are helpers, not syntax nodes.
+	return spec.Name.IsExported()
+}
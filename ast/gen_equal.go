@@ -0,0 +1,398 @@
+//go:build ignore
+
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"log"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Generates equal.go, the node comparison and hashing behind Equal and Hash.
+
+type NodeType int
+
+const (
+	NodeTypeStruct NodeType = iota
+	NodeTypeSlice
+)
+
+type EqualNodeType struct {
+	Type     NodeType
+	Name     string
+	Elem     string
+	Children []Child
+}
+
+type EqualInterface struct {
+	Name       string
+	UniqueFunc string
+	Structs    []string
+}
+
+// FieldKind says how a field is compared.
+type FieldKind int
+
+const (
+	FieldScalar FieldKind = iota
+	FieldStruct
+	FieldPointer
+	FieldInterface
+	FieldSlice
+	// FieldIgnored fields do not change what code a node stands for.
+	FieldIgnored
+)
+
+type Child struct {
+	FieldName string
+	FieldType string
+	Kind      FieldKind
+	Scalar    Scalar
+}
+
+// Scalar says how a scalar field is compared and hashed.
+type Scalar struct {
+	// Equal is the equaler method comparing the field, or "" to use ==.
+	Equal string
+	// Hash is the hasher method hashing the field, converted to Convert if
+	// that is set.
+	Hash    string
+	Convert string
+}
+
+var scalars = map[string]Scalar{
+	"bool":               {Hash: "bool"},
+	"int":                {Hash: "int"},
+	"float64":            {Hash: "float"},
+	"string":             {Hash: "str"},
+	"ScopeContext":       {Equal: "scope", Hash: "scope"},
+	"PropertyKind":       {Hash: "str", Convert: "string"},
+	"UnaryOperator":      {Hash: "u64", Convert: "uint64"},
+	"AssignmentOperator": {Hash: "u64", Convert: "uint64"},
+	"BinaryOperator":     {Hash: "u64", Convert: "uint64"},
+	"UpdateOperator":     {Hash: "u64", Convert: "uint64"},
+	"LogicalOperator":    {Hash: "u64", Convert: "uint64"},
+	"token.Token":        {Hash: "u64", Convert: "uint64"},
+	"*big.Int":           {Equal: "bigInt", Hash: "bigInt"},
+	"*LazyBody":          {Equal: "lazyBody", Hash: "lazyBody"},
+}
+
+// handWritten nodes have their equalX and hashX methods in compare.go.
+var handWritten = []string{"Identifier"}
+
+func main() {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, "./ast", func(info fs.FileInfo) bool {
+		switch info.Name() {
+		case "clone.go", "codec.go", "equal.go", "visit.go":
+			return false
+		}
+		return true
+	}, parser.ParseComments)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	var nodes []EqualNodeType
+	var interfaces []EqualInterface
+	for _, file := range pkgs["ast"].Files {
+		nodes = append(nodes, findEqualNodes(file)...)
+		interfaces = append(interfaces, findEqualInterfaces(file)...)
+	}
+	for _, file := range pkgs["ast"].Files {
+		findStructsForInterfaces(file, interfaces)
+	}
+	slices.SortFunc(nodes, func(a, b EqualNodeType) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	slices.SortFunc(interfaces, func(a, b EqualInterface) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	for _, i := range interfaces {
+		slices.Sort(i.Structs)
+	}
+
+	kinds := make(map[string]FieldKind)
+	for _, node := range nodes {
+		if node.Type == NodeTypeStruct {
+			kinds[node.Name] = FieldStruct
+		} else {
+			kinds[node.Name] = FieldSlice
+		}
+	}
+	for _, i := range interfaces {
+		kinds[i.Name] = FieldInterface
+	}
+	for i := range nodes {
+		for j := range nodes[i].Children {
+			child := &nodes[i].Children[j]
+			switch {
+			case child.FieldType == "Idx", child.FieldName == "Raw", child.FieldName == "Comment":
+				// Positions, the source spelling of literals and comments.
+				child.Kind = FieldIgnored
+				continue
+			}
+			if scalar, ok := scalars[child.FieldType]; ok {
+				child.Scalar = scalar
+				continue
+			}
+			name, pointer := strings.CutPrefix(child.FieldType, "*")
+			kind, ok := kinds[name]
+			switch {
+			case !ok || pointer && kind != FieldStruct:
+				log.Fatalf("%s.%s: cannot compare field of type %s", nodes[i].Name, child.FieldName, child.FieldType)
+			case pointer:
+				child.Kind = FieldPointer
+			default:
+				child.Kind = kind
+			}
+			child.FieldType = name
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gen_equal.go; DO NOT EDIT.\n\npackage ast\n\n")
+
+	b.WriteString("func (e *equaler) equalNode(a, b VisitableNode) bool {\nswitch a := a.(type) {\n")
+	for _, node := range nodes {
+		fmt.Fprintf(&b, "case *%s:\nb, ok := b.(*%s)\n", node.Name, node.Name)
+		if node.Type == NodeTypeSlice {
+			b.WriteString("if !ok || a == nil || b == nil {\nreturn ok && a == b\n}\n")
+			fmt.Fprintf(&b, "return e.equal%s(*a, *b)\n", node.Name)
+		} else {
+			fmt.Fprintf(&b, "return ok && e.equal%s(a, b)\n", node.Name)
+		}
+	}
+	b.WriteString("}\nreturn a == nil && b == nil\n}\n\n")
+
+	b.WriteString("func (h *hasher) hashNode(n VisitableNode) {\nswitch n := n.(type) {\n")
+	for tag, node := range nodes {
+		fmt.Fprintf(&b, "case *%s:\nh.int(%d)\n", node.Name, tag+1)
+		if node.Type == NodeTypeSlice {
+			fmt.Fprintf(&b, "if h.present(n != nil) {\nh.hash%s(*n)\n}\n", node.Name)
+		} else {
+			fmt.Fprintf(&b, "h.hash%s(n)\n", node.Name)
+		}
+	}
+	b.WriteString("default:\nh.int(0)\n}\n}\n\n")
+
+	for _, node := range nodes {
+		if slices.Contains(handWritten, node.Name) {
+			continue
+		}
+		switch node.Type {
+		case NodeTypeStruct:
+			fmt.Fprintf(&b, "func (e *equaler) equal%s(a, b *%s) bool {\n", node.Name, node.Name)
+			b.WriteString("if a == nil || b == nil {\nreturn a == b\n}\n")
+			conds := []string{"true"}
+			for _, child := range node.Children {
+				if cond := equalField(child); cond != "" {
+					conds = append(conds, cond)
+				}
+			}
+			if len(conds) > 1 {
+				conds = conds[1:]
+			}
+			fmt.Fprintf(&b, "return %s\n}\n", strings.Join(conds, " &&\n"))
+			fmt.Fprintf(&b, "func (h *hasher) hash%s(n *%s) {\n", node.Name, node.Name)
+			b.WriteString("if !h.present(n != nil) {\nreturn\n}\n")
+			for _, child := range node.Children {
+				hashField(&b, child)
+			}
+			b.WriteString("}\n")
+		case NodeTypeSlice:
+			fmt.Fprintf(&b, "func (e *equaler) equal%s(a, b %s) bool {\n", node.Name, node.Name)
+			b.WriteString("if len(a) != len(b) {\nreturn false\n}\n")
+			fmt.Fprintf(&b, "for i := range a {\nif !e.equal%s(&a[i], &b[i]) {\nreturn false\n}\n}\nreturn true\n}\n", node.Elem)
+			fmt.Fprintf(&b, "func (h *hasher) hash%s(n %s) {\n", node.Name, node.Name)
+			fmt.Fprintf(&b, "h.int(len(n))\nfor i := range n {\nh.hash%s(&n[i])\n}\n}\n", node.Elem)
+		}
+	}
+
+	for _, i := range interfaces {
+		fmt.Fprintf(&b, "func (e *equaler) equal%s(a, b %s) bool {\n", i.Name, i.Name)
+		b.WriteString("switch a := a.(type) {\ncase nil:\nreturn b == nil\n")
+		for _, s := range i.Structs {
+			fmt.Fprintf(&b, "case *%s:\nb, ok := b.(*%s)\nreturn ok && e.equal%s(a, b)\n", s, s, s)
+		}
+		b.WriteString("}\nreturn false\n}\n")
+
+		fmt.Fprintf(&b, "func (h *hasher) hash%s(n %s) {\n", i.Name, i.Name)
+		b.WriteString("switch n := n.(type) {\ncase nil:\nh.int(0)\n")
+		for tag, s := range i.Structs {
+			fmt.Fprintf(&b, "case *%s:\nh.int(%d)\nh.hash%s(n)\n", s, tag+1, s)
+		}
+		b.WriteString("}\n}\n")
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatalf("format: %v\n%s", err, b.Bytes())
+	}
+	if err := os.WriteFile("ast/equal.go", src, 0644); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
+
+// equalField returns the condition comparing a field of nodes a and b, or ""
+// if the field is ignored.
+func equalField(child Child) string {
+	a, c := "a."+child.FieldName, "b."+child.FieldName
+	switch child.Kind {
+	case FieldScalar:
+		if child.Scalar.Equal != "" {
+			return fmt.Sprintf("e.%s(%s, %s)", child.Scalar.Equal, a, c)
+		}
+		return fmt.Sprintf("%s == %s", a, c)
+	case FieldStruct:
+		return fmt.Sprintf("e.equal%s(&%s, &%s)", child.FieldType, a, c)
+	case FieldPointer, FieldInterface, FieldSlice:
+		return fmt.Sprintf("e.equal%s(%s, %s)", child.FieldType, a, c)
+	}
+	return ""
+}
+
+func hashField(b *bytes.Buffer, child Child) {
+	field := "n." + child.FieldName
+	switch child.Kind {
+	case FieldIgnored:
+	case FieldScalar:
+		if child.Scalar.Convert != "" {
+			field = child.Scalar.Convert + "(" + field + ")"
+		}
+		fmt.Fprintf(b, "h.%s(%s)\n", child.Scalar.Hash, field)
+	case FieldStruct:
+		fmt.Fprintf(b, "h.hash%s(&%s)\n", child.FieldType, field)
+	case FieldPointer, FieldInterface, FieldSlice:
+		fmt.Fprintf(b, "h.hash%s(%s)\n", child.FieldType, field)
+	}
+}
+
+func findEqualInterfaces(f *ast.File) []EqualInterface {
+	var interfaces []EqualInterface
+	for _, decl := range f.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			t, ok := typeSpec.Type.(*ast.InterfaceType)
+			if !ok {
+				continue
+			}
+			idx := slices.IndexFunc(t.Methods.List, func(a *ast.Field) bool {
+				return len(a.Names) != 0 && strings.HasPrefix(a.Names[0].Name, "_")
+			})
+			if idx == -1 {
+				continue
+			}
+			interfaces = append(interfaces, EqualInterface{
+				Name:       typeSpec.Name.Name,
+				UniqueFunc: t.Methods.List[idx].Names[0].Name,
+			})
+		}
+	}
+	return interfaces
+}
+
+func findStructsForInterfaces(f *ast.File, interfaces []EqualInterface) {
+	for _, decl := range f.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+			continue
+		}
+		starExpr, ok := funcDecl.Recv.List[0].Type.(*ast.StarExpr)
+		if !ok {
+			continue
+		}
+		ident, ok := starExpr.X.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		idx := slices.IndexFunc(interfaces, func(a EqualInterface) bool {
+			return a.UniqueFunc == funcDecl.Name.Name
+		})
+		if idx == -1 {
+			continue
+		}
+		interfaces[idx].Structs = append(interfaces[idx].Structs, ident.Name)
+	}
+}
+
+func findEqualNodes(f *ast.File) (types []EqualNodeType) {
+	for _, decl := range f.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+
+			if !isNodeType(typeSpec) {
+				continue
+			}
+
+			switch t := typeSpec.Type.(type) {
+			case *ast.StructType:
+				types = append(types, EqualNodeType{
+					Type:     NodeTypeStruct,
+					Name:     typeSpec.Name.Name,
+					Children: findStructChildren(typeSpec.Name.Name, t.Fields.List),
+				})
+			case *ast.ArrayType:
+				elem, ok := t.Elt.(*ast.Ident)
+				if !ok {
+					log.Fatalf("%s: unsupported element type", typeSpec.Name.Name)
+				}
+				types = append(types, EqualNodeType{
+					Type: NodeTypeSlice,
+					Name: typeSpec.Name.Name,
+					Elem: elem.Name,
+				})
+			}
+		}
+	}
+	return types
+}
+
+func findStructChildren(node string, fields []*ast.Field) (children []Child) {
+	for _, field := range fields {
+		var typ string
+		switch t := field.Type.(type) {
+		case *ast.Ident:
+			typ = t.Name
+		case *ast.StarExpr:
+			typ = "*" + types.ExprString(t.X)
+		case *ast.SelectorExpr:
+			typ = types.ExprString(t)
+		default:
+			log.Fatalf("%s: unsupported field type %s", node, types.ExprString(field.Type))
+		}
+		if len(field.Names) == 0 {
+			// Embedded interface, as in BindingTarget.
+			children = append(children, Child{FieldName: typ, FieldType: typ})
+			continue
+		}
+		for _, name := range field.Names {
+			children = append(children, Child{FieldName: name.Name, FieldType: typ})
+		}
+	}
+	return children
+}
@@ -0,0 +1,237 @@
+//go:build ignore
+
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"log"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Generates path.go, the child traversal behind Traverse.
+
+type NodeType int
+
+const (
+	NodeTypeStruct NodeType = iota
+	NodeTypeSlice
+)
+
+type PathNodeType struct {
+	Type   NodeType
+	Name   string
+	Elem   string
+	Fields []*ast.Field
+}
+
+// Wrapper is a struct whose only field holds an interface, such as
+// Expression or Statement.
+type Wrapper struct {
+	Field     string
+	Interface string
+}
+
+var scalars = []string{
+	"Idx", "any", "bool", "int", "ScopeContext", "string", "PropertyKind", "float64",
+	"UnaryOperator", "AssignmentOperator", "BinaryOperator", "UpdateOperator", "LogicalOperator",
+}
+
+func main() {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, "./ast", func(info fs.FileInfo) bool {
+		switch info.Name() {
+		case "clone.go", "codec.go", "path.go", "visit.go":
+			return false
+		}
+		return true
+	}, parser.ParseComments)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	var nodes []PathNodeType
+	interfaces := make(map[string]bool)
+	for _, file := range pkgs["ast"].Files {
+		nodes = append(nodes, findPathNodes(file)...)
+		for _, name := range findInterfaces(file) {
+			interfaces[name] = true
+		}
+	}
+	slices.SortFunc(nodes, func(a, b PathNodeType) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	wrappers := make(map[string]Wrapper)
+	for _, node := range nodes {
+		if node.Type != NodeTypeStruct || len(node.Fields) != 1 {
+			continue
+		}
+		field := node.Fields[0]
+		ident, ok := field.Type.(*ast.Ident)
+		if !ok || !interfaces[ident.Name] {
+			continue
+		}
+		name := ident.Name
+		if len(field.Names) != 0 {
+			name = field.Names[0].Name
+		}
+		wrappers[node.Name] = Wrapper{Field: name, Interface: ident.Name}
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gen_path.go; DO NOT EDIT.\n\npackage ast\n\n")
+	b.WriteString("func (t *traversal) children(p *NodePath) {\nswitch n := p.Node.(type) {\n")
+	for _, node := range nodes {
+		var body bytes.Buffer
+		if node.Type == NodeTypeSlice {
+			fmt.Fprintf(&body, "visitList(t, p, \"\", %s(n))\n", listFunc(node.Name))
+		} else if w, ok := wrappers[node.Name]; ok {
+			// Wrappers are only visited as the root of a traversal.
+			fmt.Fprintf(&body, "visitField(t, p, %q, &n.%s, nil)\n", w.Field, w.Field)
+		} else {
+			for _, field := range node.Fields {
+				optional := field.Tag != nil && field.Tag.Value == "`optional:\"true\"`"
+				for _, name := range field.Names {
+					writeField(&body, node.Name, name.Name, field.Type, optional, interfaces, wrappers, nodes)
+				}
+			}
+		}
+		if body.Len() != 0 {
+			fmt.Fprintf(&b, "case *%s:\n%s", node.Name, body.Bytes())
+		}
+	}
+	b.WriteString("}\n}\n\n")
+
+	for _, node := range nodes {
+		if node.Type != NodeTypeSlice {
+			continue
+		}
+		fmt.Fprintf(&b, "func %s(l *%s) *listOf[%s, %s] {\n", listFunc(node.Name), node.Name, node.Name, node.Elem)
+		fmt.Fprintf(&b, "return &listOf[%s, %s]{\nl: l,\n", node.Name, node.Elem)
+		if w, ok := wrappers[node.Elem]; ok {
+			fmt.Fprintf(&b, "elem: func(e *%s) VisitableNode {\nreturn e.%s\n},\n", node.Elem, w.Field)
+			fmt.Fprintf(&b, "wrap: func(n VisitableNode) (%s, bool) {\nv, ok := n.(%s)\nreturn %s{%s: v}, ok\n},\n", node.Elem, w.Interface, node.Elem, w.Field)
+		} else {
+			fmt.Fprintf(&b, "elem: func(e *%s) VisitableNode {\nreturn e\n},\n", node.Elem)
+			fmt.Fprintf(&b, "wrap: func(n VisitableNode) (%s, bool) {\nif v, ok := n.(*%s); ok && v != nil {\nreturn *v, true\n}\nreturn %s{}, false\n},\n", node.Elem, node.Elem, node.Elem)
+		}
+		b.WriteString("}\n}\n")
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatalf("format: %v\n%s", err, b.Bytes())
+	}
+	if err := os.WriteFile("ast/path.go", src, 0644); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
+
+func writeField(b *bytes.Buffer, node, name string, typ ast.Expr, optional bool, interfaces map[string]bool, wrappers map[string]Wrapper, nodes []PathNodeType) {
+	remove := "nil"
+	if optional {
+		remove = fmt.Sprintf("func() { n.%s = nil }", name)
+	}
+	switch t := typ.(type) {
+	case *ast.Ident:
+		switch {
+		case slices.Contains(scalars, t.Name):
+		case interfaces[t.Name]:
+			fmt.Fprintf(b, "if n.%s != nil {\nvisitField(t, p, %q, &n.%s, %s)\n}\n", name, name, name, remove)
+		case slices.ContainsFunc(nodes, func(n PathNodeType) bool { return n.Name == t.Name && n.Type == NodeTypeSlice }):
+			fmt.Fprintf(b, "visitList(t, p, %q, %s(&n.%s))\n", name, listFunc(t.Name), name)
+		default:
+			log.Fatalf("%s.%s: unsupported field type %s", node, name, t.Name)
+		}
+	case *ast.StarExpr:
+		ident, ok := t.X.(*ast.Ident)
+		if !ok || ident.Name == "string" || ident.Name == "LazyBody" {
+			return
+		}
+		if w, ok := wrappers[ident.Name]; ok {
+			fmt.Fprintf(b, "if n.%s != nil {\nvisitField(t, p, %q, &n.%s.%s, %s)\n}\n", name, name, name, w.Field, remove)
+			return
+		}
+		fmt.Fprintf(b, "if n.%s != nil {\nvisitField(t, p, %q, &n.%s, %s)\n}\n", name, name, name, remove)
+	case *ast.SelectorExpr:
+	default:
+		log.Fatalf("%s.%s: unsupported field type", node, name)
+	}
+}
+
+func listFunc(name string) string {
+	return strings.ToLower(name[:1]) + name[1:] + "List"
+}
+
+func findInterfaces(f *ast.File) (names []string) {
+	for _, decl := range f.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			t, ok := typeSpec.Type.(*ast.InterfaceType)
+			if !ok {
+				continue
+			}
+			if slices.ContainsFunc(t.Methods.List, func(a *ast.Field) bool {
+				return len(a.Names) != 0 && strings.HasPrefix(a.Names[0].Name, "_")
+			}) {
+				names = append(names, typeSpec.Name.Name)
+			}
+		}
+	}
+	return names
+}
+
+func findPathNodes(f *ast.File) (types []PathNodeType) {
+	for _, decl := range f.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+
+			if !isNodeType(typeSpec) {
+				continue
+			}
+
+			switch t := typeSpec.Type.(type) {
+			case *ast.StructType:
+				types = append(types, PathNodeType{
+					Type:   NodeTypeStruct,
+					Name:   typeSpec.Name.Name,
+					Fields: t.Fields.List,
+				})
+			case *ast.ArrayType:
+				elem, ok := t.Elt.(*ast.Ident)
+				if !ok {
+					log.Fatalf("%s: unsupported element type", typeSpec.Name.Name)
+				}
+				types = append(types, PathNodeType{
+					Type: NodeTypeSlice,
+					Name: typeSpec.Name.Name,
+					Elem: elem.Name,
+				})
+			}
+		}
+	}
+	return types
+}
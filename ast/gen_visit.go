@@ -12,8 +12,10 @@ import (
 	"go/token"
 	"io/fs"
 	"log"
+	"maps"
 	"os"
 	"slices"
+	"strings"
 )
 
 // Generates visit.go
@@ -34,12 +36,28 @@ type VisitableNodeType struct {
 type Child struct {
 	FieldName string
 	Optional  bool
+	// TypeName is the name of the field's type, such as Expr or Statements,
+	// or "" for a pointer field.
+	TypeName string
 }
 
-func newChild(fieldName string, optional bool) Child {
-	return Child{FieldName: fieldName, Optional: optional}
+func newChild(fieldName string, optional bool, typeName string) Child {
+	return Child{FieldName: fieldName, Optional: optional, TypeName: typeName}
 }
 
+// folderDoc documents the generated Folder interface.
+const folderDoc = `// Folder rewrites a tree by returning the replacement of every node held
+// through an interface, such as the Expr of an Expression or the Stmt of a
+// Statement. Hooks receive the node before its children are folded; call
+// FoldChildrenWith to fold them, as NoopFolder does. Nodes held with a
+// concrete type, such as the Name of a FunctionDeclaration, cannot be
+// replaced and only have their children folded.
+//
+// FoldStmt may return any number of statements. In a statement list they
+// take the place of the statement; elsewhere, as in the Consequent of an
+// IfStatement, none become an EmptyStatement and several a BlockStatement.
+`
+
 func main() {
 	fset := token.NewFileSet()
 	pkgs, err := parser.ParseDir(fset, "./ast", func(info fs.FileInfo) bool {
@@ -50,8 +68,12 @@ func main() {
 	}
 
 	var nodes []VisitableNodeType
+	interfaces := make(map[string]bool)
 	for _, file := range pkgs["ast"].Files {
 		nodes = append(nodes, findVisitableNodes(file)...)
+		for _, name := range findInterfaces(file) {
+			interfaces[name] = true
+		}
 	}
 
 	slices.SortFunc(nodes, func(a, b VisitableNodeType) int {
@@ -63,8 +85,130 @@ func main() {
 		visitorMethods     []*ast.Field
 		noopVisitorMethods []ast.Decl
 		visitMethods       []ast.Decl
+
+		enterExitMethods     []*ast.Field
+		noopEnterExitMethods []ast.Decl
+		walkerMethods        []ast.Decl
+		enterCases           []ast.Stmt
+		exitCases            []ast.Stmt
+
+		folded              = make(map[string]bool)
+		foldChildrenMethods []ast.Decl
 	)
 	for _, node := range nodes {
+		foldChildrenBlock := &ast.BlockStmt{}
+		switch {
+		case node.Type == NodeTypeStruct:
+			for _, child := range node.Children {
+				field := newSelectorExpr(ast.NewIdent("n"), child.FieldName)
+				var stmt ast.Stmt
+				switch {
+				case child.TypeName == "Stmt":
+					// n.F = foldStmt(f, n.F)
+					folded[child.TypeName] = true
+					stmt = &ast.AssignStmt{
+						Lhs: []ast.Expr{field},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{&ast.CallExpr{
+							Fun:  ast.NewIdent("foldStmt"),
+							Args: []ast.Expr{ast.NewIdent("f"), field},
+						}},
+					}
+				case interfaces[child.TypeName]:
+					// n.F = f.FoldI(n.F)
+					folded[child.TypeName] = true
+					stmt = &ast.AssignStmt{
+						Lhs: []ast.Expr{field},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{&ast.CallExpr{
+							Fun:  newSelectorExpr(ast.NewIdent("f"), "Fold"+child.TypeName),
+							Args: []ast.Expr{field},
+						}},
+					}
+				default:
+					stmt = &ast.ExprStmt{X: &ast.CallExpr{
+						Fun:  newSelectorExpr(field, "FoldChildrenWith"),
+						Args: []ast.Expr{ast.NewIdent("f")},
+					}}
+				}
+				if child.Optional {
+					stmt = &ast.IfStmt{
+						Cond: &ast.BinaryExpr{X: field, Op: token.NEQ, Y: ast.NewIdent("nil")},
+						Body: &ast.BlockStmt{List: []ast.Stmt{stmt}},
+					}
+				}
+				foldChildrenBlock.List = append(foldChildrenBlock.List, stmt)
+			}
+		case node.Name == "Statements":
+			// foldStatements(f, n)
+			foldChildrenBlock.List = append(foldChildrenBlock.List, &ast.ExprStmt{X: &ast.CallExpr{
+				Fun:  ast.NewIdent("foldStatements"),
+				Args: []ast.Expr{ast.NewIdent("f"), ast.NewIdent("n")},
+			}})
+		default:
+			// for i := range *n {
+			//     (*n)[i].FoldChildrenWith(f)
+			// }
+			foldChildrenBlock.List = append(foldChildrenBlock.List, &ast.RangeStmt{
+				Key: ast.NewIdent("i"),
+				Tok: token.DEFINE,
+				X:   &ast.StarExpr{X: ast.NewIdent("n")},
+				Body: &ast.BlockStmt{List: []ast.Stmt{
+					&ast.ExprStmt{X: &ast.CallExpr{
+						Fun: newSelectorExpr(&ast.IndexExpr{
+							X:     &ast.ParenExpr{X: &ast.StarExpr{X: ast.NewIdent("n")}},
+							Index: ast.NewIdent("i"),
+						}, "FoldChildrenWith"),
+						Args: []ast.Expr{ast.NewIdent("f")},
+					}},
+				}},
+			})
+		}
+		foldChildrenMethods = append(foldChildrenMethods, &ast.FuncDecl{
+			Recv: newFieldList("n", &ast.StarExpr{X: ast.NewIdent(node.Name)}),
+			Name: ast.NewIdent("FoldChildrenWith"),
+			Type: &ast.FuncType{Params: newFieldList("f", ast.NewIdent("Folder"))},
+			Body: foldChildrenBlock,
+		})
+
+		for _, hook := range []string{"Enter", "Exit"} {
+			enterExitMethods = append(enterExitMethods, &ast.Field{
+				Names: []*ast.Ident{{Name: hook + node.Name}},
+				Type:  newActionFuncType(node.Name),
+			})
+
+			// func (*NoopEnterExitVisitor) EnterX(n *X) Action { return Continue }
+			noopEnterExitMethods = append(noopEnterExitMethods, &ast.FuncDecl{
+				Recv: &ast.FieldList{List: []*ast.Field{{Type: &ast.StarExpr{X: ast.NewIdent("NoopEnterExitVisitor")}}}},
+				Name: ast.NewIdent(hook + node.Name),
+				Type: newActionFuncType(node.Name),
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{&ast.ReturnStmt{Results: []ast.Expr{ast.NewIdent("Continue")}}},
+				},
+			})
+		}
+
+		// func (w *walker) VisitX(n *X) { w.walk(n) }
+		walkerMethods = append(walkerMethods, &ast.FuncDecl{
+			Recv: newFieldList("w", &ast.StarExpr{X: ast.NewIdent("walker")}),
+			Name: ast.NewIdent("Visit" + node.Name),
+			Type: &ast.FuncType{
+				Params: newFieldList("n", &ast.StarExpr{X: ast.NewIdent(node.Name)}),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ExprStmt{X: &ast.CallExpr{
+						Fun:  newSelectorExpr(ast.NewIdent("w"), "walk"),
+						Args: []ast.Expr{ast.NewIdent("n")},
+					}},
+				},
+			},
+		})
+
+		// case *X: return v.EnterX(n)
+		enterCases = append(enterCases, newDispatchCase(node.Name, "Enter"))
+		exitCases = append(exitCases, newDispatchCase(node.Name, "Exit"))
+
 		visitorMethods = append(visitorMethods, &ast.Field{
 			Names: []*ast.Ident{{Name: "Visit" + node.Name}},
 			Type: &ast.FuncType{
@@ -200,14 +344,133 @@ func main() {
 	genPkg.Decls = append(genPkg.Decls, noopVisitorMethods...)
 	genPkg.Decls = append(genPkg.Decls, visitMethods...)
 
+	genPkg.Decls = append(genPkg.Decls,
+		&ast.GenDecl{
+			Tok: token.TYPE,
+			Specs: []ast.Spec{
+				&ast.TypeSpec{
+					Name: ast.NewIdent("EnterExitVisitor"),
+					Type: &ast.InterfaceType{
+						Methods: &ast.FieldList{List: enterExitMethods},
+					},
+				},
+			},
+		},
+		&ast.GenDecl{
+			Tok: token.TYPE,
+			Specs: []ast.Spec{
+				&ast.TypeSpec{
+					Name: ast.NewIdent("NoopEnterExitVisitor"),
+					Type: &ast.StructType{Fields: &ast.FieldList{}},
+				},
+			},
+		},
+	)
+	genPkg.Decls = append(genPkg.Decls, noopEnterExitMethods...)
+	genPkg.Decls = append(genPkg.Decls, newDispatchFunc("enterNode", enterCases), newDispatchFunc("exitNode", exitCases))
+	genPkg.Decls = append(genPkg.Decls, walkerMethods...)
+
+	var (
+		folderMethods     []*ast.Field
+		noopFolderMethods []ast.Decl
+	)
+	for _, name := range slices.Sorted(maps.Keys(folded)) {
+		var result ast.Expr = ast.NewIdent(name)
+		ret := ast.Expr(ast.NewIdent("n"))
+		if name == "Stmt" {
+			// Statements fold to any number of statements.
+			result = &ast.ArrayType{Elt: ast.NewIdent(name)}
+			ret = &ast.CompositeLit{Type: result, Elts: []ast.Expr{ast.NewIdent("n")}}
+		}
+		funcType := &ast.FuncType{
+			Params:  newFieldList("n", ast.NewIdent(name)),
+			Results: &ast.FieldList{List: []*ast.Field{{Type: result}}},
+		}
+		folderMethods = append(folderMethods, &ast.Field{
+			Names: []*ast.Ident{{Name: "Fold" + name}},
+			Type:  funcType,
+		})
+
+		// func (nf *NoopFolder) FoldI(n I) I {
+		//     n.FoldChildrenWith(nf.F)
+		//     return n
+		// }
+		noopFolderMethods = append(noopFolderMethods, &ast.FuncDecl{
+			Recv: newFieldList("nf", &ast.StarExpr{X: ast.NewIdent("NoopFolder")}),
+			Name: ast.NewIdent("Fold" + name),
+			Type: funcType,
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				&ast.ExprStmt{X: &ast.CallExpr{
+					Fun:  newSelectorExpr(ast.NewIdent("n"), "FoldChildrenWith"),
+					Args: []ast.Expr{newSelectorExpr(ast.NewIdent("nf"), "F")},
+				}},
+				&ast.ReturnStmt{Results: []ast.Expr{ret}},
+			}},
+		})
+	}
+	genPkg.Decls = append(genPkg.Decls,
+		&ast.GenDecl{
+			Tok: token.TYPE,
+			Specs: []ast.Spec{
+				&ast.TypeSpec{
+					Name: ast.NewIdent("Folder"),
+					Type: &ast.InterfaceType{
+						Methods: &ast.FieldList{List: folderMethods},
+					},
+				},
+			},
+		},
+		&ast.GenDecl{
+			Tok: token.TYPE,
+			Specs: []ast.Spec{
+				&ast.TypeSpec{
+					Name: ast.NewIdent("NoopFolder"),
+					Type: &ast.StructType{
+						Fields: newFieldList("F", ast.NewIdent("Folder")),
+					},
+				},
+			},
+		},
+	)
+	genPkg.Decls = append(genPkg.Decls, noopFolderMethods...)
+	genPkg.Decls = append(genPkg.Decls, foldChildrenMethods...)
+
 	s := bytes.NewBuffer([]byte("// Code generated by gen_visit.go; DO NOT EDIT.\n"))
 	format.Node(s, fset, genPkg)
+	src := bytes.Replace(s.Bytes(), []byte("type Folder interface"), []byte(folderDoc+"type Folder interface"), 1)
 
-	os.WriteFile("ast/visit.go", s.Bytes(), 0644)
+	os.WriteFile("ast/visit.go", src, 0644)
 
 	fmt.Println(pkgs)
 }
 
+// findInterfaces returns the node interfaces declared in f, recognised by
+// their marker methods such as _expr.
+func findInterfaces(f *ast.File) (names []string) {
+	for _, decl := range f.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			t, ok := typeSpec.Type.(*ast.InterfaceType)
+			if !ok {
+				continue
+			}
+			if slices.ContainsFunc(t.Methods.List, func(a *ast.Field) bool {
+				return len(a.Names) != 0 && strings.HasPrefix(a.Names[0].Name, "_")
+			}) {
+				names = append(names, typeSpec.Name.Name)
+			}
+		}
+	}
+	return names
+}
+
 func findVisitableNodes(f *ast.File) (types []VisitableNodeType) {
 	for _, decl := range f.Decls {
 		genDecl, ok := decl.(*ast.GenDecl)
@@ -220,8 +483,7 @@ func findVisitableNodes(f *ast.File) (types []VisitableNodeType) {
 				continue
 			}
 
-			switch typeSpec.Name.Name {
-			case "ScopeContext", "Id":
+			if !isNodeType(typeSpec) {
 				continue
 			}
 
@@ -254,7 +516,7 @@ func findStructChildren(fields []*ast.Field) (children []Child) {
 		switch fieldType := field.Type.(type) {
 		case *ast.Ident:
 			if len(field.Names) == 0 {
-				children = append(children, newChild(fieldType.Name, optional))
+				children = append(children, newChild(fieldType.Name, optional, fieldType.Name))
 				continue
 			}
 
@@ -263,7 +525,9 @@ func findStructChildren(fields []*ast.Field) (children []Child) {
 				"UnaryOperator", "AssignmentOperator", "BinaryOperator", "UpdateOperator", "LogicalOperator":
 			default:
 				fmt.Println(fieldType.Name)
-				children = append(children, newChild(field.Names[0].Name, optional))
+				for _, name := range field.Names {
+					children = append(children, newChild(name.Name, optional, fieldType.Name))
+				}
 			}
 		case *ast.StarExpr:
 			ident, ok := fieldType.X.(*ast.Ident)
@@ -272,10 +536,12 @@ func findStructChildren(fields []*ast.Field) (children []Child) {
 				// visitable AST node — skip.
 				continue
 			}
-			if ident.Name == "string" {
+			if ident.Name == "string" || ident.Name == "LazyBody" {
 				continue
 			}
-			children = append(children, newChild(field.Names[0].Name, optional))
+			for _, name := range field.Names {
+				children = append(children, newChild(name.Name, optional, ""))
+			}
 		}
 	}
 	return children
@@ -290,6 +556,60 @@ func newFieldList(name string, t ast.Expr) *ast.FieldList {
 	}
 }
 
+// newActionFuncType returns the type of an enter or exit hook for the node
+// type name: func(n *name) Action.
+func newActionFuncType(name string) *ast.FuncType {
+	return &ast.FuncType{
+		Params:  newFieldList("n", &ast.StarExpr{X: ast.NewIdent(name)}),
+		Results: &ast.FieldList{List: []*ast.Field{{Type: ast.NewIdent("Action")}}},
+	}
+}
+
+// newDispatchCase returns the case calling the enter or exit hook of the node
+// type name.
+func newDispatchCase(name, hook string) *ast.CaseClause {
+	return &ast.CaseClause{
+		List: []ast.Expr{&ast.StarExpr{X: ast.NewIdent(name)}},
+		Body: []ast.Stmt{&ast.ReturnStmt{Results: []ast.Expr{&ast.CallExpr{
+			Fun:  newSelectorExpr(ast.NewIdent("v"), hook+name),
+			Args: []ast.Expr{ast.NewIdent("n")},
+		}}}},
+	}
+}
+
+// newDispatchFunc returns a function calling the hook of an EnterExitVisitor
+// for the dynamic type of a node:
+//
+//	func name(v EnterExitVisitor, n VisitableNode) Action {
+//		switch n := n.(type) {
+//		cases...
+//		}
+//		return Continue
+//	}
+func newDispatchFunc(name string, cases []ast.Stmt) *ast.FuncDecl {
+	return &ast.FuncDecl{
+		Name: ast.NewIdent(name),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{List: []*ast.Field{
+				{Names: []*ast.Ident{ast.NewIdent("v")}, Type: ast.NewIdent("EnterExitVisitor")},
+				{Names: []*ast.Ident{ast.NewIdent("n")}, Type: ast.NewIdent("VisitableNode")},
+			}},
+			Results: &ast.FieldList{List: []*ast.Field{{Type: ast.NewIdent("Action")}}},
+		},
+		Body: &ast.BlockStmt{List: []ast.Stmt{
+			&ast.TypeSwitchStmt{
+				Assign: &ast.AssignStmt{
+					Lhs: []ast.Expr{ast.NewIdent("n")},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{&ast.TypeAssertExpr{X: ast.NewIdent("n")}},
+				},
+				Body: &ast.BlockStmt{List: cases},
+			},
+			&ast.ReturnStmt{Results: []ast.Expr{ast.NewIdent("Continue")}},
+		}},
+	}
+}
+
 func newSelectorExpr(x ast.Expr, sel string) *ast.SelectorExpr {
 	return &ast.SelectorExpr{X: x, Sel: ast.NewIdent(sel)}
 }
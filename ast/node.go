@@ -2,8 +2,11 @@ package ast
 
 import "github.com/nukilabs/ftoa"
 
-//go:generate go run ast/gen_clone.go
-//go:generate go run ast/gen_visit.go
+//go:generate go run ast/gen_clone.go ast/gen_common.go
+//go:generate go run ast/gen_visit.go ast/gen_common.go
+//go:generate go run ast/gen_codec.go ast/gen_common.go
+//go:generate go run ast/gen_path.go ast/gen_common.go
+//go:generate go run ast/gen_equal.go ast/gen_common.go
 
 // Idx is a compact encoding of a source position within JS code.
 type Idx uint32
@@ -18,6 +21,7 @@ type Node interface {
 type VisitableNode interface {
 	VisitWith(v Visitor)
 	VisitChildrenWith(v Visitor)
+	FoldChildrenWith(f Folder)
 }
 
 type Program struct {
@@ -57,10 +61,17 @@ func (n *TemplateLiteral) Idx0() Idx       { return n.OpenQuote }
 func (n *ThisExpression) Idx0() Idx        { return n.Idx }
 func (n *SuperExpression) Idx0() Idx       { return n.Idx }
 func (n *UnaryExpression) Idx0() Idx       { return n.Idx }
-func (n *UpdateExpression) Idx0() Idx      { return n.Idx }
 func (n *MetaProperty) Idx0() Idx          { return n.Idx }
-func (m *MemberExpression) Idx0() Idx      { return 0 }
-func (m *MemberExpression) Idx1() Idx      { return 0 }
+func (m *MemberExpression) Idx0() Idx      { return m.Object.Expr.Idx0() }
+func (m *MemberExpression) Idx1() Idx {
+	switch prop := m.Property.Prop.(type) {
+	case *Identifier:
+		return prop.Idx1()
+	case *ComputedProperty:
+		return prop.RightBracket + 1
+	}
+	return m.Object.Expr.Idx1()
+}
 func (n *SpreadElement) Idx0() Idx {
 	return n.Expression.Expr.Idx0()
 }
@@ -83,7 +94,12 @@ func (n *ForOfStatement) Idx0() Idx      { return n.For }
 func (n *ForStatement) Idx0() Idx        { return n.For }
 func (n *IfStatement) Idx0() Idx         { return n.If }
 func (n *LabelledStatement) Idx0() Idx   { return n.Label.Idx0() }
-func (n *Program) Idx0() Idx             { return n.Body[0].Stmt.Idx0() }
+func (n *Program) Idx0() Idx {
+	if len(n.Body) == 0 {
+		return 0
+	}
+	return n.Body[0].Stmt.Idx0()
+}
 func (n *ReturnStatement) Idx0() Idx     { return n.Return }
 func (n *SwitchStatement) Idx0() Idx     { return n.Switch }
 func (n *ThrowStatement) Idx0() Idx      { return n.Throw }
@@ -115,7 +131,7 @@ func (b *BinaryExpression) Idx1() Idx      { return b.Right.Expr.Idx1() }
 func (b *LogicalExpression) Idx1() Idx     { return b.Right.Expr.Idx1() }
 func (b *BooleanLiteral) Idx1() Idx        { return Idx(int(b.Idx) + 4) }
 func (n *CallExpression) Idx1() Idx        { return n.RightParenthesis + 1 }
-func (n *ConditionalExpression) Idx1() Idx { return n.Test.Expr.Idx1() }
+func (n *ConditionalExpression) Idx1() Idx { return n.Alternate.Expr.Idx1() }
 func (p *PrivateDotExpression) Idx1() Idx  { return p.Identifier.Idx1() }
 func (f *FunctionLiteral) Idx1() Idx       { return f.Body.Idx1() }
 func (c *ClassLiteral) Idx1() Idx          { return c.RightBrace + 1 }
@@ -164,6 +180,12 @@ func (n *SuperExpression) Idx1() Idx { return n.Idx + 5 }
 func (n *UnaryExpression) Idx1() Idx {
 	return n.Operand.Expr.Idx1()
 }
+func (n *UpdateExpression) Idx0() Idx {
+	if n.Postfix {
+		return n.Operand.Expr.Idx0()
+	}
+	return n.Idx
+}
 func (n *UpdateExpression) Idx1() Idx {
 	if n.Postfix {
 		return n.Operand.Expr.Idx1() + 2 // x++ x--
@@ -177,17 +199,32 @@ func (n *PrivateIdentifier) Idx0() Idx {
 	return n.Identifier.Idx0()
 }
 func (n *PrivateIdentifier) Idx1() Idx {
-	return n.Identifier.Idx1()
+	return n.Identifier.Idx1() + 1 // "#"
 }
 
-func (n *BadStatement) Idx1() Idx        { return n.To }
-func (n *BlockStatement) Idx1() Idx      { return n.RightBrace + 1 }
-func (n *BreakStatement) Idx1() Idx      { return n.Idx }
-func (n *ContinueStatement) Idx1() Idx   { return n.Idx }
-func (n *CaseStatement) Idx1() Idx       { return n.Consequent[len(n.Consequent)-1].Stmt.Idx1() }
+func (n *BadStatement) Idx1() Idx   { return n.To }
+func (n *BlockStatement) Idx1() Idx { return n.RightBrace + 1 }
+func (n *BreakStatement) Idx1() Idx {
+	if n.Label != nil {
+		return n.Label.Idx1()
+	}
+	return n.Idx + 5 // "break"
+}
+func (n *ContinueStatement) Idx1() Idx {
+	if n.Label != nil {
+		return n.Label.Idx1()
+	}
+	return n.Idx + 8 // "continue"
+}
+func (n *CaseStatement) Idx1() Idx {
+	if len(n.Consequent) > 0 {
+		return n.Consequent[len(n.Consequent)-1].Stmt.Idx1()
+	}
+	return n.Colon + 1
+}
 func (n *CatchStatement) Idx1() Idx      { return n.Body.Idx1() }
 func (n *DebuggerStatement) Idx1() Idx   { return n.Debugger + 8 }
-func (n *DoWhileStatement) Idx1() Idx    { return n.Test.Expr.Idx1() }
+func (n *DoWhileStatement) Idx1() Idx    { return n.RightParenthesis + 1 }
 func (n *EmptyStatement) Idx1() Idx      { return n.Semicolon + 1 }
 func (n *ExpressionStatement) Idx1() Idx { return n.Expression.Expr.Idx1() }
 func (n *ForInStatement) Idx1() Idx      { return n.Body.Stmt.Idx1() }
@@ -199,11 +236,21 @@ func (n *IfStatement) Idx1() Idx {
 	}
 	return n.Consequent.Stmt.Idx1()
 }
-func (n *LabelledStatement) Idx1() Idx { return n.Colon + 1 }
-func (n *Program) Idx1() Idx           { return n.Body[len(n.Body)-1].Stmt.Idx1() }
-func (n *ReturnStatement) Idx1() Idx   { return n.Return + 6 }
-func (n *SwitchStatement) Idx1() Idx   { return n.Body[len(n.Body)-1].Idx1() }
-func (n *ThrowStatement) Idx1() Idx    { return n.Argument.Expr.Idx1() }
+func (n *LabelledStatement) Idx1() Idx { return n.Statement.Stmt.Idx1() }
+func (n *Program) Idx1() Idx {
+	if len(n.Body) == 0 {
+		return 0
+	}
+	return n.Body[len(n.Body)-1].Stmt.Idx1()
+}
+func (n *ReturnStatement) Idx1() Idx {
+	if n.Argument != nil && n.Argument.Expr != nil {
+		return n.Argument.Expr.Idx1()
+	}
+	return n.Return + 6 // "return"
+}
+func (n *SwitchStatement) Idx1() Idx { return n.RightBrace + 1 }
+func (n *ThrowStatement) Idx1() Idx  { return n.Argument.Expr.Idx1() }
 func (n *TryStatement) Idx1() Idx {
 	if n.Finally != nil {
 		return n.Finally.Idx1()
@@ -226,7 +273,7 @@ func (b *VariableDeclarator) Idx1() Idx {
 }
 
 func (n *PropertyShort) Idx1() Idx {
-	if n.Initializer != nil {
+	if n.Initializer != nil && n.Initializer.Expr != nil {
 		return n.Initializer.Expr.Idx1()
 	}
 	return n.Name.Idx1()
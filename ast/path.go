@@ -0,0 +1,452 @@
+// Code generated by gen_path.go; DO NOT EDIT.
+
+package ast
+
+func (t *traversal) children(p *NodePath) {
+	switch n := p.Node.(type) {
+	case *ArrayLiteral:
+		visitList(t, p, "Value", expressionsList(&n.Value))
+	case *ArrayPattern:
+		visitList(t, p, "Elements", expressionsList(&n.Elements))
+		if n.Rest != nil {
+			visitField(t, p, "Rest", &n.Rest.Expr, nil)
+		}
+	case *ArrowFunctionLiteral:
+		if n.ParameterList != nil {
+			visitField(t, p, "ParameterList", &n.ParameterList, nil)
+		}
+		if n.Body != nil {
+			visitField(t, p, "Body", &n.Body.Body, nil)
+		}
+	case *AssignExpression:
+		if n.Left != nil {
+			visitField(t, p, "Left", &n.Left.Expr, nil)
+		}
+		if n.Right != nil {
+			visitField(t, p, "Right", &n.Right.Expr, nil)
+		}
+	case *AwaitExpression:
+		if n.Argument != nil {
+			visitField(t, p, "Argument", &n.Argument.Expr, nil)
+		}
+	case *BinaryExpression:
+		if n.Left != nil {
+			visitField(t, p, "Left", &n.Left.Expr, nil)
+		}
+		if n.Right != nil {
+			visitField(t, p, "Right", &n.Right.Expr, nil)
+		}
+	case *BindingTarget:
+		visitField(t, p, "Target", &n.Target, nil)
+	case *BlockStatement:
+		visitList(t, p, "List", statementsList(&n.List))
+	case *BreakStatement:
+		if n.Label != nil {
+			visitField(t, p, "Label", &n.Label, func() { n.Label = nil })
+		}
+	case *CallExpression:
+		if n.Callee != nil {
+			visitField(t, p, "Callee", &n.Callee.Expr, nil)
+		}
+		visitList(t, p, "ArgumentList", expressionsList(&n.ArgumentList))
+	case *CaseStatement:
+		if n.Test != nil {
+			visitField(t, p, "Test", &n.Test.Expr, func() { n.Test = nil })
+		}
+		visitList(t, p, "Consequent", statementsList(&n.Consequent))
+	case *CaseStatements:
+		visitList(t, p, "", caseStatementsList(n))
+	case *CatchStatement:
+		if n.Parameter != nil {
+			visitField(t, p, "Parameter", &n.Parameter.Target, func() { n.Parameter = nil })
+		}
+		if n.Body != nil {
+			visitField(t, p, "Body", &n.Body, nil)
+		}
+	case *ClassDeclaration:
+		if n.Class != nil {
+			visitField(t, p, "Class", &n.Class, nil)
+		}
+	case *ClassElement:
+		visitField(t, p, "Element", &n.Element, nil)
+	case *ClassElements:
+		visitList(t, p, "", classElementsList(n))
+	case *ClassLiteral:
+		if n.Name != nil {
+			visitField(t, p, "Name", &n.Name, func() { n.Name = nil })
+		}
+		if n.SuperClass != nil {
+			visitField(t, p, "SuperClass", &n.SuperClass.Expr, func() { n.SuperClass = nil })
+		}
+		visitList(t, p, "Body", classElementsList(&n.Body))
+	case *ClassStaticBlock:
+		if n.Block != nil {
+			visitField(t, p, "Block", &n.Block, nil)
+		}
+	case *ComputedProperty:
+		if n.Expr != nil {
+			visitField(t, p, "Expr", &n.Expr.Expr, nil)
+		}
+	case *ConciseBody:
+		visitField(t, p, "Body", &n.Body, nil)
+	case *ConditionalExpression:
+		if n.Test != nil {
+			visitField(t, p, "Test", &n.Test.Expr, nil)
+		}
+		if n.Consequent != nil {
+			visitField(t, p, "Consequent", &n.Consequent.Expr, nil)
+		}
+		if n.Alternate != nil {
+			visitField(t, p, "Alternate", &n.Alternate.Expr, nil)
+		}
+	case *ContinueStatement:
+		if n.Label != nil {
+			visitField(t, p, "Label", &n.Label, func() { n.Label = nil })
+		}
+	case *DoWhileStatement:
+		if n.Test != nil {
+			visitField(t, p, "Test", &n.Test.Expr, nil)
+		}
+		if n.Body != nil {
+			visitField(t, p, "Body", &n.Body.Stmt, nil)
+		}
+	case *Expression:
+		visitField(t, p, "Expr", &n.Expr, nil)
+	case *ExpressionStatement:
+		if n.Expression != nil {
+			visitField(t, p, "Expression", &n.Expression.Expr, nil)
+		}
+	case *Expressions:
+		visitList(t, p, "", expressionsList(n))
+	case *FieldDefinition:
+		if n.Key != nil {
+			visitField(t, p, "Key", &n.Key.Expr, nil)
+		}
+		if n.Initializer != nil {
+			visitField(t, p, "Initializer", &n.Initializer.Expr, func() { n.Initializer = nil })
+		}
+	case *ForInStatement:
+		if n.Into != nil {
+			visitField(t, p, "Into", &n.Into.Into, nil)
+		}
+		if n.Source != nil {
+			visitField(t, p, "Source", &n.Source.Expr, nil)
+		}
+		if n.Body != nil {
+			visitField(t, p, "Body", &n.Body.Stmt, nil)
+		}
+	case *ForInto:
+		visitField(t, p, "Into", &n.Into, nil)
+	case *ForLoopInitializer:
+		visitField(t, p, "Initializer", &n.Initializer, nil)
+	case *ForOfStatement:
+		if n.Into != nil {
+			visitField(t, p, "Into", &n.Into.Into, nil)
+		}
+		if n.Source != nil {
+			visitField(t, p, "Source", &n.Source.Expr, nil)
+		}
+		if n.Body != nil {
+			visitField(t, p, "Body", &n.Body.Stmt, nil)
+		}
+	case *ForStatement:
+		if n.Initializer != nil {
+			visitField(t, p, "Initializer", &n.Initializer.Initializer, func() { n.Initializer = nil })
+		}
+		if n.Update != nil {
+			visitField(t, p, "Update", &n.Update.Expr, nil)
+		}
+		if n.Test != nil {
+			visitField(t, p, "Test", &n.Test.Expr, nil)
+		}
+		if n.Body != nil {
+			visitField(t, p, "Body", &n.Body.Stmt, nil)
+		}
+	case *FunctionDeclaration:
+		if n.Function != nil {
+			visitField(t, p, "Function", &n.Function, nil)
+		}
+	case *FunctionLiteral:
+		if n.Name != nil {
+			visitField(t, p, "Name", &n.Name, func() { n.Name = nil })
+		}
+		if n.ParameterList != nil {
+			visitField(t, p, "ParameterList", &n.ParameterList, nil)
+		}
+		if n.Body != nil {
+			visitField(t, p, "Body", &n.Body, nil)
+		}
+	case *IfStatement:
+		if n.Test != nil {
+			visitField(t, p, "Test", &n.Test.Expr, nil)
+		}
+		if n.Consequent != nil {
+			visitField(t, p, "Consequent", &n.Consequent.Stmt, nil)
+		}
+		if n.Alternate != nil {
+			visitField(t, p, "Alternate", &n.Alternate.Stmt, func() { n.Alternate = nil })
+		}
+	case *LabelledStatement:
+		if n.Label != nil {
+			visitField(t, p, "Label", &n.Label, nil)
+		}
+		if n.Statement != nil {
+			visitField(t, p, "Statement", &n.Statement.Stmt, nil)
+		}
+	case *LogicalExpression:
+		if n.Left != nil {
+			visitField(t, p, "Left", &n.Left.Expr, nil)
+		}
+		if n.Right != nil {
+			visitField(t, p, "Right", &n.Right.Expr, nil)
+		}
+	case *MemberExpression:
+		if n.Object != nil {
+			visitField(t, p, "Object", &n.Object.Expr, nil)
+		}
+		if n.Property != nil {
+			visitField(t, p, "Property", &n.Property.Prop, nil)
+		}
+	case *MemberProperty:
+		visitField(t, p, "Prop", &n.Prop, nil)
+	case *MetaProperty:
+		if n.Meta != nil {
+			visitField(t, p, "Meta", &n.Meta, nil)
+		}
+		if n.Property != nil {
+			visitField(t, p, "Property", &n.Property, nil)
+		}
+	case *MethodDefinition:
+		if n.Key != nil {
+			visitField(t, p, "Key", &n.Key.Expr, nil)
+		}
+		if n.Body != nil {
+			visitField(t, p, "Body", &n.Body, nil)
+		}
+	case *NewExpression:
+		if n.Callee != nil {
+			visitField(t, p, "Callee", &n.Callee.Expr, nil)
+		}
+		visitList(t, p, "ArgumentList", expressionsList(&n.ArgumentList))
+	case *ObjectLiteral:
+		visitList(t, p, "Value", propertiesList(&n.Value))
+	case *ObjectPattern:
+		visitList(t, p, "Properties", propertiesList(&n.Properties))
+		if n.Rest != nil {
+			visitField(t, p, "Rest", &n.Rest, func() { n.Rest = nil })
+		}
+	case *Optional:
+		if n.Expr != nil {
+			visitField(t, p, "Expr", &n.Expr.Expr, nil)
+		}
+	case *OptionalChain:
+		if n.Base != nil {
+			visitField(t, p, "Base", &n.Base.Expr, nil)
+		}
+	case *ParameterList:
+		visitList(t, p, "List", variableDeclaratorsList(&n.List))
+		if n.Rest != nil {
+			visitField(t, p, "Rest", &n.Rest, func() { n.Rest = nil })
+		}
+	case *PrivateDotExpression:
+		if n.Left != nil {
+			visitField(t, p, "Left", &n.Left.Expr, nil)
+		}
+		if n.Identifier != nil {
+			visitField(t, p, "Identifier", &n.Identifier, nil)
+		}
+	case *PrivateIdentifier:
+		if n.Identifier != nil {
+			visitField(t, p, "Identifier", &n.Identifier, nil)
+		}
+	case *Program:
+		visitList(t, p, "Body", statementsList(&n.Body))
+	case *Properties:
+		visitList(t, p, "", propertiesList(n))
+	case *Property:
+		visitField(t, p, "Prop", &n.Prop, nil)
+	case *PropertyKeyed:
+		if n.Key != nil {
+			visitField(t, p, "Key", &n.Key.Expr, nil)
+		}
+		if n.Value != nil {
+			visitField(t, p, "Value", &n.Value.Expr, nil)
+		}
+	case *PropertyShort:
+		if n.Name != nil {
+			visitField(t, p, "Name", &n.Name, nil)
+		}
+		if n.Initializer != nil {
+			visitField(t, p, "Initializer", &n.Initializer.Expr, nil)
+		}
+	case *ReturnStatement:
+		if n.Argument != nil {
+			visitField(t, p, "Argument", &n.Argument.Expr, func() { n.Argument = nil })
+		}
+	case *SequenceExpression:
+		visitList(t, p, "Sequence", expressionsList(&n.Sequence))
+	case *SpreadElement:
+		if n.Expression != nil {
+			visitField(t, p, "Expression", &n.Expression.Expr, nil)
+		}
+	case *Statement:
+		visitField(t, p, "Stmt", &n.Stmt, nil)
+	case *Statements:
+		visitList(t, p, "", statementsList(n))
+	case *SwitchStatement:
+		if n.Discriminant != nil {
+			visitField(t, p, "Discriminant", &n.Discriminant.Expr, nil)
+		}
+		visitList(t, p, "Body", caseStatementsList(&n.Body))
+	case *TemplateElements:
+		visitList(t, p, "", templateElementsList(n))
+	case *TemplateLiteral:
+		if n.Tag != nil {
+			visitField(t, p, "Tag", &n.Tag.Expr, func() { n.Tag = nil })
+		}
+		visitList(t, p, "Elements", templateElementsList(&n.Elements))
+		visitList(t, p, "Expressions", expressionsList(&n.Expressions))
+	case *ThrowStatement:
+		if n.Argument != nil {
+			visitField(t, p, "Argument", &n.Argument.Expr, nil)
+		}
+	case *TryStatement:
+		if n.Body != nil {
+			visitField(t, p, "Body", &n.Body, nil)
+		}
+		if n.Catch != nil {
+			visitField(t, p, "Catch", &n.Catch, func() { n.Catch = nil })
+		}
+		if n.Finally != nil {
+			visitField(t, p, "Finally", &n.Finally, func() { n.Finally = nil })
+		}
+	case *UnaryExpression:
+		if n.Operand != nil {
+			visitField(t, p, "Operand", &n.Operand.Expr, nil)
+		}
+	case *UpdateExpression:
+		if n.Operand != nil {
+			visitField(t, p, "Operand", &n.Operand.Expr, nil)
+		}
+	case *VariableDeclaration:
+		visitList(t, p, "List", variableDeclaratorsList(&n.List))
+	case *VariableDeclarator:
+		if n.Target != nil {
+			visitField(t, p, "Target", &n.Target.Target, nil)
+		}
+		if n.Initializer != nil {
+			visitField(t, p, "Initializer", &n.Initializer.Expr, func() { n.Initializer = nil })
+		}
+	case *VariableDeclarators:
+		visitList(t, p, "", variableDeclaratorsList(n))
+	case *WhileStatement:
+		if n.Test != nil {
+			visitField(t, p, "Test", &n.Test.Expr, nil)
+		}
+		if n.Body != nil {
+			visitField(t, p, "Body", &n.Body.Stmt, nil)
+		}
+	case *WithStatement:
+		if n.Object != nil {
+			visitField(t, p, "Object", &n.Object.Expr, nil)
+		}
+		if n.Body != nil {
+			visitField(t, p, "Body", &n.Body.Stmt, nil)
+		}
+	case *YieldExpression:
+		if n.Argument != nil {
+			visitField(t, p, "Argument", &n.Argument.Expr, nil)
+		}
+	}
+}
+
+func caseStatementsList(l *CaseStatements) *listOf[CaseStatements, CaseStatement] {
+	return &listOf[CaseStatements, CaseStatement]{
+		l: l,
+		elem: func(e *CaseStatement) VisitableNode {
+			return e
+		},
+		wrap: func(n VisitableNode) (CaseStatement, bool) {
+			if v, ok := n.(*CaseStatement); ok && v != nil {
+				return *v, true
+			}
+			return CaseStatement{}, false
+		},
+	}
+}
+func classElementsList(l *ClassElements) *listOf[ClassElements, ClassElement] {
+	return &listOf[ClassElements, ClassElement]{
+		l: l,
+		elem: func(e *ClassElement) VisitableNode {
+			return e.Element
+		},
+		wrap: func(n VisitableNode) (ClassElement, bool) {
+			v, ok := n.(Element)
+			return ClassElement{Element: v}, ok
+		},
+	}
+}
+func expressionsList(l *Expressions) *listOf[Expressions, Expression] {
+	return &listOf[Expressions, Expression]{
+		l: l,
+		elem: func(e *Expression) VisitableNode {
+			return e.Expr
+		},
+		wrap: func(n VisitableNode) (Expression, bool) {
+			v, ok := n.(Expr)
+			return Expression{Expr: v}, ok
+		},
+	}
+}
+func propertiesList(l *Properties) *listOf[Properties, Property] {
+	return &listOf[Properties, Property]{
+		l: l,
+		elem: func(e *Property) VisitableNode {
+			return e.Prop
+		},
+		wrap: func(n VisitableNode) (Property, bool) {
+			v, ok := n.(Prop)
+			return Property{Prop: v}, ok
+		},
+	}
+}
+func statementsList(l *Statements) *listOf[Statements, Statement] {
+	return &listOf[Statements, Statement]{
+		l: l,
+		elem: func(e *Statement) VisitableNode {
+			return e.Stmt
+		},
+		wrap: func(n VisitableNode) (Statement, bool) {
+			v, ok := n.(Stmt)
+			return Statement{Stmt: v}, ok
+		},
+	}
+}
+func templateElementsList(l *TemplateElements) *listOf[TemplateElements, TemplateElement] {
+	return &listOf[TemplateElements, TemplateElement]{
+		l: l,
+		elem: func(e *TemplateElement) VisitableNode {
+			return e
+		},
+		wrap: func(n VisitableNode) (TemplateElement, bool) {
+			if v, ok := n.(*TemplateElement); ok && v != nil {
+				return *v, true
+			}
+			return TemplateElement{}, false
+		},
+	}
+}
+func variableDeclaratorsList(l *VariableDeclarators) *listOf[VariableDeclarators, VariableDeclarator] {
+	return &listOf[VariableDeclarators, VariableDeclarator]{
+		l: l,
+		elem: func(e *VariableDeclarator) VisitableNode {
+			return e
+		},
+		wrap: func(n VisitableNode) (VariableDeclarator, bool) {
+			if v, ok := n.(*VariableDeclarator); ok && v != nil {
+				return *v, true
+			}
+			return VariableDeclarator{}, false
+		},
+	}
+}
@@ -35,6 +35,8 @@ type (
 
 	ComputedProperty struct {
 		Expr *Expression
+
+		RightBracket Idx
 	}
 )
 
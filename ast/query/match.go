@@ -0,0 +1,252 @@
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sync"
+
+	"github.com/t14raptor/go-fast/ast"
+)
+
+// entry is a node of the tree a selector is matched against.
+type entry struct {
+	node ast.VisitableNode
+	typ  string
+	// parent is the closest ancestor that is not a wrapper or list.
+	parent *entry
+	// prev is the previous element of the list holding the node, if any.
+	prev *entry
+	// The descendants of the node are entries[pos+1 : end].
+	pos, end int
+}
+
+type index struct {
+	entries []*entry
+}
+
+// frame is a node on the stack of the walk building an index.
+type frame struct {
+	// e is the entry of the node, or nil for wrappers and lists.
+	e    *entry
+	list bool
+	// last is the last entry found directly in the node.
+	last *entry
+}
+
+func newIndex(root ast.VisitableNode) *index {
+	idx := &index{}
+	var stack []*frame
+	ast.Inspect(root, func(n ast.VisitableNode) bool {
+		if n == nil {
+			f := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if f.e != nil {
+				f.e.end = len(idx.entries)
+			}
+			return true
+		}
+		t := reflect.TypeOf(n).Elem()
+		f := &frame{list: t.Kind() == reflect.Slice}
+		if !f.list && !isWrapper(t) {
+			f.e = &entry{node: n, typ: t.Name(), pos: len(idx.entries)}
+			idx.entries = append(idx.entries, f.e)
+			// The node belongs to the closest list or node above it.
+			for i := len(stack) - 1; i >= 0; i-- {
+				if up := stack[i]; up.list || up.e != nil {
+					if up.list {
+						f.e.prev = up.last
+						up.last = f.e
+					}
+					break
+				}
+			}
+			for i := len(stack) - 1; i >= 0; i-- {
+				if stack[i].e != nil {
+					f.e.parent = stack[i].e
+					break
+				}
+			}
+		}
+		stack = append(stack, f)
+		return true
+	})
+	return idx
+}
+
+var wrappers sync.Map // reflect.Type → bool
+
+// isWrapper reports whether t is a struct holding only an interface, such
+// as Expression.
+func isWrapper(t reflect.Type) bool {
+	if w, ok := wrappers.Load(t); ok {
+		return w.(bool)
+	}
+	w := t.Kind() == reflect.Struct && t.NumField() == 1 && t.Field(0).Type.Kind() == reflect.Interface
+	wrappers.Store(t, w)
+	return w
+}
+
+func (idx *index) matchAny(alts []complexSelector, e, scope *entry) bool {
+	for i := range alts {
+		if idx.match(&alts[i], len(alts[i].compounds)-1, e, scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// match reports whether e matches c up to its i-th compound selector.
+func (idx *index) match(c *complexSelector, i int, e, scope *entry) bool {
+	if !idx.matchCompound(&c.compounds[i], e, scope) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	switch c.combinators[i-1] {
+	case '>':
+		return e.parent != nil && idx.match(c, i-1, e.parent, scope)
+	case '+':
+		return e.prev != nil && idx.match(c, i-1, e.prev, scope)
+	case '~':
+		for p := e.prev; p != nil; p = p.prev {
+			if idx.match(c, i-1, p, scope) {
+				return true
+			}
+		}
+	default:
+		for p := e.parent; p != nil; p = p.parent {
+			if idx.match(c, i-1, p, scope) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (idx *index) matchCompound(c *compound, e, scope *entry) bool {
+	if c.scope && e != scope || c.typ != "" && c.typ != e.typ {
+		return false
+	}
+	for i := range c.attrs {
+		if !c.attrs[i].match(e.node) {
+			return false
+		}
+	}
+	for _, pc := range c.pseudo {
+		switch pc.name {
+		case "has":
+			found := false
+			for _, d := range idx.entries[e.pos+1 : e.end] {
+				if idx.matchAny(pc.alts, d, e) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		case "matches":
+			if !idx.matchAny(pc.alts, e, scope) {
+				return false
+			}
+		case "not":
+			if idx.matchAny(pc.alts, e, scope) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (a *attribute) match(n ast.VisitableNode) bool {
+	v, ok := field(reflect.ValueOf(n), a.path)
+	if !ok {
+		return a.op == "!="
+	}
+	if a.op == "" {
+		return !v.IsZero()
+	}
+	var got any
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		got = s.String()
+	} else {
+		switch v.Kind() {
+		case reflect.String:
+			got = v.String()
+		case reflect.Bool:
+			got = v.Bool()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			got = float64(v.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			got = float64(v.Uint())
+		case reflect.Float32, reflect.Float64:
+			got = v.Float()
+		}
+	}
+	switch a.op {
+	case "=":
+		return equal(got, a.value)
+	case "!=":
+		return !equal(got, a.value)
+	}
+	x, ok := got.(float64)
+	if !ok {
+		return false
+	}
+	y := a.value.(float64)
+	switch a.op {
+	case "<":
+		return x < y
+	case "<=":
+		return x <= y
+	case ">":
+		return x > y
+	default:
+		return x >= y
+	}
+}
+
+func equal(got, want any) bool {
+	if re, ok := want.(*regexp.Regexp); ok {
+		s, ok := got.(string)
+		return ok && re.MatchString(s)
+	}
+	return got == want
+}
+
+// field returns the field of v at path, looking through pointers and
+// wrappers. It reports false if a field is missing or nil.
+func field(v reflect.Value, path []string) (reflect.Value, bool) {
+	for _, name := range path {
+		var ok bool
+		if v, ok = deref(v); !ok {
+			return v, false
+		}
+		for v.Kind() == reflect.Struct && isWrapper(v.Type()) && v.Type().Field(0).Name != name {
+			if v, ok = deref(v.Field(0)); !ok {
+				return v, false
+			}
+		}
+		if v.Kind() != reflect.Struct {
+			return v, false
+		}
+		if v = v.FieldByName(name); !v.IsValid() {
+			return v, false
+		}
+	}
+	return deref(v)
+}
+
+// deref follows the pointers and interfaces in v, reporting false if one
+// is nil.
+func deref(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
@@ -0,0 +1,316 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// complexSelector is a chain of compound selectors, matched from the last.
+type complexSelector struct {
+	compounds []compound
+	// combinators[i] joins compounds[i] and compounds[i+1]: ' ', '>', '~'
+	// or '+'.
+	combinators []byte
+}
+
+type compound struct {
+	// typ is the node type name, or empty for any node.
+	typ    string
+	attrs  []attribute
+	pseudo []pseudoClass
+	// scope matches only the node a :has is tested on.
+	scope bool
+}
+
+type attribute struct {
+	path []string
+	// op is empty to test that the field is set.
+	op string
+	// value is a string, float64, bool or *regexp.Regexp.
+	value any
+}
+
+type pseudoClass struct {
+	// name is has, matches or not.
+	name string
+	alts []complexSelector
+}
+
+type selectorParser struct {
+	src string
+	pos int
+}
+
+func (p *selectorParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s at offset %d of %q", ErrSyntax, fmt.Sprintf(format, args...), p.pos, p.src)
+}
+
+func (p *selectorParser) parse() ([]complexSelector, error) {
+	alts, err := p.selectorList(false)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.src) {
+		return nil, p.errorf("unexpected %q", p.src[p.pos])
+	}
+	return alts, nil
+}
+
+func (p *selectorParser) peek() byte {
+	if p.pos < len(p.src) {
+		return p.src[p.pos]
+	}
+	return 0
+}
+
+func (p *selectorParser) skipSpace() bool {
+	start := p.pos
+	for p.pos < len(p.src) && strings.IndexByte(" \t\r\n", p.src[p.pos]) != -1 {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+func (p *selectorParser) expect(c byte) error {
+	p.skipSpace()
+	if p.peek() != c {
+		return p.errorf("expected %q", c)
+	}
+	p.pos++
+	return nil
+}
+
+// selectorList parses comma-separated complex selectors. Within :has they
+// are relative to the node tested and may start with >.
+func (p *selectorParser) selectorList(relative bool) ([]complexSelector, error) {
+	var alts []complexSelector
+	for {
+		c, err := p.complex(relative)
+		if err != nil {
+			return nil, err
+		}
+		alts = append(alts, c)
+		p.skipSpace()
+		if p.peek() != ',' {
+			return alts, nil
+		}
+		p.pos++
+	}
+}
+
+func (p *selectorParser) complex(relative bool) (complexSelector, error) {
+	var c complexSelector
+	p.skipSpace()
+	if relative {
+		c.compounds = append(c.compounds, compound{scope: true})
+		if p.peek() == '>' {
+			p.pos++
+			c.combinators = append(c.combinators, '>')
+		} else {
+			c.combinators = append(c.combinators, ' ')
+		}
+		p.skipSpace()
+	}
+	for {
+		comp, err := p.compound()
+		if err != nil {
+			return c, err
+		}
+		c.compounds = append(c.compounds, comp)
+
+		space := p.skipSpace()
+		switch next := p.peek(); next {
+		case '>', '~', '+':
+			p.pos++
+			p.skipSpace()
+			c.combinators = append(c.combinators, next)
+		case ',', ')', 0:
+			return c, nil
+		default:
+			if !space {
+				return c, p.errorf("unexpected %q", next)
+			}
+			c.combinators = append(c.combinators, ' ')
+		}
+	}
+}
+
+func (p *selectorParser) compound() (compound, error) {
+	var c compound
+	start := p.pos
+	if p.peek() == '*' {
+		p.pos++
+	} else {
+		c.typ = p.ident()
+	}
+	for {
+		switch p.peek() {
+		case '[':
+			p.pos++
+			a, err := p.attribute()
+			if err != nil {
+				return c, err
+			}
+			c.attrs = append(c.attrs, a)
+		case ':':
+			p.pos++
+			pc, err := p.pseudoClass()
+			if err != nil {
+				return c, err
+			}
+			c.pseudo = append(c.pseudo, pc)
+		default:
+			if p.pos == start {
+				return c, p.errorf("expected a selector")
+			}
+			return c, nil
+		}
+	}
+}
+
+func (p *selectorParser) ident() string {
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c != '_' && c != '$' && !('a' <= c|0x20 && c|0x20 <= 'z') && !(p.pos > start && '0' <= c && c <= '9') {
+			break
+		}
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *selectorParser) attribute() (attribute, error) {
+	var a attribute
+	for {
+		p.skipSpace()
+		name := p.ident()
+		if name == "" {
+			return a, p.errorf("expected a field name")
+		}
+		a.path = append(a.path, name)
+		if p.peek() != '.' {
+			break
+		}
+		p.pos++
+	}
+	p.skipSpace()
+	for _, op := range []string{"!=", "<=", ">=", "=", "<", ">"} {
+		if strings.HasPrefix(p.src[p.pos:], op) {
+			a.op = op
+			p.pos += len(op)
+			break
+		}
+	}
+	if a.op != "" {
+		p.skipSpace()
+		v, err := p.value()
+		if err != nil {
+			return a, err
+		}
+		if _, ok := v.(float64); !ok && a.op != "=" && a.op != "!=" {
+			return a, p.errorf("%s needs a number", a.op)
+		}
+		a.value = v
+	}
+	return a, p.expect(']')
+}
+
+func (p *selectorParser) value() (any, error) {
+	switch c := p.peek(); {
+	case c == '"' || c == '\'':
+		return p.quoted(c)
+	case c == '/':
+		return p.regexp()
+	case c == '-' || c == '.' || '0' <= c && c <= '9':
+		start := p.pos
+		p.pos++
+		for p.pos < len(p.src) && strings.IndexByte("0123456789.eE", p.src[p.pos]) != -1 {
+			p.pos++
+		}
+		v, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+		if err != nil {
+			p.pos = start
+			return nil, p.errorf("invalid number")
+		}
+		return v, nil
+	}
+	switch word := p.ident(); word {
+	case "":
+		return nil, p.errorf("expected a value")
+	case "true", "false":
+		return word == "true", nil
+	default:
+		return word, nil
+	}
+}
+
+// quoted parses a string in quotes q. A backslash escapes the character
+// after it.
+func (p *selectorParser) quoted(q byte) (string, error) {
+	var b strings.Builder
+	for p.pos++; p.pos < len(p.src); p.pos++ {
+		switch c := p.src[p.pos]; {
+		case c == q:
+			p.pos++
+			return b.String(), nil
+		case c == '\\' && p.pos+1 < len(p.src):
+			p.pos++
+			b.WriteByte(p.src[p.pos])
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+// regexp parses /pattern/flags, with flags among i, m and s.
+func (p *selectorParser) regexp() (*regexp.Regexp, error) {
+	start := p.pos
+	end := start + 1
+	for ; end < len(p.src) && p.src[end] != '/'; end++ {
+		if p.src[end] == '\\' {
+			end++
+		}
+	}
+	if end >= len(p.src) {
+		return nil, p.errorf("unterminated regular expression")
+	}
+	pattern := p.src[start+1 : end]
+	p.pos = end + 1
+	flags := p.ident()
+	if strings.Trim(flags, "ims") != "" {
+		return nil, p.errorf("invalid regular expression flags %q", flags)
+	}
+	if flags != "" {
+		pattern = "(?" + flags + ")" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("%v", err)
+	}
+	return re, nil
+}
+
+func (p *selectorParser) pseudoClass() (pseudoClass, error) {
+	pc := pseudoClass{name: p.ident()}
+	switch pc.name {
+	case "is":
+		pc.name = "matches"
+	case "has", "matches", "not":
+	default:
+		return pc, p.errorf("unknown pseudo-class :%s", pc.name)
+	}
+	if err := p.expect('('); err != nil {
+		return pc, err
+	}
+	alts, err := p.selectorList(pc.name == "has")
+	if err != nil {
+		return pc, err
+	}
+	pc.alts = alts
+	return pc, p.expect(')')
+}
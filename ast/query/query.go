@@ -0,0 +1,94 @@
+// Package query finds nodes with CSS-like selectors over node types and
+// fields:
+//
+//	query.Query(program, `CallExpression[Callee.Name="_0x1234"] > NumberLiteral`)
+//
+// A selector is a comma-separated list of alternatives. Each alternative is
+// a chain of compound selectors joined by combinators:
+//
+//	A B   B is a descendant of A
+//	A > B B is a child of A
+//	A ~ B B follows A in the same list
+//	A + B B immediately follows A in the same list
+//
+// A compound selector is a node type name, such as CallExpression, or *
+// for any node, followed by any number of attribute tests and pseudo
+// classes:
+//
+//	[Async]              the field is set: not nil, zero, false or empty
+//	[Callee.Name="f"]    the field equals a string, number, true or false
+//	[Name!=f]            the field does not equal it; bare words are strings
+//	[Value>=2]           the field compares to a number with <, <=, > or >=
+//	[Name=/^_0x/i]       the field matches a regular expression
+//	:has(Identifier)     a descendant matches; :has(> Identifier) a child
+//	:matches(A, B)       the node matches one of the selectors; :is is the same
+//	:not(A, B)           the node matches none of the selectors
+//
+// Field paths use the names of Go struct fields and look through pointers
+// and wrappers such as Expression and Statement, so Callee.Name is the name
+// of an identifier callee. Operators, tokens and other fields with a String
+// method compare as their string, as in [Operator="+"].
+//
+// Wrappers and lists are not nodes to selectors: the child of a
+// CallExpression is its callee or an argument, never an Expression or
+// Expressions, and only the elements of one list are siblings.
+package query
+
+import (
+	"errors"
+
+	"github.com/t14raptor/go-fast/ast"
+)
+
+// ErrSyntax is wrapped by the errors of invalid selectors.
+var ErrSyntax = errors.New("query: invalid selector")
+
+// Selector is a compiled selector.
+type Selector struct {
+	src  string
+	alts []complexSelector
+}
+
+// Compile parses a selector.
+func Compile(selector string) (*Selector, error) {
+	p := &selectorParser{src: selector}
+	alts, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	return &Selector{src: selector, alts: alts}, nil
+}
+
+// MustCompile is like Compile but panics if the selector is invalid.
+func MustCompile(selector string) *Selector {
+	s, err := Compile(selector)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// String returns the source of the selector.
+func (s *Selector) String() string {
+	return s.src
+}
+
+// Query returns the nodes in the tree rooted at n, n included, that match
+// the selector, in the order they appear in the tree.
+func (s *Selector) Query(n ast.VisitableNode) []ast.VisitableNode {
+	idx := newIndex(n)
+	var found []ast.VisitableNode
+	for _, e := range idx.entries {
+		if idx.matchAny(s.alts, e, nil) {
+			found = append(found, e.node)
+		}
+	}
+	return found
+}
+
+// Query returns the nodes in the tree rooted at n that match selector. It
+// panics if the selector is invalid; use Compile for selectors that are not
+// fixed when the program is written.
+func Query(n ast.VisitableNode, selector string) []ast.VisitableNode {
+	return MustCompile(selector).Query(n)
+}
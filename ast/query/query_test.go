@@ -0,0 +1,72 @@
+package query_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/t14raptor/go-fast/ast/query"
+	"github.com/t14raptor/go-fast/generator"
+	"github.com/t14raptor/go-fast/parser"
+)
+
+const src = `
+_0x1234(1, "a");
+_0x1234(x, 2);
+window.foo;
+for (;;) { window.bar(); if (a) { window.baz = 1; } }
+async function f(a, b) { return a + b * 2; }
+let [p, q] = [1, 2];
+`
+
+func TestQuery(t *testing.T) {
+	program, err := parser.ParseFile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		selector string
+		want     []string
+	}{
+		{`CallExpression[Callee.Name="_0x1234"] > NumberLiteral`, []string{"1", "2"}},
+		{`CallExpression[Callee.Name=/^_0x/] > :matches(StringLiteral, Identifier)`, []string{"_0x1234", `"a"`, "_0x1234", "x"}},
+		{`ForStatement MemberExpression[Object.Name=window]`, []string{"window.bar", "window.baz"}},
+		{`MemberExpression[Object.Name=window]:not(ForStatement *)`, []string{"window.foo"}},
+		{`ExpressionStatement:has(> CallExpression > StringLiteral)`, []string{`_0x1234(1,"a");`}},
+		{`ExpressionStatement:has(AssignExpression)`, []string{"window.baz=1;"}},
+		{`BlockStatement > ExpressionStatement + IfStatement`, []string{"if(a){window.baz=1;}"}},
+		{`ExpressionStatement ~ ForStatement > IfStatement`, nil},
+		{`ExpressionStatement ~ ForStatement > * > IfStatement`, []string{"if(a){window.baz=1;}"}},
+		{`FunctionDeclaration ~ VariableDeclaration ArrayLiteral > NumberLiteral[Value>1]`, []string{"2"}},
+		{`BinaryExpression[Operator="*"], FunctionLiteral[Async] > Identifier`, []string{"f", "b*2"}},
+		{`VariableDeclaration[Token=let] ArrayPattern Identifier[Name!=q]`, []string{"p"}},
+	}
+	for _, tt := range tests {
+		found := query.Query(program, tt.selector)
+		var got []string
+		for _, n := range found {
+			got = append(got, generator.GenerateMinified(n))
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Query(%s) = %q; want %q", tt.selector, got, tt.want)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, selector := range []string{
+		"",
+		"CallExpression >",
+		"[Name",
+		`[Name="a]`,
+		"[Value<a]",
+		"[Name=/(/]",
+		":first-child",
+		":has()",
+		"A B)",
+	} {
+		if _, err := query.Compile(selector); !errors.Is(err, query.ErrSyntax) {
+			t.Errorf("Compile(%q) error = %v; want ErrSyntax", selector, err)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package rewrite
+
+import (
+	"reflect"
+
+	"github.com/t14raptor/go-fast/ast"
+)
+
+// Find returns the matches of the rule in the tree rooted at n, in the
+// order they appear, without rewriting it.
+func (r *Rule) Find(n ast.VisitableNode) []Match {
+	v := &rewriter{rule: r}
+	v.V = v
+	n.VisitWith(v)
+	return v.matches
+}
+
+// Apply replaces the matches of the rule in the tree rooted at n and
+// returns them, in the order they appear. After replacing a match it goes
+// on with the code bound to metavariables that the replacement holds, so
+// matches nested in it are replaced too. The rest of the replacement is not
+// searched again, so a replacement that matches its own pattern does not
+// recurse.
+func (r *Rule) Apply(n ast.VisitableNode) []Match {
+	v := &rewriter{rule: r, apply: true}
+	v.V = v
+	n.VisitWith(v)
+	return v.matches
+}
+
+// rewriter finds, and possibly replaces, the matches of a rule.
+type rewriter struct {
+	ast.NoopVisitor
+	rule    *Rule
+	apply   bool
+	matches []Match
+}
+
+// accept records a match of code from start to end if the filter of the
+// rule accepts it.
+func (v *rewriter) accept(m *matcher, start, end ast.Idx) bool {
+	match := Match{Start: start, End: end, Vars: m.vars}
+	if v.rule.where != nil && !v.rule.where(&match) {
+		return false
+	}
+	v.matches = append(v.matches, match)
+	return true
+}
+
+func (v *rewriter) VisitExpression(n *ast.Expression) {
+	p := v.rule.pattern
+	if p == nil || n.Expr == nil || reflect.TypeOf(p) != reflect.TypeOf(n.Expr) {
+		n.VisitChildrenWith(v)
+		return
+	}
+	m := newMatcher()
+	if !m.match(p, n.Expr) {
+		n.VisitChildrenWith(v)
+		return
+	}
+	s := newSubstituter(m.vars)
+	replacement := s.expr(v.rule.replacement)
+	if s.failed || !v.accept(m, n.Expr.Idx0(), n.Expr.Idx1()) || !v.apply {
+		n.VisitChildrenWith(v)
+		return
+	}
+	v.visitHoles(s.holes)
+	n.Expr = replacement.Expr
+}
+
+// visitHoles goes on with the code substituted into a replacement.
+func (v *rewriter) visitHoles(holes []ast.VisitableNode) {
+	for _, h := range holes {
+		h.VisitWith(v)
+	}
+}
+
+func (v *rewriter) VisitStatements(n *ast.Statements) {
+	k := len(v.rule.stmts)
+	if v.rule.pattern != nil || k == 0 {
+		n.VisitChildrenWith(v)
+		return
+	}
+	list := *n
+	for i := 0; i < len(list); i++ {
+		replacement, holes, ok := v.matchStatements(list[i:min(i+k, len(list))])
+		if !ok {
+			list[i].VisitChildrenWith(v)
+			continue
+		}
+		if !v.apply {
+			for j := i; j < i+k; j++ {
+				list[j].VisitChildrenWith(v)
+			}
+			i += k - 1
+			continue
+		}
+		v.visitHoles(holes)
+		list = append(list[:i], append(replacement, list[i+k:]...)...)
+		i += len(replacement) - 1
+	}
+	*n = list
+}
+
+// VisitStatement matches a pattern of one statement where a single
+// statement is expected, as in the body of an if statement.
+func (v *rewriter) VisitStatement(n *ast.Statement) {
+	if v.rule.pattern != nil || len(v.rule.stmts) != 1 {
+		n.VisitChildrenWith(v)
+		return
+	}
+	replacement, holes, ok := v.matchStatements(ast.Statements{*n})
+	if !ok || !v.apply {
+		n.VisitChildrenWith(v)
+		return
+	}
+	v.visitHoles(holes)
+	switch len(replacement) {
+	case 0:
+		n.Stmt = &ast.EmptyStatement{}
+	case 1:
+		n.Stmt = replacement[0].Stmt
+	default:
+		n.Stmt = &ast.BlockStatement{List: replacement}
+	}
+}
+
+// matchStatements matches the statement pattern against list, returning
+// the replacement and the code substituted into it.
+func (v *rewriter) matchStatements(list ast.Statements) (ast.Statements, []ast.VisitableNode, bool) {
+	if len(list) != len(v.rule.stmts) {
+		return nil, nil, false
+	}
+	m := newMatcher()
+	for i := range list {
+		if !m.match(&v.rule.stmts[i], &list[i]) {
+			return nil, nil, false
+		}
+	}
+	s := newSubstituter(m.vars)
+	replacement := s.stmts(v.rule.replacementStmts)
+	if s.failed {
+		return nil, nil, false
+	}
+	if !v.accept(m, list[0].Stmt.Idx0(), list[len(list)-1].Stmt.Idx1()) {
+		return nil, nil, false
+	}
+	return replacement, s.holes, true
+}
+
+// substituter replaces the metavariables of a copy of a replacement with
+// the code bound to them.
+type substituter struct {
+	ast.NoopVisitor
+	vars map[string]ast.VisitableNode
+	// used holds the metavariables substituted so far, whose code is
+	// cloned when used again.
+	used map[string]bool
+	// holes holds the nodes the bound code was put into.
+	holes  []ast.VisitableNode
+	failed bool
+}
+
+func newSubstituter(vars map[string]ast.VisitableNode) *substituter {
+	s := &substituter{vars: vars, used: make(map[string]bool)}
+	s.V = s
+	return s
+}
+
+func (s *substituter) expr(e ast.Expr) *ast.Expression {
+	n := (&ast.Expression{Expr: e}).Clone()
+	n.VisitWith(s)
+	return n
+}
+
+func (s *substituter) stmts(list ast.Statements) ast.Statements {
+	n := list.Clone()
+	n.VisitWith(s)
+	return *n
+}
+
+// use returns the code bound to name, cloned if it was used before.
+func (s *substituter) use(name string) ast.VisitableNode {
+	n := s.vars[name]
+	if !s.used[name] {
+		s.used[name] = true
+		return n
+	}
+	switch n := n.(type) {
+	case ast.Expr:
+		return (&ast.Expression{Expr: n}).Clone().Expr
+	case ast.Stmt:
+		return (&ast.Statement{Stmt: n}).Clone().Stmt
+	}
+	return n
+}
+
+func (s *substituter) VisitStatement(n *ast.Statement) {
+	if name, ok := statementMetavariable(n); ok {
+		if _, ok := s.vars[name].(ast.Expr); !ok {
+			n.Stmt = s.use(name).(ast.Stmt)
+			s.holes = append(s.holes, n)
+			return
+		}
+	}
+	n.VisitChildrenWith(s)
+}
+
+func (s *substituter) VisitExpression(n *ast.Expression) {
+	name, ok := expressionMetavariable(n)
+	if !ok {
+		n.VisitChildrenWith(s)
+		return
+	}
+	switch e := s.use(name).(type) {
+	case *ast.StringLiteral:
+		// A key spelled as an identifier needs quotes as an expression.
+		if e.Raw != nil && *e.Raw == e.Value {
+			e = &ast.StringLiteral{Value: e.Value, Idx: e.Idx}
+		}
+		n.Expr = e
+	case ast.Expr:
+		n.Expr = e
+		s.holes = append(s.holes, n)
+	default:
+		s.failed = true
+	}
+}
+
+// VisitIdentifier substitutes the metavariables of names.
+func (s *substituter) VisitIdentifier(n *ast.Identifier) {
+	name, ok := metavariable(n.Name)
+	if !ok {
+		return
+	}
+	switch b := s.vars[name].(type) {
+	case *ast.Identifier:
+		*n = *b
+	case *ast.StringLiteral:
+		if !isIdentifierName(b.Value) {
+			s.failed = true
+		}
+		n.Name = b.Value
+	default:
+		s.failed = true
+	}
+}
+
+// VisitStringLiteral substitutes the metavariables of strings and keys.
+func (s *substituter) VisitStringLiteral(n *ast.StringLiteral) {
+	name, ok := metavariable(n.Value)
+	if !ok {
+		return
+	}
+	// Keys spelled as identifiers have no quotes in their raw text.
+	key := n.Raw != nil && *n.Raw == n.Value
+	switch b := s.vars[name].(type) {
+	case *ast.Identifier:
+		n.Value = b.Name
+	case *ast.StringLiteral:
+		n.Value = b.Value
+	default:
+		s.failed = true
+		return
+	}
+	n.Raw = nil
+	if key && isIdentifierName(n.Value) {
+		n.Raw = &n.Value
+	}
+}
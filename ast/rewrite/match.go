@@ -0,0 +1,129 @@
+package rewrite
+
+import (
+	"math/big"
+	"reflect"
+
+	"github.com/t14raptor/go-fast/ast"
+)
+
+var (
+	bigIntType        = reflect.TypeFor[*big.Int]()
+	idxType           = reflect.TypeFor[ast.Idx]()
+	scopeContextType  = reflect.TypeFor[ast.ScopeContext]()
+	expressionType    = reflect.TypeFor[ast.Expression]()
+	statementType     = reflect.TypeFor[ast.Statement]()
+	identifierType    = reflect.TypeFor[ast.Identifier]()
+	stringLiteralType = reflect.TypeFor[ast.StringLiteral]()
+)
+
+// matcher matches a pattern against a tree, binding metavariables.
+type matcher struct {
+	vars map[string]ast.VisitableNode
+}
+
+func newMatcher() *matcher {
+	return &matcher{vars: make(map[string]ast.VisitableNode)}
+}
+
+// match reports whether the tree n matches the pattern p, which is of the
+// same type.
+func (m *matcher) match(p, n any) bool {
+	return m.value(reflect.ValueOf(p), reflect.ValueOf(n))
+}
+
+// bind binds name to n, reporting false if it is bound to different code.
+func (m *matcher) bind(name string, n ast.VisitableNode) bool {
+	if prev, ok := m.vars[name]; ok {
+		return ast.Equal(prev, n, ast.EqualOptions{})
+	}
+	m.vars[name] = n
+	return true
+}
+
+// value matches p against n. Both are addressable unless they are the
+// pointers passed to match.
+func (m *matcher) value(p, n reflect.Value) bool {
+	switch p.Kind() {
+	case reflect.Pointer:
+		if p.IsNil() || n.IsNil() {
+			return p.IsNil() == n.IsNil()
+		}
+		if p.Type() == bigIntType {
+			return p.Interface().(*big.Int).Cmp(n.Interface().(*big.Int)) == 0
+		}
+		return m.value(p.Elem(), n.Elem())
+	case reflect.Interface:
+		if p.IsNil() || n.IsNil() {
+			return p.IsNil() == n.IsNil()
+		}
+		if p.Elem().Type() != n.Elem().Type() {
+			return false
+		}
+		return m.value(p.Elem(), n.Elem())
+	case reflect.Slice:
+		if p.Len() != n.Len() {
+			return false
+		}
+		for i := range p.Len() {
+			if !m.value(p.Index(i), n.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		return m.node(p, n)
+	}
+	return p.Equal(n)
+}
+
+// node matches the struct p against n, binding the metavariable p stands
+// for if it is one.
+func (m *matcher) node(p, n reflect.Value) bool {
+	switch p.Type() {
+	case expressionType:
+		if name, ok := expressionMetavariable(p.Addr().Interface().(*ast.Expression)); ok {
+			e := n.Addr().Interface().(*ast.Expression).Expr
+			return e != nil && m.bind(name, e)
+		}
+	case statementType:
+		if name, ok := statementMetavariable(p.Addr().Interface().(*ast.Statement)); ok {
+			return m.bind(name, n.Addr().Interface().(*ast.Statement).Stmt)
+		}
+	case identifierType:
+		if name, ok := metavariable(p.Addr().Interface().(*ast.Identifier).Name); ok {
+			return m.bind(name, n.Addr().Interface().(*ast.Identifier))
+		}
+	case stringLiteralType:
+		if name, ok := metavariable(p.Addr().Interface().(*ast.StringLiteral).Value); ok {
+			return m.bind(name, n.Addr().Interface().(*ast.StringLiteral))
+		}
+	}
+	t := p.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() || f.Type == idxType || f.Type == scopeContextType || f.Name == "Raw" || f.Name == "Comment" {
+			continue
+		}
+		if !m.value(p.Field(i), n.Field(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// expressionMetavariable returns the metavariable e is, if any.
+func expressionMetavariable(e *ast.Expression) (string, bool) {
+	if id, ok := e.Expr.(*ast.Identifier); ok {
+		return metavariable(id.Name)
+	}
+	return "", false
+}
+
+// statementMetavariable returns the metavariable s is, as in $s;, if any.
+func statementMetavariable(s *ast.Statement) (string, bool) {
+	if st, ok := s.Stmt.(*ast.ExpressionStatement); ok {
+		return expressionMetavariable(st.Expression)
+	}
+	return "", false
+}
@@ -0,0 +1,196 @@
+// Package rewrite applies structural search-and-replace rules written as
+// JavaScript with metavariables:
+//
+//	r := rewrite.MustCompile(`$obj["$key"]`, `$obj.$key`)
+//	matches := r.Apply(program)
+//
+// A metavariable is an identifier of a $ followed by a name, as in $obj.
+// In a pattern it matches according to where it appears:
+//
+//   - as an expression, any expression;
+//   - as a statement on its own, as in "$s;", any statement;
+//   - as a declared name, parameter, property name or label, any
+//     identifier;
+//   - as the whole of a string literal or property key, as in "$key", any
+//     string literal or key.
+//
+// A metavariable used more than once must match equal code each time, as
+// reported by ast.Equal, so in a resolved tree both must refer to the same
+// binding. Identifiers that are not metavariables match by name; positions,
+// raw literal text and comments are ignored.
+//
+// A pattern ending in a semicolon or holding anything but a single
+// expression is a list of statements, which matches that many consecutive
+// statements of a block. Its replacement is a list of statements too,
+// possibly empty to delete the match. Any other pattern is an expression
+// and its replacement must be one.
+//
+// The replacement may use the metavariables of the pattern, in the same
+// kinds of places. A string or key bound to a metavariable becomes an
+// identifier where a name is needed, and the other way round; a match whose
+// string is not a valid identifier name there is skipped, as is one whose
+// statement is used as an expression.
+package rewrite
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/parser"
+)
+
+// ErrPattern is wrapped by the errors of invalid patterns and replacements.
+var ErrPattern = errors.New("rewrite: invalid pattern")
+
+// Rule is a compiled search-and-replace rule.
+type Rule struct {
+	// pattern is the expression pattern, or nil for a statement pattern.
+	pattern ast.Expr
+	// stmts is the statement pattern.
+	stmts ast.Statements
+
+	replacement      ast.Expr
+	replacementStmts ast.Statements
+
+	where func(m *Match) bool
+}
+
+// Match is a match of a rule.
+type Match struct {
+	// Start and End are the span of the matched code: the index of its
+	// first character and of the one after it.
+	Start, End ast.Idx
+	// Vars holds the nodes bound to the metavariables, keyed by name
+	// without the $. They are an ast.Expr for expressions, an ast.Stmt for
+	// statements, an *ast.Identifier for names and an *ast.StringLiteral
+	// for strings and keys.
+	Vars map[string]ast.VisitableNode
+}
+
+// Name returns the name of the identifier, or the value of the string
+// literal, bound to the metavariable name.
+func (m *Match) Name(name string) (string, bool) {
+	switch n := m.Vars[name].(type) {
+	case *ast.Identifier:
+		return n.Name, true
+	case *ast.StringLiteral:
+		return n.Value, true
+	}
+	return "", false
+}
+
+// Compile parses a pattern and its replacement.
+func Compile(pattern, replacement string) (*Rule, error) {
+	r := &Rule{}
+	stmts, err := parse(pattern)
+	if err != nil {
+		return nil, err
+	}
+	replacementStmts, err := parse(replacement)
+	if err != nil {
+		return nil, err
+	}
+	if e := expression(pattern, stmts); e != nil {
+		r.pattern = e
+		if r.replacement = expression(replacement, replacementStmts); r.replacement == nil {
+			return nil, fmt.Errorf("%w: replacement %q of an expression is not an expression", ErrPattern, replacement)
+		}
+	} else {
+		r.stmts, r.replacementStmts = stmts, replacementStmts
+	}
+
+	declared := metavariables(&stmts)
+	for name := range metavariables(&replacementStmts) {
+		if !declared[name] {
+			return nil, fmt.Errorf("%w: metavariable $%s of the replacement is not in the pattern", ErrPattern, name)
+		}
+	}
+	return r, nil
+}
+
+// MustCompile is like Compile but panics if the pattern or replacement is
+// invalid.
+func MustCompile(pattern, replacement string) *Rule {
+	r, err := Compile(pattern, replacement)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Where makes the rule skip the matches for which f returns false, and
+// returns the rule.
+func (r *Rule) Where(f func(m *Match) bool) *Rule {
+	r.where = f
+	return r
+}
+
+// parse parses src as the body of an async generator function, so that
+// patterns may return, await and yield.
+func parse(src string) (ast.Statements, error) {
+	program, err := parser.ParseFile("async function* _() {\n" + src + "\n}")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPattern, err)
+	}
+	if len(program.Body) == 1 {
+		if f, ok := program.Body[0].Stmt.(*ast.FunctionDeclaration); ok {
+			return f.Function.Body.List, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q is not a list of statements", ErrPattern, src)
+}
+
+// expression returns the expression of a pattern that is one, or nil.
+func expression(src string, stmts ast.Statements) ast.Expr {
+	if len(stmts) != 1 || strings.HasSuffix(strings.TrimSpace(src), ";") {
+		return nil
+	}
+	if s, ok := stmts[0].Stmt.(*ast.ExpressionStatement); ok {
+		return s.Expression.Expr
+	}
+	return nil
+}
+
+// metavariable returns the name of the metavariable s, without the $.
+func metavariable(s string) (string, bool) {
+	if len(s) < 2 || s[0] != '$' || !isIdentifierName(s) {
+		return "", false
+	}
+	return s[1:], true
+}
+
+// metavariables returns the names of the metavariables in n.
+func metavariables(n ast.VisitableNode) map[string]bool {
+	names := make(map[string]bool)
+	ast.Inspect(n, func(n ast.VisitableNode) bool {
+		switch n := n.(type) {
+		case *ast.Identifier:
+			if name, ok := metavariable(n.Name); ok {
+				names[name] = true
+			}
+		case *ast.StringLiteral:
+			if name, ok := metavariable(n.Value); ok {
+				names[name] = true
+			}
+		}
+		return true
+	})
+	return names
+}
+
+// isIdentifierName reports whether s is spelled as an identifier. Reserved
+// words are identifier names too.
+func isIdentifierName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, c := range s {
+		if c != '$' && c != '_' && !unicode.IsLetter(c) && (i == 0 || !unicode.IsDigit(c)) {
+			return false
+		}
+	}
+	return true
+}
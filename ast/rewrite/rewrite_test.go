@@ -0,0 +1,102 @@
+package rewrite_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/ast/rewrite"
+	"github.com/t14raptor/go-fast/generator"
+	"github.com/t14raptor/go-fast/parser"
+	"github.com/t14raptor/go-fast/resolver"
+)
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		pattern, replacement string
+		src, want            string
+		matches              int
+	}{
+		{`$obj["$key"]`, `$obj.$key`, `a["b"]["c d"]["e"]; f(x["y"]);`, `a.b["c d"].e;f(x.y);`, 3},
+		{`$a && $b();`, `if ($a) $b();`, `x && y(); if (z) x && g();`, `if(x)y();if(z)if(x)g();`, 2},
+		{`$x + $x`, `2 * $x`, `a + a; a + b; f() + f();`, `2*a;a+b;2*f();`, 2},
+		{`({$k: $v})`, `[$k, $v]`, `({a: 1}); ({"b c": 2});`, `["a",1];["b c",2];`, 2},
+		{`var $x = $init; $x = $y;`, `var $x = $y;`, `var a = 1; a = 2; var b = 1; c = 2;`, `var a=2;var b=1;c=2;`, 1},
+		{`console.log($msg);`, ``, `if (a) console.log(1); console.log(2); b;`, `if(a);b;`, 2},
+		{`$s; debugger;`, `$s;`, `{ let a; debugger; } debugger;`, `{let a;}`, 2},
+		{`function $f($p) { return $p; }`, `const $f = $p => $p;`, `function id(v) { return v; } function g(v) { return w; }`, `const id=(v)=>v;function g(v){return w;}`, 1},
+		// Replacements that match their own pattern are not searched again,
+		// but the code bound in them is.
+		{`$a.b`, `$a.b.c`, `x.b; x.b.b;`, `x.b.c;x.b.c.b.c;`, 3},
+		{`f($x)`, `f(f($x))`, `f(1); f(g(f(2)));`, `f(f(1));f(f(g(f(f(2)))));`, 3},
+		{`foo();`, `{ foo(); }`, `foo(); if (a) foo();`, `{foo();}if(a){foo();}`, 2},
+	}
+	for _, tt := range tests {
+		r, err := rewrite.Compile(tt.pattern, tt.replacement)
+		if err != nil {
+			t.Errorf("Compile(%q, %q): %v", tt.pattern, tt.replacement, err)
+			continue
+		}
+		program, err := parser.ParseFile(tt.src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := len(r.Find(program)); got != tt.matches {
+			t.Errorf("%q in %q: found %d matches; want %d", tt.pattern, tt.src, got, tt.matches)
+		}
+		matches := r.Apply(program)
+		if got := generator.GenerateMinified(program); got != tt.want {
+			t.Errorf("%q → %q in %q = %q; want %q", tt.pattern, tt.replacement, tt.src, got, tt.want)
+		}
+		if len(matches) != tt.matches {
+			t.Errorf("%q in %q: applied %d matches; want %d", tt.pattern, tt.src, len(matches), tt.matches)
+		}
+	}
+}
+
+func TestSpansAndWhere(t *testing.T) {
+	src := `f(1); f("s"); f(2);`
+	program, err := parser.ParseFile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := rewrite.MustCompile(`f($x)`, `g($x)`).Where(func(m *rewrite.Match) bool {
+		_, isString := m.Vars["x"].(*ast.StringLiteral)
+		return !isString
+	})
+	matches := r.Apply(program)
+	var spans []string
+	for _, m := range matches {
+		spans = append(spans, src[m.Start:m.End])
+	}
+	if len(spans) != 2 || spans[0] != "f(1)" || spans[1] != "f(2)" {
+		t.Errorf("matched %q; want f(1) and f(2)", spans)
+	}
+	if got, want := generator.GenerateMinified(program), `g(1);f("s");g(2);`; got != want {
+		t.Errorf("rewrote to %q; want %q", got, want)
+	}
+}
+
+func TestScope(t *testing.T) {
+	program, err := parser.ParseFile(`let x = 1; x + x; { let x = 2; x + (() => { let x; return x; })(); }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolver.Resolve(program)
+	if got := len(rewrite.MustCompile(`$a + $a`, `2 * $a`).Find(program)); got != 1 {
+		t.Errorf("found %d sums of one binding; want 1", got)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct{ pattern, replacement string }{
+		{`a +`, `b`},
+		{`$a`, `$b`},
+		{`f($a)`, `return $a;`},
+	}
+	for _, tt := range tests {
+		if _, err := rewrite.Compile(tt.pattern, tt.replacement); !errors.Is(err, rewrite.ErrPattern) {
+			t.Errorf("Compile(%q, %q) error = %v; want ErrPattern", tt.pattern, tt.replacement, err)
+		}
+	}
+}
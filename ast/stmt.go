@@ -46,7 +46,8 @@ type (
 		Test       *Expression `optional:"true"`
 		Consequent Statements
 
-		Case Idx
+		Case  Idx
+		Colon Idx
 	}
 
 	CatchStatement struct {
@@ -64,7 +65,8 @@ type (
 		Test *Expression
 		Body *Statement
 
-		Do Idx
+		Do               Idx
+		RightParenthesis Idx
 	}
 
 	EmptyStatement struct {
@@ -102,7 +104,8 @@ type (
 		Default      int
 		Body         CaseStatements
 
-		Switch Idx
+		Switch     Idx
+		RightBrace Idx
 	}
 
 	ThrowStatement struct {
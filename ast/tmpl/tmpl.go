@@ -0,0 +1,511 @@
+// Package tmpl builds syntax trees from JavaScript source with placeholders:
+//
+//	tmpl.Expr("Object.defineProperty(%0, %1, {value: %2})", target, key, val)
+//	tmpl.Stmts("if (%cond) { %body }", tmpl.Vars{"cond": cond, "body": body})
+//
+// A placeholder is a % followed by a number, which picks an argument by
+// position, or by a name, which looks it up in the Vars passed as the only
+// argument. Write the remainder operator with a space after it, as in a % b.
+// Placeholders are not recognised in strings, template text, regular
+// expressions or comments.
+//
+// The type of an argument decides where its placeholder may appear:
+//
+//   - a string or *ast.Identifier is a name, allowed anywhere an identifier
+//     is: a reference, a declared name, a parameter, a property name or a
+//     label. It must be a valid identifier, and may only be a reserved word
+//     such as if or class where it names a property;
+//   - any other ast.Expr, or an *ast.Expression, is an expression, allowed
+//     where an expression is;
+//   - an ast.Stmt, []ast.Stmt or ast.Statements is a list of statements,
+//     allowed as a statement on its own, as in "%body;" or "{ %body }".
+//
+// A placeholder anywhere else is an error, as is an argument that no
+// placeholder uses. Arguments used more than once are cloned for every use
+// after the first.
+package tmpl
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/parser"
+	"github.com/t14raptor/go-fast/parser/scanner"
+	"github.com/t14raptor/go-fast/parser/scanner/token"
+)
+
+// Vars holds the arguments of named placeholders.
+type Vars map[string]any
+
+// ErrTemplate is wrapped by the errors of invalid templates and arguments.
+var ErrTemplate = errors.New("tmpl: invalid template")
+
+// Expr parses src as an expression and substitutes args for its
+// placeholders.
+func Expr(src string, args ...any) (ast.Expr, error) {
+	program, err := parse(src, "(\n", "\n);", args)
+	if err != nil {
+		return nil, err
+	}
+	if len(program.Body) == 1 {
+		if s, ok := program.Body[0].Stmt.(*ast.ExpressionStatement); ok {
+			return s.Expression.Expr, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q is not a single expression", ErrTemplate, src)
+}
+
+// Stmts parses src as a list of statements and substitutes args for its
+// placeholders. The statements may return, await and yield, as in the body
+// of an async generator function.
+func Stmts(src string, args ...any) (ast.Statements, error) {
+	program, err := parse(src, "async function* _() {\n", "\n}", args)
+	if err != nil {
+		return nil, err
+	}
+	if len(program.Body) == 1 {
+		if f, ok := program.Body[0].Stmt.(*ast.FunctionDeclaration); ok {
+			return f.Function.Body.List, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q is not a list of statements", ErrTemplate, src)
+}
+
+// Stmt is like Stmts for templates of a single statement.
+func Stmt(src string, args ...any) (ast.Stmt, error) {
+	list, err := Stmts(src, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) != 1 {
+		return nil, fmt.Errorf("%w: %q has %d statements, want 1", ErrTemplate, src, len(list))
+	}
+	return list[0].Stmt, nil
+}
+
+// Must returns n, panicking if err is not nil. It is meant for templates
+// fixed at compile time:
+//
+//	var call = tmpl.Must(tmpl.Expr("f(%0)", x))
+func Must[T any](n T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func parse(src, prefix, suffix string, args []any) (*ast.Program, error) {
+	s, err := newSubstituter(args)
+	if err != nil {
+		return nil, err
+	}
+	code, err := s.scan(src)
+	if err != nil {
+		return nil, err
+	}
+	program, err := parser.ParseFile(prefix + code + suffix)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTemplate, err)
+	}
+	program.VisitWith(s)
+	if s.err != nil {
+		return nil, s.err
+	}
+	for _, a := range s.args {
+		if a.uses == 0 {
+			return nil, fmt.Errorf("%w: argument %s is not used", ErrTemplate, a.name)
+		}
+	}
+	return program, nil
+}
+
+// placeholderPrefix starts the identifiers placeholders are replaced with
+// before parsing.
+const placeholderPrefix = "$tmpl$"
+
+// scan replaces the placeholders in src with identifiers, checking that
+// each names an argument. It reads src with the parser's scanner, so that
+// strings, template text, regular expressions and comments are passed over.
+// As in the parser's lazy function bodies, a slash starts a regular
+// expression unless the token before it can end an expression.
+func (s *substituter) scan(src string) (string, error) {
+	var err error
+	sc := scanner.NewScanner(src, &err)
+	var b strings.Builder
+	// braces holds, for every template literal being scanned, how many
+	// braces of its current substitution are open.
+	var braces []int
+	// done is the offset up to which src has been copied to b.
+	var done ast.Idx
+	prev := token.Undetermined
+	for {
+		sc.Next()
+		kind := sc.Token.Kind
+		switch {
+		case (prev == token.Period || prev == token.QuestionDot) && kind >= token.Identifier && kind <= token.Yield:
+			// A keyword after a dot is a property name, as in a.return.
+			kind = token.Identifier
+		case kind == token.Of || kind == token.Identifier && src[sc.Token.Idx0:sc.Token.Idx1] == "of":
+			// of separates the head of a for-of statement after its target,
+			// and is a name anywhere else.
+			kind = token.Identifier
+			if token.EndsExpression(prev) {
+				kind = token.Of
+			}
+		case kind == token.Let || kind == token.Static || kind == token.Async:
+			kind = token.Identifier
+		}
+		switch kind {
+		case token.Eof:
+			b.WriteString(src[done:])
+			return b.String(), nil
+		case token.TemplateHead:
+			braces = append(braces, 0)
+		case token.LeftBrace:
+			if len(braces) != 0 {
+				braces[len(braces)-1]++
+			}
+		case token.RightBrace:
+			if len(braces) == 0 {
+				break
+			}
+			if braces[len(braces)-1] != 0 {
+				braces[len(braces)-1]--
+				break
+			}
+			braces = braces[:len(braces)-1]
+			sc.NextTemplatePart()
+			if kind = sc.Token.Kind; kind == token.TemplateMiddle {
+				braces = append(braces, 0)
+			}
+		case token.Slash, token.QuotientAssign:
+			if !token.EndsExpression(prev) {
+				sc.ParseRegExp()
+				kind = token.String
+			}
+		case token.Remainder:
+			name := placeholderName(src[sc.Token.Idx1:])
+			if name == "" {
+				break
+			}
+			a, err := s.lookup(name)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(src[done:sc.Token.Idx0])
+			fmt.Fprintf(&b, "%s%d", placeholderPrefix, a)
+			done = sc.Token.Idx1 + ast.Idx(len(name))
+			sc.Seek(done)
+			kind = token.Identifier
+		}
+		prev = kind
+	}
+}
+
+// placeholderName returns the name of the placeholder at the start of src,
+// which follows its %: a number, or letters, digits and underscores not
+// starting with a digit.
+func placeholderName(src string) string {
+	isDigit := func(c byte) bool { return '0' <= c && c <= '9' }
+	isLetter := func(c byte) bool { return c == '_' || 'a' <= c|0x20 && c|0x20 <= 'z' }
+	n := 0
+	if n < len(src) && isDigit(src[n]) {
+		for n < len(src) && isDigit(src[n]) {
+			n++
+		}
+		return src[:n]
+	}
+	for n < len(src) && (isLetter(src[n]) || n > 0 && isDigit(src[n])) {
+		n++
+	}
+	return src[:n]
+}
+
+// isName reports whether s is an identifier name, which may be a reserved
+// word.
+func isName(s string) bool {
+	for i, c := range s {
+		if c != '$' && c != '_' && !unicode.IsLetter(c) && (i == 0 || !unicode.IsDigit(c)) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isReserved reports whether the name s is a reserved word, which cannot
+// name a binding or be referenced. Contextual keywords such as let and async
+// are not reserved.
+func isReserved(s string) bool {
+	k := token.MatchKeyword(s)
+	return k != token.Identifier && !token.UnreservedWord(k)
+}
+
+// kind is the kind of node an argument holds.
+type kind uint8
+
+const (
+	kindName kind = iota
+	kindExpr
+	kindStmts
+)
+
+func (k kind) String() string {
+	switch k {
+	case kindName:
+		return "a name"
+	case kindExpr:
+		return "an expression"
+	}
+	return "statements"
+}
+
+type argument struct {
+	// name is the placeholder of the argument, as in %0 or %cond.
+	name string
+	kind kind
+	// value is a string, *ast.Identifier, ast.Expr or ast.Statements.
+	value any
+	uses  int
+}
+
+// substituter replaces placeholders with their arguments.
+type substituter struct {
+	ast.NoopVisitor
+	args []*argument
+	// names maps the names of named placeholders to their arguments. It is
+	// nil for positional placeholders.
+	names map[string]int
+	err   error
+}
+
+func newSubstituter(args []any) (*substituter, error) {
+	s := &substituter{}
+	s.V = s
+	if len(args) == 1 {
+		if vars, ok := args[0].(Vars); ok {
+			s.names = make(map[string]int, len(vars))
+			for _, name := range slices.Sorted(maps.Keys(vars)) {
+				if err := s.add("%"+name, vars[name]); err != nil {
+					return nil, err
+				}
+				s.names[name] = len(s.args) - 1
+			}
+			return s, nil
+		}
+	}
+	for i, v := range args {
+		if err := s.add("%"+strconv.Itoa(i), v); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// add appends the argument of placeholder name, classifying its value.
+func (s *substituter) add(name string, v any) error {
+	a := &argument{name: name}
+	switch v := v.(type) {
+	case string:
+		if !isName(v) {
+			return fmt.Errorf("%w: argument %s is not a valid name: %q", ErrTemplate, name, v)
+		}
+		a.kind, a.value = kindName, v
+	case *ast.Identifier:
+		if !isName(v.Name) {
+			return fmt.Errorf("%w: argument %s is not a valid name: %q", ErrTemplate, name, v.Name)
+		}
+		a.kind, a.value = kindName, v
+	case *ast.Expression:
+		return s.add(name, v.Expr)
+	case ast.Expr:
+		a.kind, a.value = kindExpr, v
+	case ast.Stmt:
+		a.kind, a.value = kindStmts, ast.Statements{{Stmt: v}}
+	case *ast.Statement:
+		a.kind, a.value = kindStmts, ast.Statements{*v}
+	case []ast.Stmt:
+		list := make(ast.Statements, len(v))
+		for i, st := range v {
+			list[i].Stmt = st
+		}
+		a.kind, a.value = kindStmts, list
+	case ast.Statements:
+		a.kind, a.value = kindStmts, v
+	default:
+		return fmt.Errorf("%w: argument %s has unsupported type %T", ErrTemplate, name, v)
+	}
+	s.args = append(s.args, a)
+	return nil
+}
+
+// lookup returns the index of the argument of the placeholder %name.
+func (s *substituter) lookup(name string) (int, error) {
+	if s.names != nil {
+		if i, ok := s.names[name]; ok {
+			return i, nil
+		}
+	} else if i, err := strconv.Atoi(name); err == nil && i < len(s.args) {
+		return i, nil
+	}
+	return 0, fmt.Errorf("%w: placeholder %%%s has no argument", ErrTemplate, name)
+}
+
+// argument returns the argument of the placeholder identifier name, or nil
+// if name is not a placeholder.
+func (s *substituter) argument(name string) *argument {
+	i, ok := strings.CutPrefix(name, placeholderPrefix)
+	if !ok {
+		return nil
+	}
+	n, err := strconv.Atoi(i)
+	if err != nil || n >= len(s.args) {
+		return nil
+	}
+	return s.args[n]
+}
+
+// use returns the value of a, cloned if it was used before.
+func (s *substituter) use(a *argument) any {
+	a.uses++
+	if a.uses == 1 {
+		return a.value
+	}
+	switch v := a.value.(type) {
+	case ast.Expr:
+		return (&ast.Expression{Expr: v}).Clone().Expr
+	case ast.Statements:
+		return *v.Clone()
+	}
+	return a.value
+}
+
+// misuse records that the placeholder of a appears where its kind is not
+// allowed.
+func (s *substituter) misuse(a *argument, as string) {
+	a.uses++
+	if s.err == nil {
+		s.err = fmt.Errorf("%w: placeholder %s holds %v but is used as %s", ErrTemplate, a.name, a.kind, as)
+	}
+}
+
+// statementsArgument returns the argument of a statement %name;, if it
+// holds statements.
+func (s *substituter) statementsArgument(n *ast.Statement) *argument {
+	st, ok := n.Stmt.(*ast.ExpressionStatement)
+	if !ok {
+		return nil
+	}
+	id, ok := st.Expression.Expr.(*ast.Identifier)
+	if !ok {
+		return nil
+	}
+	if a := s.argument(id.Name); a != nil && a.kind == kindStmts {
+		return a
+	}
+	return nil
+}
+
+func (s *substituter) VisitStatements(n *ast.Statements) {
+	list := make(ast.Statements, 0, len(*n))
+	for i := range *n {
+		if a := s.statementsArgument(&(*n)[i]); a != nil {
+			list = append(list, s.use(a).(ast.Statements)...)
+			continue
+		}
+		(*n)[i].VisitWith(s)
+		list = append(list, (*n)[i])
+	}
+	*n = list
+}
+
+func (s *substituter) VisitStatement(n *ast.Statement) {
+	a := s.statementsArgument(n)
+	if a == nil {
+		n.VisitChildrenWith(s)
+		return
+	}
+	switch list := s.use(a).(ast.Statements); len(list) {
+	case 0:
+		n.Stmt = &ast.EmptyStatement{}
+	case 1:
+		n.Stmt = list[0].Stmt
+	default:
+		n.Stmt = &ast.BlockStatement{List: list}
+	}
+}
+
+func (s *substituter) VisitExpression(n *ast.Expression) {
+	id, ok := n.Expr.(*ast.Identifier)
+	if !ok {
+		n.VisitChildrenWith(s)
+		return
+	}
+	a := s.argument(id.Name)
+	switch {
+	case a == nil || a.kind == kindName:
+		s.VisitIdentifier(id)
+	case a.kind == kindExpr:
+		n.Expr = s.use(a).(ast.Expr)
+	default:
+		s.misuse(a, "an expression")
+	}
+}
+
+func (s *substituter) VisitIdentifier(n *ast.Identifier) {
+	s.name(n, false)
+}
+
+// VisitMemberProperty substitutes the placeholders of property names after
+// a dot.
+func (s *substituter) VisitMemberProperty(n *ast.MemberProperty) {
+	if id, ok := n.Prop.(*ast.Identifier); ok {
+		s.name(id, true)
+		return
+	}
+	n.VisitChildrenWith(s)
+}
+
+// name substitutes the placeholder of the identifier n. Reserved words are
+// only allowed as property names, where property is set.
+func (s *substituter) name(n *ast.Identifier, property bool) {
+	a := s.argument(n.Name)
+	switch {
+	case a == nil:
+	case a.kind == kindName:
+		switch v := s.use(a).(type) {
+		case string:
+			n.Name = v
+		case *ast.Identifier:
+			*n = *v
+		}
+		if !property && isReserved(n.Name) && s.err == nil {
+			s.err = fmt.Errorf("%w: placeholder %s holds the reserved word %q, which is only allowed as a property name", ErrTemplate, a.name, n.Name)
+		}
+	default:
+		s.misuse(a, "a name")
+	}
+}
+
+// VisitStringLiteral substitutes the placeholders of property names, which
+// the parser turns into string literals without quotes.
+func (s *substituter) VisitStringLiteral(n *ast.StringLiteral) {
+	if n.Raw == nil || *n.Raw != n.Value {
+		return
+	}
+	a := s.argument(n.Value)
+	switch {
+	case a == nil:
+	case a.kind == kindName:
+		id := &ast.Identifier{Name: n.Value}
+		s.name(id, true)
+		n.Value, n.Raw = id.Name, &id.Name
+	default:
+		s.misuse(a, "a property name")
+	}
+}
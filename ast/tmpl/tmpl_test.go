@@ -0,0 +1,136 @@
+package tmpl_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/ast/build"
+	"github.com/t14raptor/go-fast/ast/tmpl"
+	"github.com/t14raptor/go-fast/generator"
+	"github.com/t14raptor/go-fast/parser"
+	"github.com/t14raptor/go-fast/parser/scanner"
+)
+
+func parse(t *testing.T, src string) *ast.Program {
+	t.Helper()
+	program, err := parser.ParseFile(src)
+	if err != nil {
+		t.Fatalf("parse %q: %v", src, err)
+	}
+	return program
+}
+
+func TestExpr(t *testing.T) {
+	x := build.Ident("x")
+	tests := []struct {
+		src  string
+		args []any
+		want string
+	}{
+		{"Object.defineProperty(%0, %1, {value: %2})", []any{x, build.Str("k"), build.Num(1)}, `Object.defineProperty(x, "k", {value: 1})`},
+		{"%0 + %0", []any{build.Call(x)}, "x() + x()"},
+		{"%0 % 2", []any{"n"}, "n % 2"},
+		{"a.%0 || {%0: %1}", []any{"b", x}, "a.b || {b: x}"},
+		{"{%0}", []any{"b"}, "{b}"},
+		{"`%0 ${%0}`", []any{"a"}, "`%0 ${a}`"},
+		{"'%0' + %0 /* %0 */", []any{"a"}, "'%0' + a"},
+		{"(%f) => %f(%arg)", []any{tmpl.Vars{"f": "g", "arg": build.Num(2)}}, "g => g(2)"},
+		{"function %0(%1) { return %1; }", []any{"f", build.Ident("y")}, "function f(y) { return y; }"},
+		{"/%0/.test(%0) / /%0/g.lastIndex", []any{"a"}, "/%0/.test(a) / /%0/g.lastIndex"},
+		{"a.%0 + {%0: 1}.%0 + %1", []any{"default", "of"}, "a.default + {default: 1}.default + of"},
+	}
+	for _, tt := range tests {
+		got, err := tmpl.Expr(tt.src, tt.args...)
+		if err != nil {
+			t.Errorf("Expr(%q): %v", tt.src, err)
+			continue
+		}
+		want := parse(t, "("+tt.want+");")
+		if !ast.Equal(build.Program(build.ExprStmt(got)), want, ast.EqualOptions{}) {
+			t.Errorf("Expr(%q) = %s; want %s", tt.src, generator.GenerateMinified(build.ExprStmt(got)), tt.want)
+		}
+	}
+}
+
+func TestStmts(t *testing.T) {
+	body := build.Statements(build.ExprStmt(build.Ident("a")), build.ExprStmt(build.Ident("b")))
+	tests := []struct {
+		src  string
+		args []any
+		want string
+	}{
+		{"if (%cond) { %body }", []any{tmpl.Vars{"cond": build.Ident("c"), "body": body}}, "if (c) { a; b; }"},
+		{"%0; c; %0;", []any{body}, "a; b; c; a; b;"},
+		{"if (c) %0; else %1;", []any{body, build.Return(nil)}, "if (c) { a; b; } else return;"},
+		{"while (c) %0;", []any{[]ast.Stmt{}}, "while (c) ;"},
+		{"let %0 = await %1; %l: for (;;) break %l;", []any{tmpl.Vars{"0": "v", "1": build.Ident("p"), "l": "outer"}}, "let v = await p; outer: for (;;) break outer;"},
+	}
+	for _, tt := range tests {
+		got, err := tmpl.Stmts(tt.src, tt.args...)
+		if err != nil {
+			t.Errorf("Stmts(%q): %v", tt.src, err)
+			continue
+		}
+		want := parse(t, "async function f() {"+tt.want+"}").Body[0].Stmt.(*ast.FunctionDeclaration).Function.Body.List
+		if !ast.Equal(&got, &want, ast.EqualOptions{}) {
+			t.Errorf("Stmts(%q) = %s; want %s", tt.src, generator.GenerateMinified(build.Block(stmts(got)...)), tt.want)
+		}
+	}
+}
+
+func stmts(list ast.Statements) []ast.Stmt {
+	s := make([]ast.Stmt, len(list))
+	for i := range list {
+		s[i] = list[i].Stmt
+	}
+	return s
+}
+
+func TestReuseClones(t *testing.T) {
+	call := build.Call(build.Ident("f"))
+	e, err := tmpl.Expr("[%0, %0]", call)
+	if err != nil {
+		t.Fatal(err)
+	}
+	elems := e.(*ast.ArrayLiteral).Value
+	if elems[0].Expr != call {
+		t.Error("first use is not the argument itself")
+	}
+	if elems[1].Expr == call {
+		t.Error("second use is not a clone")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		args []any
+	}{
+		{"let %0 = 1;", []any{build.Num(1)}},
+		{"let x = %0;", []any{build.Statements(build.Return(nil))}},
+		{"a.%0;", []any{build.Call(build.Ident("f"))}},
+		{"({%0: 1});", []any{build.Num(1)}},
+		{"%0;", []any{"a", "b"}},
+		{"%1;", []any{"a"}},
+		{"%b;", []any{tmpl.Vars{"a": "a"}}},
+		{"%0;", []any{42}},
+		{"a +;", nil},
+		{"let %0 = 1;", []any{"if"}},
+		{"%0();", []any{build.Ident("class")}},
+		{"%0;", []any{"a-b"}},
+		{"%0;", []any{""}},
+	}
+	for _, tt := range tests {
+		if _, err := tmpl.Stmts(tt.src, tt.args...); !errors.Is(err, tmpl.ErrTemplate) {
+			t.Errorf("Stmts(%q) error = %v; want ErrTemplate", tt.src, err)
+		}
+	}
+	var serr scanner.Error
+	if _, err := tmpl.Expr("'%0", "a"); !errors.As(err, &serr) {
+		t.Errorf("Expr of an unterminated string error = %v; want a scanner.Error", err)
+	}
+	if _, err := tmpl.Stmt("a; b;"); !errors.Is(err, tmpl.ErrTemplate) {
+		t.Errorf("Stmt of two statements error = %v; want ErrTemplate", err)
+	}
+}
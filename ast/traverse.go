@@ -0,0 +1,392 @@
+package ast
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// PathVisitor holds the callbacks of Traverse. Enter is called before the
+// children of a node are traversed and Exit after them. Either may be nil.
+type PathVisitor struct {
+	Enter func(p *NodePath)
+	Exit  func(p *NodePath)
+}
+
+// NodePath is the position of a node during Traverse. Besides the node it
+// records where the node is stored, so that a callback can replace, remove
+// or insert next to it.
+//
+// Wrappers such as Expression, Statement and Property get no path of their
+// own: the path of the node they wrap refers to the field holding the
+// wrapper. A path is only valid until its callback returns.
+type NodePath struct {
+	Node   VisitableNode
+	Parent *NodePath
+	// Key is the name of the field of the parent node holding Node, such as
+	// "Consequent" or "List".
+	Key string
+	// Index is the position of Node in its list, or -1 if the field holds a
+	// single node.
+	Index int
+
+	set    func(n VisitableNode) bool
+	remove func()
+	list   pathList
+	// resume is the list position after which the traversal continues.
+	resume int
+	state  pathState
+}
+
+type pathState uint8
+
+const (
+	pathSkipped pathState = 1 << iota
+	pathReplaced
+	pathRemoved
+)
+
+// Traverse walks the tree rooted at root depth first, calling v.Enter and
+// v.Exit with the path of every node. Nodes added by Replace,
+// ReplaceWithMany, InsertBefore and InsertAfter are not traversed. The
+// children and Exit of a node are skipped once it is replaced, removed or
+// Skip is called.
+func Traverse(root VisitableNode, v PathVisitor) {
+	t := &traversal{enter: v.Enter, exit: v.Exit}
+	t.visit(&NodePath{Node: root, Index: -1})
+}
+
+type traversal struct {
+	enter, exit func(p *NodePath)
+}
+
+func (t *traversal) visit(p *NodePath) {
+	if t.enter != nil {
+		t.enter(p)
+		if p.state != 0 {
+			return
+		}
+	}
+	t.children(p)
+	if t.exit != nil && p.state&(pathReplaced|pathRemoved) == 0 {
+		t.exit(p)
+	}
+}
+
+// visitField traverses the node stored in f. remove clears the field, and is
+// nil if the field is required.
+func visitField[V VisitableNode](t *traversal, parent *NodePath, key string, f *V, remove func()) {
+	node := VisitableNode(*f)
+	if e, ok := node.(*Expression); ok {
+		// A ConciseBody, ForLoopInitializer or ForInto holding an
+		// expression.
+		visitField(t, parent, key, &e.Expr, remove)
+		return
+	}
+	if node == nil {
+		return
+	}
+	t.visit(&NodePath{
+		Node:   node,
+		Parent: parent,
+		Key:    key,
+		Index:  -1,
+		set: func(n VisitableNode) bool {
+			v, ok := n.(V)
+			if ok {
+				*f = v
+			}
+			return ok
+		},
+		remove: remove,
+	})
+}
+
+func visitList[L ~[]E, E any](t *traversal, parent *NodePath, key string, list *listOf[L, E]) {
+	for i := 0; i < len(*list.l); i++ {
+		node := list.node(i)
+		if node == nil {
+			// An array hole.
+			continue
+		}
+		p := &NodePath{Node: node, Parent: parent, Key: key, Index: i, list: list, resume: i}
+		t.visit(p)
+		i = p.resume
+	}
+}
+
+// pathList is a list field, such as Statements, seen through the nodes it
+// holds.
+type pathList interface {
+	container() VisitableNode
+	node(i int) VisitableNode
+	// splice replaces the elements i to j with nodes, reporting false if a
+	// node does not fit the list.
+	splice(i, j int, nodes []VisitableNode) bool
+}
+
+type listOf[L ~[]E, E any] struct {
+	l *L
+	// elem returns the node held by an element, nil for an array hole.
+	elem func(e *E) VisitableNode
+	// wrap makes an element holding n.
+	wrap func(n VisitableNode) (E, bool)
+}
+
+func (c *listOf[L, E]) container() VisitableNode {
+	return any(c.l).(VisitableNode)
+}
+
+func (c *listOf[L, E]) node(i int) VisitableNode {
+	return c.elem(&(*c.l)[i])
+}
+
+func (c *listOf[L, E]) splice(i, j int, nodes []VisitableNode) bool {
+	elems := make([]E, len(nodes))
+	for k, n := range nodes {
+		e, ok := c.wrap(n)
+		if !ok {
+			return false
+		}
+		elems[k] = e
+	}
+	*c.l = slices.Replace(*c.l, i, j, elems...)
+	return true
+}
+
+// Skip stops the traversal from visiting the children of the node and
+// calling Exit for it.
+func (p *NodePath) Skip() {
+	p.state |= pathSkipped
+}
+
+// Container returns the list holding the node, such as *Statements, or the
+// parent node if the node is not in a list.
+func (p *NodePath) Container() VisitableNode {
+	if p.list != nil {
+		return p.list.container()
+	}
+	if p.Parent != nil {
+		return p.Parent.Node
+	}
+	return nil
+}
+
+// Ancestors yields the paths of the enclosing nodes, innermost first.
+func (p *NodePath) Ancestors() iter.Seq[*NodePath] {
+	return func(yield func(*NodePath) bool) {
+		for q := p.Parent; q != nil; q = q.Parent {
+			if !yield(q) {
+				return
+			}
+		}
+	}
+}
+
+// Scope returns the path of the innermost node, p itself included, that
+// opens a scope the way the resolver does, the node of the resolver's Scope:
+// the program, a function, a class, a class static block, a for, for-in or
+// for-of statement, the cases of a switch statement, a catch clause, or a
+// block that is not the body of a function or static block. The resolver
+// records the scope's context on functions and blocks.
+func (p *NodePath) Scope() *NodePath {
+	var child *NodePath
+	for q := p; q != nil; child, q = q, q.Parent {
+		switch q.Node.(type) {
+		case *Program, *FunctionLiteral, *ArrowFunctionLiteral, *ClassLiteral, *ClassStaticBlock,
+			*ForStatement, *ForInStatement, *ForOfStatement, *CatchStatement:
+			return q
+		case *SwitchStatement:
+			// The discriminant is outside the scope of the cases.
+			if child == nil || child.Key != "Discriminant" {
+				return q
+			}
+		case *BlockStatement:
+			if q.Parent != nil {
+				switch q.Parent.Node.(type) {
+				case *FunctionLiteral, *ArrowFunctionLiteral, *ClassStaticBlock:
+					continue
+				}
+			}
+			return q
+		}
+	}
+	return nil
+}
+
+// Replace puts n in place of the node. n must fit the field or list holding
+// the node; a statement list only takes statements, for example.
+func (p *NodePath) Replace(n VisitableNode) {
+	p.check("replace")
+	if p.list != nil {
+		if !p.splice(p.Index, p.Index+1, []VisitableNode{n}) {
+			p.misfit(n)
+		}
+		p.Node = p.list.node(p.Index)
+	} else {
+		if p.set == nil || !p.set(n) {
+			p.misfit(n)
+		}
+		p.Node = n
+	}
+	p.state |= pathReplaced
+}
+
+// ReplaceWithMany puts nodes in place of the node. Outside a list, the
+// nodes are combined into a BlockStatement or a SequenceExpression.
+func (p *NodePath) ReplaceWithMany(nodes ...VisitableNode) {
+	p.check("replace")
+	switch {
+	case len(nodes) == 0:
+		p.Remove()
+	case p.list != nil:
+		if !p.splice(p.Index, p.Index+1, nodes) {
+			p.misfit(nodes...)
+		}
+		p.resume += len(nodes) - 1
+		p.Node = p.list.node(p.Index)
+		p.state |= pathReplaced
+	case len(nodes) == 1:
+		p.Replace(nodes[0])
+	default:
+		group, _ := groupNodes(nodes)
+		if group == nil {
+			p.misfit(nodes...)
+		}
+		p.Replace(group)
+	}
+}
+
+// InsertBefore inserts nodes before the node. Outside a list, a statement
+// is wrapped in a BlockStatement and an expression in a SequenceExpression
+// to make room for them.
+func (p *NodePath) InsertBefore(nodes ...VisitableNode) {
+	p.check("insert before")
+	if len(nodes) == 0 {
+		return
+	}
+	if p.list == nil {
+		p.regroup(append(slices.Clip(nodes), p.Node), len(nodes))
+		return
+	}
+	if !p.splice(p.Index, p.Index, nodes) {
+		p.misfit(nodes...)
+	}
+	p.Index += len(nodes)
+	p.resume += len(nodes)
+}
+
+// InsertAfter inserts nodes after the node. Outside a list, a statement is
+// wrapped in a BlockStatement to make room for them; an expression cannot
+// be followed by others without changing its value.
+func (p *NodePath) InsertAfter(nodes ...VisitableNode) {
+	p.check("insert after")
+	if len(nodes) == 0 {
+		return
+	}
+	if p.list == nil {
+		if _, ok := p.Node.(Stmt); !ok {
+			panic(fmt.Sprintf("ast: cannot insert after %T outside a list", p.Node))
+		}
+		p.regroup(append([]VisitableNode{p.Node}, nodes...), 0)
+		return
+	}
+	if !p.splice(p.Index+1, p.Index+1, nodes) {
+		p.misfit(nodes...)
+	}
+	p.resume += len(nodes)
+}
+
+// Remove removes the node from its list, or clears the optional field
+// holding it. Removing the last declarator of a variable declaration
+// removes the declaration.
+func (p *NodePath) Remove() {
+	p.check("remove")
+	switch {
+	case p.list != nil:
+		decl, ok := p.Parent.Node.(*VariableDeclaration)
+		last := ok && len(decl.List) == 1
+		if last && p.Parent.list == nil && p.Parent.remove == nil {
+			panic(fmt.Sprintf("ast: cannot remove the last declarator of %s, it is required", p.Parent.describe()))
+		}
+		p.splice(p.Index, p.Index+1, nil)
+		p.resume--
+		if last {
+			p.Parent.Remove()
+		}
+	case p.remove != nil:
+		p.remove()
+	default:
+		panic(fmt.Sprintf("ast: cannot remove %s, it is required", p.describe()))
+	}
+	p.state |= pathRemoved
+}
+
+// splice replaces the elements i to j of the list holding the node with
+// nodes, and keeps the Default case of a switch statement in step with its
+// cases.
+func (p *NodePath) splice(i, j int, nodes []VisitableNode) bool {
+	if !p.list.splice(i, j, nodes) {
+		return false
+	}
+	if s, ok := p.Parent.Node.(*SwitchStatement); ok && p.Key == "Body" {
+		s.Default = slices.IndexFunc(s.Body, func(c CaseStatement) bool { return c.Test == nil })
+	}
+	return true
+}
+
+// regroup replaces the node with a group of nodes holding it at index i, and
+// moves the path into the group.
+func (p *NodePath) regroup(nodes []VisitableNode, i int) {
+	group, list := groupNodes(nodes)
+	if group == nil || p.set == nil || !p.set(group) {
+		p.misfit(nodes...)
+	}
+	key := "List"
+	if _, ok := group.(*SequenceExpression); ok {
+		key = "Sequence"
+	}
+	p.Parent = &NodePath{
+		Node:   group,
+		Parent: p.Parent,
+		Key:    p.Key,
+		Index:  -1,
+		set:    p.set,
+		remove: p.remove,
+	}
+	p.Key, p.Index, p.list = key, i, list
+	p.set, p.remove = nil, nil
+}
+
+// groupNodes combines statements into a block and expressions into a
+// sequence.
+func groupNodes(nodes []VisitableNode) (VisitableNode, pathList) {
+	if block := (&BlockStatement{}); statementsList(&block.List).splice(0, 0, nodes) {
+		return block, statementsList(&block.List)
+	}
+	if seq := (&SequenceExpression{}); expressionsList(&seq.Sequence).splice(0, 0, nodes) {
+		return seq, expressionsList(&seq.Sequence)
+	}
+	return nil, nil
+}
+
+func (p *NodePath) check(op string) {
+	if p.state&pathRemoved != 0 {
+		panic(fmt.Sprintf("ast: cannot %s %T, it was removed", op, p.Node))
+	}
+}
+
+func (p *NodePath) misfit(nodes ...VisitableNode) {
+	types := make([]string, len(nodes))
+	for i, n := range nodes {
+		types[i] = fmt.Sprintf("%T", n)
+	}
+	panic(fmt.Sprintf("ast: %v do not fit %s", types, p.describe()))
+}
+
+func (p *NodePath) describe() string {
+	if p.Parent == nil {
+		return "the root of the traversal"
+	}
+	return fmt.Sprintf("%s of %T", p.Key, p.Parent.Node)
+}
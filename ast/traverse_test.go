@@ -0,0 +1,326 @@
+package ast_test
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/generator"
+	"github.com/t14raptor/go-fast/parser"
+	"github.com/t14raptor/go-fast/resolver"
+)
+
+func parse(t *testing.T, src string) *ast.Program {
+	t.Helper()
+	p, err := parser.ParseFile(src)
+	if err != nil {
+		t.Fatalf("parse %q: %v", src, err)
+	}
+	return p
+}
+
+// statements parses src and returns its top-level statements as nodes to
+// insert elsewhere.
+func statements(t *testing.T, src string) []ast.VisitableNode {
+	t.Helper()
+	var nodes []ast.VisitableNode
+	for _, s := range parse(t, src).Body {
+		nodes = append(nodes, s.Stmt)
+	}
+	return nodes
+}
+
+func isIdent(n ast.VisitableNode, name string) bool {
+	id, ok := n.(*ast.Identifier)
+	return ok && id.Name == name
+}
+
+// isExprStmt reports whether n is the expression statement "name;".
+func isExprStmt(n ast.VisitableNode, name string) bool {
+	s, ok := n.(*ast.ExpressionStatement)
+	return ok && isIdent(s.Expression.Expr, name)
+}
+
+func TestNodePath(t *testing.T) {
+	program := parse(t, "if (a) b; else { c; }")
+
+	var got []string
+	ast.Traverse(program, ast.PathVisitor{Enter: func(p *ast.NodePath) {
+		if p.Parent == nil {
+			return
+		}
+		got = append(got, fmt.Sprintf("%T %s[%d] in %T", p.Node, p.Key, p.Index, p.Parent.Node))
+	}})
+	want := []string{
+		"*ast.IfStatement Body[0] in *ast.Program",
+		"*ast.Identifier Test[-1] in *ast.IfStatement",
+		"*ast.ExpressionStatement Consequent[-1] in *ast.IfStatement",
+		"*ast.Identifier Expression[-1] in *ast.ExpressionStatement",
+		"*ast.BlockStatement Alternate[-1] in *ast.IfStatement",
+		"*ast.ExpressionStatement List[0] in *ast.BlockStatement",
+		"*ast.Identifier Expression[-1] in *ast.ExpressionStatement",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("paths:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestNodePathAncestorsAndScope(t *testing.T) {
+	program := parse(t, "function f() { for (;;) { x; } }")
+
+	var ancestors []string
+	var scope ast.VisitableNode
+	ast.Traverse(program, ast.PathVisitor{Enter: func(p *ast.NodePath) {
+		if !isIdent(p.Node, "x") {
+			return
+		}
+		for a := range p.Ancestors() {
+			ancestors = append(ancestors, fmt.Sprintf("%T", a.Node))
+		}
+		scope = p.Scope().Node
+	}})
+	want := []string{
+		"*ast.ExpressionStatement", "*ast.BlockStatement", "*ast.ForStatement", "*ast.BlockStatement",
+		"*ast.FunctionLiteral", "*ast.FunctionDeclaration", "*ast.Program",
+	}
+	if !slices.Equal(ancestors, want) {
+		t.Errorf("ancestors = %v; want %v", ancestors, want)
+	}
+	if _, ok := scope.(*ast.BlockStatement); !ok {
+		t.Errorf("scope = %T; want the for statement's block", scope)
+	}
+}
+
+func TestNodePathScopeMatchesResolver(t *testing.T) {
+	program := parse(t, `a;
+function f(b) { b; { a; } }
+(c => { c; try {} catch (e) { e; { e; } } })();
+(d => d)();
+for (const k in o) { k; }
+for (let i = 0; i < 1; i++) i;
+switch (a) { case 1: let s; s; }
+class C extends a { static { a; } m() { a; } x = a; }`)
+	table := resolver.Resolve(program)
+
+	scopes := make(map[*ast.Identifier]*resolver.Scope)
+	for _, sym := range table.Symbols() {
+		for _, ref := range sym.References {
+			scopes[ref.Ident] = ref.Scope
+		}
+	}
+	n := 0
+	ast.Traverse(program, ast.PathVisitor{Enter: func(p *ast.NodePath) {
+		id, ok := p.Node.(*ast.Identifier)
+		if !ok || scopes[id] == nil {
+			return
+		}
+		n++
+		if got, want := p.Scope().Node, scopes[id].Node(); got != want {
+			t.Errorf("%s at %d: Scope = %T; resolver's is the %v scope of %T", id.Name, id.Idx, got, scopes[id].Kind(), want)
+		}
+	}})
+	if n != len(scopes) {
+		t.Errorf("visited %d references; want %d", n, len(scopes))
+	}
+}
+
+func TestNodePathEdits(t *testing.T) {
+	tests := []struct {
+		name  string
+		src   string
+		enter func(t *testing.T, p *ast.NodePath)
+		want  string
+		// visited lists the expression statements visited, in order.
+		visited string
+	}{
+		{
+			name: "replace",
+			src:  "x + x;",
+			enter: func(t *testing.T, p *ast.NodePath) {
+				if isIdent(p.Node, "x") {
+					p.Replace(&ast.Identifier{Name: "y"})
+				}
+			},
+			want: "y+y;",
+		},
+		{
+			name: "replace with many in a list",
+			src:  "a; b; c;",
+			enter: func(t *testing.T, p *ast.NodePath) {
+				if isExprStmt(p.Node, "b") {
+					p.ReplaceWithMany(statements(t, "d; e;")...)
+				}
+			},
+			want:    "a;d;e;c;",
+			visited: "a b c",
+		},
+		{
+			name: "remove from a list",
+			src:  "a; b; b; c;",
+			enter: func(t *testing.T, p *ast.NodePath) {
+				if isExprStmt(p.Node, "b") {
+					p.Remove()
+				}
+			},
+			want:    "a;c;",
+			visited: "a b b c",
+		},
+		{
+			name: "insert into a list",
+			src:  "a; b;",
+			enter: func(t *testing.T, p *ast.NodePath) {
+				if isExprStmt(p.Node, "a") {
+					p.InsertBefore(statements(t, "x;")...)
+					p.InsertAfter(statements(t, "y; z;")...)
+					if p.Index != 1 {
+						t.Errorf("index after InsertBefore = %d; want 1", p.Index)
+					}
+				}
+			},
+			want:    "x;a;y;z;b;",
+			visited: "a b",
+		},
+		{
+			name: "insert around a statement outside a list",
+			src:  "if (a) b;",
+			enter: func(t *testing.T, p *ast.NodePath) {
+				if isExprStmt(p.Node, "b") {
+					p.InsertBefore(statements(t, "x;")...)
+					p.InsertAfter(statements(t, "y;")...)
+				}
+			},
+			want:    "if(a){x;b;y;}",
+			visited: "b",
+		},
+		{
+			name: "insert before an expression outside a list",
+			src:  "f(a ? b : c);",
+			enter: func(t *testing.T, p *ast.NodePath) {
+				if isIdent(p.Node, "b") {
+					p.InsertBefore(&ast.Identifier{Name: "x"})
+				}
+			},
+			want: "f(a?(x,b):c);",
+		},
+		{
+			name: "remove an optional field",
+			src:  "if (a) b; else c;",
+			enter: func(t *testing.T, p *ast.NodePath) {
+				if isExprStmt(p.Node, "c") {
+					p.Remove()
+				}
+			},
+			want:    "if(a)b;",
+			visited: "b c",
+		},
+		{
+			name: "remove a declarator",
+			src:  "var a = 1, b = 2;",
+			enter: func(t *testing.T, p *ast.NodePath) {
+				if d, ok := p.Node.(*ast.VariableDeclarator); ok && isIdent(d.Target.Target, "b") {
+					p.Remove()
+				}
+			},
+			want: "var a=1;",
+		},
+		{
+			name: "remove the last declarator",
+			src:  "a; var b = 1; c;",
+			enter: func(t *testing.T, p *ast.NodePath) {
+				if d, ok := p.Node.(*ast.VariableDeclarator); ok && isIdent(d.Target.Target, "b") {
+					p.Remove()
+				}
+			},
+			want:    "a;c;",
+			visited: "a c",
+		},
+		{
+			name: "remove a case before the default",
+			src:  "switch (x) { case 1: a; case 2: b; default: c; }",
+			enter: func(t *testing.T, p *ast.NodePath) {
+				if c, ok := p.Node.(*ast.CaseStatement); ok && c.Test != nil {
+					if n, ok := c.Test.Expr.(*ast.NumberLiteral); ok && n.Value == 1 {
+						p.Remove()
+					}
+				}
+			},
+			want: "switch(x){case 2:b;default:c;}",
+		},
+		{
+			name: "insert a case before the default",
+			src:  "switch (x) { default: c; case 2: b; }",
+			enter: func(t *testing.T, p *ast.NodePath) {
+				if c, ok := p.Node.(*ast.CaseStatement); ok && c.Test == nil {
+					p.InsertBefore(&ast.CaseStatement{Test: &ast.Expression{Expr: &ast.Identifier{Name: "y"}}})
+				}
+			},
+			want: "switch(x){case y:default:c;case 2:b;}",
+		},
+		{
+			name: "skip",
+			src:  "a; { b; }",
+			enter: func(t *testing.T, p *ast.NodePath) {
+				if _, ok := p.Node.(*ast.BlockStatement); ok {
+					p.Skip()
+				}
+			},
+			want:    "a;{b;}",
+			visited: "a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := parse(t, tt.src)
+			var visited []string
+			ast.Traverse(program, ast.PathVisitor{Enter: func(p *ast.NodePath) {
+				if s, ok := p.Node.(*ast.ExpressionStatement); ok {
+					if id, ok := s.Expression.Expr.(*ast.Identifier); ok {
+						visited = append(visited, id.Name)
+					}
+				}
+				tt.enter(t, p)
+			}})
+			if got := generator.GenerateMinified(program); got != tt.want {
+				t.Errorf("generated %q; want %q", got, tt.want)
+			}
+			if problems := ast.Validate(program); len(problems) != 0 {
+				t.Errorf("invalid tree: %v", problems)
+			}
+			if tt.visited != "" && strings.Join(visited, " ") != tt.visited {
+				t.Errorf("visited %q; want %q", strings.Join(visited, " "), tt.visited)
+			}
+		})
+	}
+}
+
+func TestNodePathExit(t *testing.T) {
+	program := parse(t, "a + b;")
+	var order []string
+	ast.Traverse(program, ast.PathVisitor{
+		Enter: func(p *ast.NodePath) { order = append(order, fmt.Sprintf("enter %T", p.Node)) },
+		Exit:  func(p *ast.NodePath) { order = append(order, fmt.Sprintf("exit %T", p.Node)) },
+	})
+	want := []string{
+		"enter *ast.Program", "enter *ast.ExpressionStatement", "enter *ast.BinaryExpression",
+		"enter *ast.Identifier", "exit *ast.Identifier", "enter *ast.Identifier", "exit *ast.Identifier",
+		"exit *ast.BinaryExpression", "exit *ast.ExpressionStatement", "exit *ast.Program",
+	}
+	if !slices.Equal(order, want) {
+		t.Errorf("order = %v; want %v", order, want)
+	}
+}
+
+func TestNodePathMisfit(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("replacing a statement with an identifier did not panic")
+		}
+	}()
+	ast.Traverse(parse(t, "a;"), ast.PathVisitor{Enter: func(p *ast.NodePath) {
+		if _, ok := p.Node.(*ast.ExpressionStatement); ok {
+			p.Replace(&ast.Identifier{Name: "x"})
+		}
+	}})
+}
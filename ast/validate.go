@@ -0,0 +1,183 @@
+package ast
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Problem is an invariant broken by a tree, as reported by Validate.
+type Problem struct {
+	// Path locates the node breaking the invariant from the root of the
+	// tree, as in Body[0].Stmt.Expression.
+	Path string
+	// Node is the node breaking the invariant. For a nil field it is the
+	// node holding the field, and Path leads to the field.
+	Node VisitableNode
+	// Message describes the problem.
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Path == "" {
+		return p.Message
+	}
+	return p.Path + ": " + p.Message
+}
+
+// Validate checks that the tree rooted at n can be printed, walked and
+// resolved, and returns the problems found in the order of the tree. It
+// checks that fields not tagged optional are set, that wrappers such as
+// Expression hold a node except where the parser leaves them empty, as in
+// array holes, shorthand properties without default and the clauses of
+// for (;;), and invariants the types do not capture:
+//
+//   - a SequenceExpression holds at least one expression;
+//   - a TemplateLiteral has one more element than expressions;
+//   - a VariableDeclaration declares at least one variable, and exactly one
+//     as the left side of a for-in or for-of statement;
+//   - the Default of a SwitchStatement is -1 or the index of the only case
+//     without a test;
+//   - the Rest of a ParameterList is an Identifier;
+//   - identifiers have a name.
+//
+// A nil list of problems means the tree is valid; tests of transforms can
+// check it after every pass.
+func Validate(n VisitableNode) []Problem {
+	var v validator
+	v.value("", reflect.ValueOf(n), false)
+	return v.problems
+}
+
+type validator struct {
+	problems []Problem
+}
+
+func (v *validator) report(path string, n VisitableNode, format string, args ...any) {
+	v.problems = append(v.problems, Problem{Path: path, Node: n, Message: fmt.Sprintf(format, args...)})
+}
+
+// value checks the node or list v found at path. hole tells whether a
+// wrapper there may be empty.
+func (v *validator) value(path string, val reflect.Value, hole bool) {
+	if val.Kind() == reflect.Interface {
+		val = val.Elem()
+	}
+	if val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return
+		}
+		val = val.Elem()
+	}
+	switch val.Kind() {
+	case reflect.Slice:
+		for i := range val.Len() {
+			v.value(path+"["+strconv.Itoa(i)+"]", val.Index(i).Addr(), hole)
+		}
+		return
+	case reflect.Struct:
+	default:
+		return
+	}
+	if val.Type() == lazyBodyType {
+		return
+	}
+
+	n, _ := val.Addr().Interface().(VisitableNode)
+	t := val.Type()
+	wrapper := isWrapper(t)
+	for i := range t.NumField() {
+		f := t.Field(i)
+		fv := val.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fieldPath := f.Name
+		if path != "" {
+			fieldPath = path + "." + f.Name
+		}
+		if (fv.Kind() == reflect.Pointer || fv.Kind() == reflect.Interface) && fv.IsNil() {
+			optional := f.Tag.Get("optional") == "true"
+			if wrapper && !hole || !wrapper && !optional {
+				v.report(fieldPath, n, "%s %s is nil", t.Name(), f.Name)
+			}
+			continue
+		}
+		v.value(fieldPath, fv, holes(n, f.Name))
+	}
+	v.invariants(path, n)
+}
+
+// holes reports whether the wrappers in the field name of n may be empty.
+func holes(n VisitableNode, name string) bool {
+	switch n.(type) {
+	case *ArrayLiteral:
+		return name == "Value"
+	case *ArrayPattern:
+		return name == "Elements" || name == "Rest"
+	case *ObjectPattern:
+		return name == "Rest"
+	case *PropertyShort:
+		return name == "Initializer"
+	case *ForStatement:
+		return name == "Test" || name == "Update"
+	}
+	return false
+}
+
+func (v *validator) invariants(path string, n VisitableNode) {
+	switch n := n.(type) {
+	case *SequenceExpression:
+		if len(n.Sequence) == 0 {
+			v.report(path, n, "SequenceExpression is empty")
+		}
+	case *TemplateLiteral:
+		if len(n.Elements) != len(n.Expressions)+1 {
+			v.report(path, n, "TemplateLiteral has %d elements for %d expressions", len(n.Elements), len(n.Expressions))
+		}
+	case *VariableDeclaration:
+		if len(n.List) == 0 {
+			v.report(path, n, "VariableDeclaration is empty")
+		}
+	case *ForInStatement:
+		v.forInto(path, n, n.Into)
+	case *ForOfStatement:
+		v.forInto(path, n, n.Into)
+	case *SwitchStatement:
+		v.switchDefault(path, n)
+	case *ParameterList:
+		if _, ok := n.Rest.(*Identifier); n.Rest != nil && !ok {
+			v.report(path, n, "ParameterList Rest is a %T, not an *Identifier", n.Rest)
+		}
+	case *Identifier:
+		if n.Name == "" {
+			v.report(path, n, "Identifier has no name")
+		}
+	}
+}
+
+func (v *validator) forInto(path string, n VisitableNode, into *ForInto) {
+	if into == nil {
+		// Already reported as a nil field.
+		return
+	}
+	if d, ok := into.Into.(*VariableDeclaration); ok && len(d.List) > 1 {
+		v.report(path, n, "%T declares %d variables", n, len(d.List))
+	}
+}
+
+func (v *validator) switchDefault(path string, n *SwitchStatement) {
+	if n.Default < -1 || n.Default >= len(n.Body) {
+		v.report(path, n, "SwitchStatement Default %d is out of range for %d cases", n.Default, len(n.Body))
+		return
+	}
+	for i, c := range n.Body {
+		if (c.Test == nil) != (i == n.Default) {
+			if c.Test == nil {
+				v.report(path, n, "SwitchStatement case %d has no test but Default is %d", i, n.Default)
+			} else {
+				v.report(path, n, "SwitchStatement Default %d has a test", i)
+			}
+		}
+	}
+}
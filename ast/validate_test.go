@@ -0,0 +1,93 @@
+package ast_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/t14raptor/go-fast/ast"
+)
+
+func TestValidateParsed(t *testing.T) {
+	for _, src := range []string{
+		"let [a, , ...b] = [1, , 2]; var {c, d: [e] = 1, ...f} = g;",
+		"for (;;) break; for (let i = 0; i < 1; i++) continue; for (const k in o) ; for (x of y) ;",
+		"switch (a) { case 1: b; default: c; case 2: }",
+		"try { t(); } catch { } finally { } try { t(); } catch ({m}) { }",
+		"function h(a = 1, ...r) { return; } async function* g() { yield* x; await x; }",
+		"class C extends D { static #p = 1; get x() { return this.#p; } static { } }",
+		"(async (x, y) => ({x, y, ...z, [k]: 1, get q() { return 1; }, r() {}}));",
+		"tag`a${1}b`; a?.b?.[c]?.(d); (a, b); a ? b : c; if (a) b; else { e; }",
+	} {
+		if problems := ast.Validate(parse(t, src)); problems != nil {
+			t.Errorf("%q: Validate = %v; want no problems", src, problems)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		// src is parsed and broken by mutate.
+		src    string
+		mutate func(p *ast.Program)
+		want   []string
+	}{
+		{"a;", func(p *ast.Program) {
+			p.Body[0].Stmt.(*ast.ExpressionStatement).Expression.Expr = nil
+		}, []string{"Body[0].Stmt.Expression.Expr: Expression Expr is nil"}},
+		{"f(a);", func(p *ast.Program) {
+			call := p.Body[0].Stmt.(*ast.ExpressionStatement).Expression.Expr.(*ast.CallExpression)
+			call.ArgumentList[0].Expr = &ast.SequenceExpression{}
+			call.Callee = nil
+		}, []string{
+			"Body[0].Stmt.Expression.Expr.Callee: CallExpression Callee is nil",
+			"Body[0].Stmt.Expression.Expr.ArgumentList[0].Expr: SequenceExpression is empty",
+		}},
+		{"switch (a) { case 1: default: }", func(p *ast.Program) {
+			p.Body[0].Stmt.(*ast.SwitchStatement).Default = 2
+		}, []string{"Body[0].Stmt: SwitchStatement Default 2 is out of range for 2 cases"}},
+		{"switch (a) { case 1: default: }", func(p *ast.Program) {
+			p.Body[0].Stmt.(*ast.SwitchStatement).Default = -1
+		}, []string{"Body[0].Stmt: SwitchStatement case 1 has no test but Default is -1"}},
+		{"function f(...r) {}", func(p *ast.Program) {
+			p.Body[0].Stmt.(*ast.FunctionDeclaration).Function.ParameterList.Rest = &ast.ArrayPattern{Rest: &ast.Expression{}}
+		}, []string{"Body[0].Stmt.Function.ParameterList: ParameterList Rest is a *ast.ArrayPattern, not an *Identifier"}},
+		{"let a = `x${a}y`;", func(p *ast.Program) {
+			d := p.Body[0].Stmt.(*ast.VariableDeclaration)
+			d.List[0].Initializer.Expr.(*ast.TemplateLiteral).Elements = nil
+			d.List[0].Target.Target.(*ast.Identifier).Name = ""
+		}, []string{
+			"Body[0].Stmt.List[0].Target.Target: Identifier has no name",
+			"Body[0].Stmt.List[0].Initializer.Expr: TemplateLiteral has 0 elements for 1 expressions",
+		}},
+		{"for (;;) ;", func(p *ast.Program) {
+			p.Body[0].Stmt.(*ast.ForStatement).Body.Stmt = nil
+		}, []string{"Body[0].Stmt.Body.Stmt: Statement Stmt is nil"}},
+		{"for (a in b) ;", func(p *ast.Program) {
+			p.Body[0].Stmt.(*ast.ForInStatement).Into = nil
+		}, []string{"Body[0].Stmt.Into: ForInStatement Into is nil"}},
+		{"for (a of b) ;", func(p *ast.Program) {
+			p.Body[0].Stmt.(*ast.ForOfStatement).Into = nil
+		}, []string{"Body[0].Stmt.Into: ForOfStatement Into is nil"}},
+	}
+	for _, tt := range tests {
+		program := parse(t, tt.src)
+		tt.mutate(program)
+		var got []string
+		for _, p := range ast.Validate(program) {
+			got = append(got, p.String())
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%q: Validate = %q; want %q", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestResultTypesAreNotNodes(t *testing.T) {
+	// isNodeType in gen_common.go keeps the generators from treating these
+	// as nodes, which gave Problem Clone and VisitWith methods.
+	for _, v := range []any{&ast.Problem{}, &ast.DumpOptions{}, &ast.EqualOptions{}} {
+		if _, ok := v.(ast.VisitableNode); ok {
+			t.Errorf("%T is a VisitableNode", v)
+		}
+	}
+}
@@ -680,6 +680,7 @@ func (n *MetaProperty) VisitWith(v Visitor) {
 }
 func (n *MetaProperty) VisitChildrenWith(v Visitor) {
 	n.Meta.VisitWith(v)
+	n.Property.VisitWith(v)
 }
 func (n *MethodDefinition) VisitWith(v Visitor) {
 	v.VisitMethodDefinition(n)
@@ -947,3 +948,1708 @@ func (n *YieldExpression) VisitWith(v Visitor) {
 func (n *YieldExpression) VisitChildrenWith(v Visitor) {
 	n.Argument.VisitWith(v)
 }
+
+type EnterExitVisitor interface {
+	EnterArrayLiteral(n *ArrayLiteral) Action
+	ExitArrayLiteral(n *ArrayLiteral) Action
+	EnterArrayPattern(n *ArrayPattern) Action
+	ExitArrayPattern(n *ArrayPattern) Action
+	EnterArrowFunctionLiteral(n *ArrowFunctionLiteral) Action
+	ExitArrowFunctionLiteral(n *ArrowFunctionLiteral) Action
+	EnterAssignExpression(n *AssignExpression) Action
+	ExitAssignExpression(n *AssignExpression) Action
+	EnterAwaitExpression(n *AwaitExpression) Action
+	ExitAwaitExpression(n *AwaitExpression) Action
+	EnterBadStatement(n *BadStatement) Action
+	ExitBadStatement(n *BadStatement) Action
+	EnterBigIntLiteral(n *BigIntLiteral) Action
+	ExitBigIntLiteral(n *BigIntLiteral) Action
+	EnterBinaryExpression(n *BinaryExpression) Action
+	ExitBinaryExpression(n *BinaryExpression) Action
+	EnterBindingTarget(n *BindingTarget) Action
+	ExitBindingTarget(n *BindingTarget) Action
+	EnterBlockStatement(n *BlockStatement) Action
+	ExitBlockStatement(n *BlockStatement) Action
+	EnterBooleanLiteral(n *BooleanLiteral) Action
+	ExitBooleanLiteral(n *BooleanLiteral) Action
+	EnterBreakStatement(n *BreakStatement) Action
+	ExitBreakStatement(n *BreakStatement) Action
+	EnterCallExpression(n *CallExpression) Action
+	ExitCallExpression(n *CallExpression) Action
+	EnterCaseStatement(n *CaseStatement) Action
+	ExitCaseStatement(n *CaseStatement) Action
+	EnterCaseStatements(n *CaseStatements) Action
+	ExitCaseStatements(n *CaseStatements) Action
+	EnterCatchStatement(n *CatchStatement) Action
+	ExitCatchStatement(n *CatchStatement) Action
+	EnterClassDeclaration(n *ClassDeclaration) Action
+	ExitClassDeclaration(n *ClassDeclaration) Action
+	EnterClassElement(n *ClassElement) Action
+	ExitClassElement(n *ClassElement) Action
+	EnterClassElements(n *ClassElements) Action
+	ExitClassElements(n *ClassElements) Action
+	EnterClassLiteral(n *ClassLiteral) Action
+	ExitClassLiteral(n *ClassLiteral) Action
+	EnterClassStaticBlock(n *ClassStaticBlock) Action
+	ExitClassStaticBlock(n *ClassStaticBlock) Action
+	EnterComputedProperty(n *ComputedProperty) Action
+	ExitComputedProperty(n *ComputedProperty) Action
+	EnterConciseBody(n *ConciseBody) Action
+	ExitConciseBody(n *ConciseBody) Action
+	EnterConditionalExpression(n *ConditionalExpression) Action
+	ExitConditionalExpression(n *ConditionalExpression) Action
+	EnterContinueStatement(n *ContinueStatement) Action
+	ExitContinueStatement(n *ContinueStatement) Action
+	EnterDebuggerStatement(n *DebuggerStatement) Action
+	ExitDebuggerStatement(n *DebuggerStatement) Action
+	EnterDoWhileStatement(n *DoWhileStatement) Action
+	ExitDoWhileStatement(n *DoWhileStatement) Action
+	EnterEmptyStatement(n *EmptyStatement) Action
+	ExitEmptyStatement(n *EmptyStatement) Action
+	EnterExpression(n *Expression) Action
+	ExitExpression(n *Expression) Action
+	EnterExpressionStatement(n *ExpressionStatement) Action
+	ExitExpressionStatement(n *ExpressionStatement) Action
+	EnterExpressions(n *Expressions) Action
+	ExitExpressions(n *Expressions) Action
+	EnterFieldDefinition(n *FieldDefinition) Action
+	ExitFieldDefinition(n *FieldDefinition) Action
+	EnterForInStatement(n *ForInStatement) Action
+	ExitForInStatement(n *ForInStatement) Action
+	EnterForInto(n *ForInto) Action
+	ExitForInto(n *ForInto) Action
+	EnterForLoopInitializer(n *ForLoopInitializer) Action
+	ExitForLoopInitializer(n *ForLoopInitializer) Action
+	EnterForOfStatement(n *ForOfStatement) Action
+	ExitForOfStatement(n *ForOfStatement) Action
+	EnterForStatement(n *ForStatement) Action
+	ExitForStatement(n *ForStatement) Action
+	EnterFunctionDeclaration(n *FunctionDeclaration) Action
+	ExitFunctionDeclaration(n *FunctionDeclaration) Action
+	EnterFunctionLiteral(n *FunctionLiteral) Action
+	ExitFunctionLiteral(n *FunctionLiteral) Action
+	EnterIdentifier(n *Identifier) Action
+	ExitIdentifier(n *Identifier) Action
+	EnterIfStatement(n *IfStatement) Action
+	ExitIfStatement(n *IfStatement) Action
+	EnterInvalidExpression(n *InvalidExpression) Action
+	ExitInvalidExpression(n *InvalidExpression) Action
+	EnterLabelledStatement(n *LabelledStatement) Action
+	ExitLabelledStatement(n *LabelledStatement) Action
+	EnterLogicalExpression(n *LogicalExpression) Action
+	ExitLogicalExpression(n *LogicalExpression) Action
+	EnterMemberExpression(n *MemberExpression) Action
+	ExitMemberExpression(n *MemberExpression) Action
+	EnterMemberProperty(n *MemberProperty) Action
+	ExitMemberProperty(n *MemberProperty) Action
+	EnterMetaProperty(n *MetaProperty) Action
+	ExitMetaProperty(n *MetaProperty) Action
+	EnterMethodDefinition(n *MethodDefinition) Action
+	ExitMethodDefinition(n *MethodDefinition) Action
+	EnterNewExpression(n *NewExpression) Action
+	ExitNewExpression(n *NewExpression) Action
+	EnterNullLiteral(n *NullLiteral) Action
+	ExitNullLiteral(n *NullLiteral) Action
+	EnterNumberLiteral(n *NumberLiteral) Action
+	ExitNumberLiteral(n *NumberLiteral) Action
+	EnterObjectLiteral(n *ObjectLiteral) Action
+	ExitObjectLiteral(n *ObjectLiteral) Action
+	EnterObjectPattern(n *ObjectPattern) Action
+	ExitObjectPattern(n *ObjectPattern) Action
+	EnterOptional(n *Optional) Action
+	ExitOptional(n *Optional) Action
+	EnterOptionalChain(n *OptionalChain) Action
+	ExitOptionalChain(n *OptionalChain) Action
+	EnterParameterList(n *ParameterList) Action
+	ExitParameterList(n *ParameterList) Action
+	EnterPrivateDotExpression(n *PrivateDotExpression) Action
+	ExitPrivateDotExpression(n *PrivateDotExpression) Action
+	EnterPrivateIdentifier(n *PrivateIdentifier) Action
+	ExitPrivateIdentifier(n *PrivateIdentifier) Action
+	EnterProgram(n *Program) Action
+	ExitProgram(n *Program) Action
+	EnterProperties(n *Properties) Action
+	ExitProperties(n *Properties) Action
+	EnterProperty(n *Property) Action
+	ExitProperty(n *Property) Action
+	EnterPropertyKeyed(n *PropertyKeyed) Action
+	ExitPropertyKeyed(n *PropertyKeyed) Action
+	EnterPropertyShort(n *PropertyShort) Action
+	ExitPropertyShort(n *PropertyShort) Action
+	EnterRegExpLiteral(n *RegExpLiteral) Action
+	ExitRegExpLiteral(n *RegExpLiteral) Action
+	EnterReturnStatement(n *ReturnStatement) Action
+	ExitReturnStatement(n *ReturnStatement) Action
+	EnterSequenceExpression(n *SequenceExpression) Action
+	ExitSequenceExpression(n *SequenceExpression) Action
+	EnterSpreadElement(n *SpreadElement) Action
+	ExitSpreadElement(n *SpreadElement) Action
+	EnterStatement(n *Statement) Action
+	ExitStatement(n *Statement) Action
+	EnterStatements(n *Statements) Action
+	ExitStatements(n *Statements) Action
+	EnterStringLiteral(n *StringLiteral) Action
+	ExitStringLiteral(n *StringLiteral) Action
+	EnterSuperExpression(n *SuperExpression) Action
+	ExitSuperExpression(n *SuperExpression) Action
+	EnterSwitchStatement(n *SwitchStatement) Action
+	ExitSwitchStatement(n *SwitchStatement) Action
+	EnterTemplateElement(n *TemplateElement) Action
+	ExitTemplateElement(n *TemplateElement) Action
+	EnterTemplateElements(n *TemplateElements) Action
+	ExitTemplateElements(n *TemplateElements) Action
+	EnterTemplateLiteral(n *TemplateLiteral) Action
+	ExitTemplateLiteral(n *TemplateLiteral) Action
+	EnterThisExpression(n *ThisExpression) Action
+	ExitThisExpression(n *ThisExpression) Action
+	EnterThrowStatement(n *ThrowStatement) Action
+	ExitThrowStatement(n *ThrowStatement) Action
+	EnterTryStatement(n *TryStatement) Action
+	ExitTryStatement(n *TryStatement) Action
+	EnterUnaryExpression(n *UnaryExpression) Action
+	ExitUnaryExpression(n *UnaryExpression) Action
+	EnterUpdateExpression(n *UpdateExpression) Action
+	ExitUpdateExpression(n *UpdateExpression) Action
+	EnterVariableDeclaration(n *VariableDeclaration) Action
+	ExitVariableDeclaration(n *VariableDeclaration) Action
+	EnterVariableDeclarator(n *VariableDeclarator) Action
+	ExitVariableDeclarator(n *VariableDeclarator) Action
+	EnterVariableDeclarators(n *VariableDeclarators) Action
+	ExitVariableDeclarators(n *VariableDeclarators) Action
+	EnterWhileStatement(n *WhileStatement) Action
+	ExitWhileStatement(n *WhileStatement) Action
+	EnterWithStatement(n *WithStatement) Action
+	ExitWithStatement(n *WithStatement) Action
+	EnterYieldExpression(n *YieldExpression) Action
+	ExitYieldExpression(n *YieldExpression) Action
+}
+type NoopEnterExitVisitor struct {
+}
+
+func (*NoopEnterExitVisitor) EnterArrayLiteral(n *ArrayLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitArrayLiteral(n *ArrayLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterArrayPattern(n *ArrayPattern) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitArrayPattern(n *ArrayPattern) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterArrowFunctionLiteral(n *ArrowFunctionLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitArrowFunctionLiteral(n *ArrowFunctionLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterAssignExpression(n *AssignExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitAssignExpression(n *AssignExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterAwaitExpression(n *AwaitExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitAwaitExpression(n *AwaitExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterBadStatement(n *BadStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitBadStatement(n *BadStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterBigIntLiteral(n *BigIntLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitBigIntLiteral(n *BigIntLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterBinaryExpression(n *BinaryExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitBinaryExpression(n *BinaryExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterBindingTarget(n *BindingTarget) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitBindingTarget(n *BindingTarget) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterBlockStatement(n *BlockStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitBlockStatement(n *BlockStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterBooleanLiteral(n *BooleanLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitBooleanLiteral(n *BooleanLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterBreakStatement(n *BreakStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitBreakStatement(n *BreakStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterCallExpression(n *CallExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitCallExpression(n *CallExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterCaseStatement(n *CaseStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitCaseStatement(n *CaseStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterCaseStatements(n *CaseStatements) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitCaseStatements(n *CaseStatements) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterCatchStatement(n *CatchStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitCatchStatement(n *CatchStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterClassDeclaration(n *ClassDeclaration) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitClassDeclaration(n *ClassDeclaration) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterClassElement(n *ClassElement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitClassElement(n *ClassElement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterClassElements(n *ClassElements) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitClassElements(n *ClassElements) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterClassLiteral(n *ClassLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitClassLiteral(n *ClassLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterClassStaticBlock(n *ClassStaticBlock) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitClassStaticBlock(n *ClassStaticBlock) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterComputedProperty(n *ComputedProperty) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitComputedProperty(n *ComputedProperty) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterConciseBody(n *ConciseBody) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitConciseBody(n *ConciseBody) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterConditionalExpression(n *ConditionalExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitConditionalExpression(n *ConditionalExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterContinueStatement(n *ContinueStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitContinueStatement(n *ContinueStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterDebuggerStatement(n *DebuggerStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitDebuggerStatement(n *DebuggerStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterDoWhileStatement(n *DoWhileStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitDoWhileStatement(n *DoWhileStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterEmptyStatement(n *EmptyStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitEmptyStatement(n *EmptyStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterExpression(n *Expression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitExpression(n *Expression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterExpressionStatement(n *ExpressionStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitExpressionStatement(n *ExpressionStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterExpressions(n *Expressions) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitExpressions(n *Expressions) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterFieldDefinition(n *FieldDefinition) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitFieldDefinition(n *FieldDefinition) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterForInStatement(n *ForInStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitForInStatement(n *ForInStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterForInto(n *ForInto) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitForInto(n *ForInto) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterForLoopInitializer(n *ForLoopInitializer) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitForLoopInitializer(n *ForLoopInitializer) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterForOfStatement(n *ForOfStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitForOfStatement(n *ForOfStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterForStatement(n *ForStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitForStatement(n *ForStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterFunctionDeclaration(n *FunctionDeclaration) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitFunctionDeclaration(n *FunctionDeclaration) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterFunctionLiteral(n *FunctionLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitFunctionLiteral(n *FunctionLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterIdentifier(n *Identifier) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitIdentifier(n *Identifier) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterIfStatement(n *IfStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitIfStatement(n *IfStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterInvalidExpression(n *InvalidExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitInvalidExpression(n *InvalidExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterLabelledStatement(n *LabelledStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitLabelledStatement(n *LabelledStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterLogicalExpression(n *LogicalExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitLogicalExpression(n *LogicalExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterMemberExpression(n *MemberExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitMemberExpression(n *MemberExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterMemberProperty(n *MemberProperty) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitMemberProperty(n *MemberProperty) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterMetaProperty(n *MetaProperty) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitMetaProperty(n *MetaProperty) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterMethodDefinition(n *MethodDefinition) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitMethodDefinition(n *MethodDefinition) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterNewExpression(n *NewExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitNewExpression(n *NewExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterNullLiteral(n *NullLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitNullLiteral(n *NullLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterNumberLiteral(n *NumberLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitNumberLiteral(n *NumberLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterObjectLiteral(n *ObjectLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitObjectLiteral(n *ObjectLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterObjectPattern(n *ObjectPattern) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitObjectPattern(n *ObjectPattern) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterOptional(n *Optional) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitOptional(n *Optional) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterOptionalChain(n *OptionalChain) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitOptionalChain(n *OptionalChain) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterParameterList(n *ParameterList) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitParameterList(n *ParameterList) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterPrivateDotExpression(n *PrivateDotExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitPrivateDotExpression(n *PrivateDotExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterPrivateIdentifier(n *PrivateIdentifier) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitPrivateIdentifier(n *PrivateIdentifier) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterProgram(n *Program) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitProgram(n *Program) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterProperties(n *Properties) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitProperties(n *Properties) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterProperty(n *Property) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitProperty(n *Property) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterPropertyKeyed(n *PropertyKeyed) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitPropertyKeyed(n *PropertyKeyed) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterPropertyShort(n *PropertyShort) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitPropertyShort(n *PropertyShort) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterRegExpLiteral(n *RegExpLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitRegExpLiteral(n *RegExpLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterReturnStatement(n *ReturnStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitReturnStatement(n *ReturnStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterSequenceExpression(n *SequenceExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitSequenceExpression(n *SequenceExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterSpreadElement(n *SpreadElement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitSpreadElement(n *SpreadElement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterStatement(n *Statement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitStatement(n *Statement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterStatements(n *Statements) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitStatements(n *Statements) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterStringLiteral(n *StringLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitStringLiteral(n *StringLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterSuperExpression(n *SuperExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitSuperExpression(n *SuperExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterSwitchStatement(n *SwitchStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitSwitchStatement(n *SwitchStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterTemplateElement(n *TemplateElement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitTemplateElement(n *TemplateElement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterTemplateElements(n *TemplateElements) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitTemplateElements(n *TemplateElements) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterTemplateLiteral(n *TemplateLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitTemplateLiteral(n *TemplateLiteral) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterThisExpression(n *ThisExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitThisExpression(n *ThisExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterThrowStatement(n *ThrowStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitThrowStatement(n *ThrowStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterTryStatement(n *TryStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitTryStatement(n *TryStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterUnaryExpression(n *UnaryExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitUnaryExpression(n *UnaryExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterUpdateExpression(n *UpdateExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitUpdateExpression(n *UpdateExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterVariableDeclaration(n *VariableDeclaration) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitVariableDeclaration(n *VariableDeclaration) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterVariableDeclarator(n *VariableDeclarator) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitVariableDeclarator(n *VariableDeclarator) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterVariableDeclarators(n *VariableDeclarators) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitVariableDeclarators(n *VariableDeclarators) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterWhileStatement(n *WhileStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitWhileStatement(n *WhileStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterWithStatement(n *WithStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitWithStatement(n *WithStatement) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) EnterYieldExpression(n *YieldExpression) Action {
+	return Continue
+}
+func (*NoopEnterExitVisitor) ExitYieldExpression(n *YieldExpression) Action {
+	return Continue
+}
+func enterNode(v EnterExitVisitor, n VisitableNode) Action {
+	switch n := n.(type) {
+	case *ArrayLiteral:
+		return v.EnterArrayLiteral(n)
+	case *ArrayPattern:
+		return v.EnterArrayPattern(n)
+	case *ArrowFunctionLiteral:
+		return v.EnterArrowFunctionLiteral(n)
+	case *AssignExpression:
+		return v.EnterAssignExpression(n)
+	case *AwaitExpression:
+		return v.EnterAwaitExpression(n)
+	case *BadStatement:
+		return v.EnterBadStatement(n)
+	case *BigIntLiteral:
+		return v.EnterBigIntLiteral(n)
+	case *BinaryExpression:
+		return v.EnterBinaryExpression(n)
+	case *BindingTarget:
+		return v.EnterBindingTarget(n)
+	case *BlockStatement:
+		return v.EnterBlockStatement(n)
+	case *BooleanLiteral:
+		return v.EnterBooleanLiteral(n)
+	case *BreakStatement:
+		return v.EnterBreakStatement(n)
+	case *CallExpression:
+		return v.EnterCallExpression(n)
+	case *CaseStatement:
+		return v.EnterCaseStatement(n)
+	case *CaseStatements:
+		return v.EnterCaseStatements(n)
+	case *CatchStatement:
+		return v.EnterCatchStatement(n)
+	case *ClassDeclaration:
+		return v.EnterClassDeclaration(n)
+	case *ClassElement:
+		return v.EnterClassElement(n)
+	case *ClassElements:
+		return v.EnterClassElements(n)
+	case *ClassLiteral:
+		return v.EnterClassLiteral(n)
+	case *ClassStaticBlock:
+		return v.EnterClassStaticBlock(n)
+	case *ComputedProperty:
+		return v.EnterComputedProperty(n)
+	case *ConciseBody:
+		return v.EnterConciseBody(n)
+	case *ConditionalExpression:
+		return v.EnterConditionalExpression(n)
+	case *ContinueStatement:
+		return v.EnterContinueStatement(n)
+	case *DebuggerStatement:
+		return v.EnterDebuggerStatement(n)
+	case *DoWhileStatement:
+		return v.EnterDoWhileStatement(n)
+	case *EmptyStatement:
+		return v.EnterEmptyStatement(n)
+	case *Expression:
+		return v.EnterExpression(n)
+	case *ExpressionStatement:
+		return v.EnterExpressionStatement(n)
+	case *Expressions:
+		return v.EnterExpressions(n)
+	case *FieldDefinition:
+		return v.EnterFieldDefinition(n)
+	case *ForInStatement:
+		return v.EnterForInStatement(n)
+	case *ForInto:
+		return v.EnterForInto(n)
+	case *ForLoopInitializer:
+		return v.EnterForLoopInitializer(n)
+	case *ForOfStatement:
+		return v.EnterForOfStatement(n)
+	case *ForStatement:
+		return v.EnterForStatement(n)
+	case *FunctionDeclaration:
+		return v.EnterFunctionDeclaration(n)
+	case *FunctionLiteral:
+		return v.EnterFunctionLiteral(n)
+	case *Identifier:
+		return v.EnterIdentifier(n)
+	case *IfStatement:
+		return v.EnterIfStatement(n)
+	case *InvalidExpression:
+		return v.EnterInvalidExpression(n)
+	case *LabelledStatement:
+		return v.EnterLabelledStatement(n)
+	case *LogicalExpression:
+		return v.EnterLogicalExpression(n)
+	case *MemberExpression:
+		return v.EnterMemberExpression(n)
+	case *MemberProperty:
+		return v.EnterMemberProperty(n)
+	case *MetaProperty:
+		return v.EnterMetaProperty(n)
+	case *MethodDefinition:
+		return v.EnterMethodDefinition(n)
+	case *NewExpression:
+		return v.EnterNewExpression(n)
+	case *NullLiteral:
+		return v.EnterNullLiteral(n)
+	case *NumberLiteral:
+		return v.EnterNumberLiteral(n)
+	case *ObjectLiteral:
+		return v.EnterObjectLiteral(n)
+	case *ObjectPattern:
+		return v.EnterObjectPattern(n)
+	case *Optional:
+		return v.EnterOptional(n)
+	case *OptionalChain:
+		return v.EnterOptionalChain(n)
+	case *ParameterList:
+		return v.EnterParameterList(n)
+	case *PrivateDotExpression:
+		return v.EnterPrivateDotExpression(n)
+	case *PrivateIdentifier:
+		return v.EnterPrivateIdentifier(n)
+	case *Program:
+		return v.EnterProgram(n)
+	case *Properties:
+		return v.EnterProperties(n)
+	case *Property:
+		return v.EnterProperty(n)
+	case *PropertyKeyed:
+		return v.EnterPropertyKeyed(n)
+	case *PropertyShort:
+		return v.EnterPropertyShort(n)
+	case *RegExpLiteral:
+		return v.EnterRegExpLiteral(n)
+	case *ReturnStatement:
+		return v.EnterReturnStatement(n)
+	case *SequenceExpression:
+		return v.EnterSequenceExpression(n)
+	case *SpreadElement:
+		return v.EnterSpreadElement(n)
+	case *Statement:
+		return v.EnterStatement(n)
+	case *Statements:
+		return v.EnterStatements(n)
+	case *StringLiteral:
+		return v.EnterStringLiteral(n)
+	case *SuperExpression:
+		return v.EnterSuperExpression(n)
+	case *SwitchStatement:
+		return v.EnterSwitchStatement(n)
+	case *TemplateElement:
+		return v.EnterTemplateElement(n)
+	case *TemplateElements:
+		return v.EnterTemplateElements(n)
+	case *TemplateLiteral:
+		return v.EnterTemplateLiteral(n)
+	case *ThisExpression:
+		return v.EnterThisExpression(n)
+	case *ThrowStatement:
+		return v.EnterThrowStatement(n)
+	case *TryStatement:
+		return v.EnterTryStatement(n)
+	case *UnaryExpression:
+		return v.EnterUnaryExpression(n)
+	case *UpdateExpression:
+		return v.EnterUpdateExpression(n)
+	case *VariableDeclaration:
+		return v.EnterVariableDeclaration(n)
+	case *VariableDeclarator:
+		return v.EnterVariableDeclarator(n)
+	case *VariableDeclarators:
+		return v.EnterVariableDeclarators(n)
+	case *WhileStatement:
+		return v.EnterWhileStatement(n)
+	case *WithStatement:
+		return v.EnterWithStatement(n)
+	case *YieldExpression:
+		return v.EnterYieldExpression(n)
+	}
+	return Continue
+}
+func exitNode(v EnterExitVisitor, n VisitableNode) Action {
+	switch n := n.(type) {
+	case *ArrayLiteral:
+		return v.ExitArrayLiteral(n)
+	case *ArrayPattern:
+		return v.ExitArrayPattern(n)
+	case *ArrowFunctionLiteral:
+		return v.ExitArrowFunctionLiteral(n)
+	case *AssignExpression:
+		return v.ExitAssignExpression(n)
+	case *AwaitExpression:
+		return v.ExitAwaitExpression(n)
+	case *BadStatement:
+		return v.ExitBadStatement(n)
+	case *BigIntLiteral:
+		return v.ExitBigIntLiteral(n)
+	case *BinaryExpression:
+		return v.ExitBinaryExpression(n)
+	case *BindingTarget:
+		return v.ExitBindingTarget(n)
+	case *BlockStatement:
+		return v.ExitBlockStatement(n)
+	case *BooleanLiteral:
+		return v.ExitBooleanLiteral(n)
+	case *BreakStatement:
+		return v.ExitBreakStatement(n)
+	case *CallExpression:
+		return v.ExitCallExpression(n)
+	case *CaseStatement:
+		return v.ExitCaseStatement(n)
+	case *CaseStatements:
+		return v.ExitCaseStatements(n)
+	case *CatchStatement:
+		return v.ExitCatchStatement(n)
+	case *ClassDeclaration:
+		return v.ExitClassDeclaration(n)
+	case *ClassElement:
+		return v.ExitClassElement(n)
+	case *ClassElements:
+		return v.ExitClassElements(n)
+	case *ClassLiteral:
+		return v.ExitClassLiteral(n)
+	case *ClassStaticBlock:
+		return v.ExitClassStaticBlock(n)
+	case *ComputedProperty:
+		return v.ExitComputedProperty(n)
+	case *ConciseBody:
+		return v.ExitConciseBody(n)
+	case *ConditionalExpression:
+		return v.ExitConditionalExpression(n)
+	case *ContinueStatement:
+		return v.ExitContinueStatement(n)
+	case *DebuggerStatement:
+		return v.ExitDebuggerStatement(n)
+	case *DoWhileStatement:
+		return v.ExitDoWhileStatement(n)
+	case *EmptyStatement:
+		return v.ExitEmptyStatement(n)
+	case *Expression:
+		return v.ExitExpression(n)
+	case *ExpressionStatement:
+		return v.ExitExpressionStatement(n)
+	case *Expressions:
+		return v.ExitExpressions(n)
+	case *FieldDefinition:
+		return v.ExitFieldDefinition(n)
+	case *ForInStatement:
+		return v.ExitForInStatement(n)
+	case *ForInto:
+		return v.ExitForInto(n)
+	case *ForLoopInitializer:
+		return v.ExitForLoopInitializer(n)
+	case *ForOfStatement:
+		return v.ExitForOfStatement(n)
+	case *ForStatement:
+		return v.ExitForStatement(n)
+	case *FunctionDeclaration:
+		return v.ExitFunctionDeclaration(n)
+	case *FunctionLiteral:
+		return v.ExitFunctionLiteral(n)
+	case *Identifier:
+		return v.ExitIdentifier(n)
+	case *IfStatement:
+		return v.ExitIfStatement(n)
+	case *InvalidExpression:
+		return v.ExitInvalidExpression(n)
+	case *LabelledStatement:
+		return v.ExitLabelledStatement(n)
+	case *LogicalExpression:
+		return v.ExitLogicalExpression(n)
+	case *MemberExpression:
+		return v.ExitMemberExpression(n)
+	case *MemberProperty:
+		return v.ExitMemberProperty(n)
+	case *MetaProperty:
+		return v.ExitMetaProperty(n)
+	case *MethodDefinition:
+		return v.ExitMethodDefinition(n)
+	case *NewExpression:
+		return v.ExitNewExpression(n)
+	case *NullLiteral:
+		return v.ExitNullLiteral(n)
+	case *NumberLiteral:
+		return v.ExitNumberLiteral(n)
+	case *ObjectLiteral:
+		return v.ExitObjectLiteral(n)
+	case *ObjectPattern:
+		return v.ExitObjectPattern(n)
+	case *Optional:
+		return v.ExitOptional(n)
+	case *OptionalChain:
+		return v.ExitOptionalChain(n)
+	case *ParameterList:
+		return v.ExitParameterList(n)
+	case *PrivateDotExpression:
+		return v.ExitPrivateDotExpression(n)
+	case *PrivateIdentifier:
+		return v.ExitPrivateIdentifier(n)
+	case *Program:
+		return v.ExitProgram(n)
+	case *Properties:
+		return v.ExitProperties(n)
+	case *Property:
+		return v.ExitProperty(n)
+	case *PropertyKeyed:
+		return v.ExitPropertyKeyed(n)
+	case *PropertyShort:
+		return v.ExitPropertyShort(n)
+	case *RegExpLiteral:
+		return v.ExitRegExpLiteral(n)
+	case *ReturnStatement:
+		return v.ExitReturnStatement(n)
+	case *SequenceExpression:
+		return v.ExitSequenceExpression(n)
+	case *SpreadElement:
+		return v.ExitSpreadElement(n)
+	case *Statement:
+		return v.ExitStatement(n)
+	case *Statements:
+		return v.ExitStatements(n)
+	case *StringLiteral:
+		return v.ExitStringLiteral(n)
+	case *SuperExpression:
+		return v.ExitSuperExpression(n)
+	case *SwitchStatement:
+		return v.ExitSwitchStatement(n)
+	case *TemplateElement:
+		return v.ExitTemplateElement(n)
+	case *TemplateElements:
+		return v.ExitTemplateElements(n)
+	case *TemplateLiteral:
+		return v.ExitTemplateLiteral(n)
+	case *ThisExpression:
+		return v.ExitThisExpression(n)
+	case *ThrowStatement:
+		return v.ExitThrowStatement(n)
+	case *TryStatement:
+		return v.ExitTryStatement(n)
+	case *UnaryExpression:
+		return v.ExitUnaryExpression(n)
+	case *UpdateExpression:
+		return v.ExitUpdateExpression(n)
+	case *VariableDeclaration:
+		return v.ExitVariableDeclaration(n)
+	case *VariableDeclarator:
+		return v.ExitVariableDeclarator(n)
+	case *VariableDeclarators:
+		return v.ExitVariableDeclarators(n)
+	case *WhileStatement:
+		return v.ExitWhileStatement(n)
+	case *WithStatement:
+		return v.ExitWithStatement(n)
+	case *YieldExpression:
+		return v.ExitYieldExpression(n)
+	}
+	return Continue
+}
+func (w *walker) VisitArrayLiteral(n *ArrayLiteral) {
+	w.walk(n)
+}
+func (w *walker) VisitArrayPattern(n *ArrayPattern) {
+	w.walk(n)
+}
+func (w *walker) VisitArrowFunctionLiteral(n *ArrowFunctionLiteral) {
+	w.walk(n)
+}
+func (w *walker) VisitAssignExpression(n *AssignExpression) {
+	w.walk(n)
+}
+func (w *walker) VisitAwaitExpression(n *AwaitExpression) {
+	w.walk(n)
+}
+func (w *walker) VisitBadStatement(n *BadStatement) {
+	w.walk(n)
+}
+func (w *walker) VisitBigIntLiteral(n *BigIntLiteral) {
+	w.walk(n)
+}
+func (w *walker) VisitBinaryExpression(n *BinaryExpression) {
+	w.walk(n)
+}
+func (w *walker) VisitBindingTarget(n *BindingTarget) {
+	w.walk(n)
+}
+func (w *walker) VisitBlockStatement(n *BlockStatement) {
+	w.walk(n)
+}
+func (w *walker) VisitBooleanLiteral(n *BooleanLiteral) {
+	w.walk(n)
+}
+func (w *walker) VisitBreakStatement(n *BreakStatement) {
+	w.walk(n)
+}
+func (w *walker) VisitCallExpression(n *CallExpression) {
+	w.walk(n)
+}
+func (w *walker) VisitCaseStatement(n *CaseStatement) {
+	w.walk(n)
+}
+func (w *walker) VisitCaseStatements(n *CaseStatements) {
+	w.walk(n)
+}
+func (w *walker) VisitCatchStatement(n *CatchStatement) {
+	w.walk(n)
+}
+func (w *walker) VisitClassDeclaration(n *ClassDeclaration) {
+	w.walk(n)
+}
+func (w *walker) VisitClassElement(n *ClassElement) {
+	w.walk(n)
+}
+func (w *walker) VisitClassElements(n *ClassElements) {
+	w.walk(n)
+}
+func (w *walker) VisitClassLiteral(n *ClassLiteral) {
+	w.walk(n)
+}
+func (w *walker) VisitClassStaticBlock(n *ClassStaticBlock) {
+	w.walk(n)
+}
+func (w *walker) VisitComputedProperty(n *ComputedProperty) {
+	w.walk(n)
+}
+func (w *walker) VisitConciseBody(n *ConciseBody) {
+	w.walk(n)
+}
+func (w *walker) VisitConditionalExpression(n *ConditionalExpression) {
+	w.walk(n)
+}
+func (w *walker) VisitContinueStatement(n *ContinueStatement) {
+	w.walk(n)
+}
+func (w *walker) VisitDebuggerStatement(n *DebuggerStatement) {
+	w.walk(n)
+}
+func (w *walker) VisitDoWhileStatement(n *DoWhileStatement) {
+	w.walk(n)
+}
+func (w *walker) VisitEmptyStatement(n *EmptyStatement) {
+	w.walk(n)
+}
+func (w *walker) VisitExpression(n *Expression) {
+	w.walk(n)
+}
+func (w *walker) VisitExpressionStatement(n *ExpressionStatement) {
+	w.walk(n)
+}
+func (w *walker) VisitExpressions(n *Expressions) {
+	w.walk(n)
+}
+func (w *walker) VisitFieldDefinition(n *FieldDefinition) {
+	w.walk(n)
+}
+func (w *walker) VisitForInStatement(n *ForInStatement) {
+	w.walk(n)
+}
+func (w *walker) VisitForInto(n *ForInto) {
+	w.walk(n)
+}
+func (w *walker) VisitForLoopInitializer(n *ForLoopInitializer) {
+	w.walk(n)
+}
+func (w *walker) VisitForOfStatement(n *ForOfStatement) {
+	w.walk(n)
+}
+func (w *walker) VisitForStatement(n *ForStatement) {
+	w.walk(n)
+}
+func (w *walker) VisitFunctionDeclaration(n *FunctionDeclaration) {
+	w.walk(n)
+}
+func (w *walker) VisitFunctionLiteral(n *FunctionLiteral) {
+	w.walk(n)
+}
+func (w *walker) VisitIdentifier(n *Identifier) {
+	w.walk(n)
+}
+func (w *walker) VisitIfStatement(n *IfStatement) {
+	w.walk(n)
+}
+func (w *walker) VisitInvalidExpression(n *InvalidExpression) {
+	w.walk(n)
+}
+func (w *walker) VisitLabelledStatement(n *LabelledStatement) {
+	w.walk(n)
+}
+func (w *walker) VisitLogicalExpression(n *LogicalExpression) {
+	w.walk(n)
+}
+func (w *walker) VisitMemberExpression(n *MemberExpression) {
+	w.walk(n)
+}
+func (w *walker) VisitMemberProperty(n *MemberProperty) {
+	w.walk(n)
+}
+func (w *walker) VisitMetaProperty(n *MetaProperty) {
+	w.walk(n)
+}
+func (w *walker) VisitMethodDefinition(n *MethodDefinition) {
+	w.walk(n)
+}
+func (w *walker) VisitNewExpression(n *NewExpression) {
+	w.walk(n)
+}
+func (w *walker) VisitNullLiteral(n *NullLiteral) {
+	w.walk(n)
+}
+func (w *walker) VisitNumberLiteral(n *NumberLiteral) {
+	w.walk(n)
+}
+func (w *walker) VisitObjectLiteral(n *ObjectLiteral) {
+	w.walk(n)
+}
+func (w *walker) VisitObjectPattern(n *ObjectPattern) {
+	w.walk(n)
+}
+func (w *walker) VisitOptional(n *Optional) {
+	w.walk(n)
+}
+func (w *walker) VisitOptionalChain(n *OptionalChain) {
+	w.walk(n)
+}
+func (w *walker) VisitParameterList(n *ParameterList) {
+	w.walk(n)
+}
+func (w *walker) VisitPrivateDotExpression(n *PrivateDotExpression) {
+	w.walk(n)
+}
+func (w *walker) VisitPrivateIdentifier(n *PrivateIdentifier) {
+	w.walk(n)
+}
+func (w *walker) VisitProgram(n *Program) {
+	w.walk(n)
+}
+func (w *walker) VisitProperties(n *Properties) {
+	w.walk(n)
+}
+func (w *walker) VisitProperty(n *Property) {
+	w.walk(n)
+}
+func (w *walker) VisitPropertyKeyed(n *PropertyKeyed) {
+	w.walk(n)
+}
+func (w *walker) VisitPropertyShort(n *PropertyShort) {
+	w.walk(n)
+}
+func (w *walker) VisitRegExpLiteral(n *RegExpLiteral) {
+	w.walk(n)
+}
+func (w *walker) VisitReturnStatement(n *ReturnStatement) {
+	w.walk(n)
+}
+func (w *walker) VisitSequenceExpression(n *SequenceExpression) {
+	w.walk(n)
+}
+func (w *walker) VisitSpreadElement(n *SpreadElement) {
+	w.walk(n)
+}
+func (w *walker) VisitStatement(n *Statement) {
+	w.walk(n)
+}
+func (w *walker) VisitStatements(n *Statements) {
+	w.walk(n)
+}
+func (w *walker) VisitStringLiteral(n *StringLiteral) {
+	w.walk(n)
+}
+func (w *walker) VisitSuperExpression(n *SuperExpression) {
+	w.walk(n)
+}
+func (w *walker) VisitSwitchStatement(n *SwitchStatement) {
+	w.walk(n)
+}
+func (w *walker) VisitTemplateElement(n *TemplateElement) {
+	w.walk(n)
+}
+func (w *walker) VisitTemplateElements(n *TemplateElements) {
+	w.walk(n)
+}
+func (w *walker) VisitTemplateLiteral(n *TemplateLiteral) {
+	w.walk(n)
+}
+func (w *walker) VisitThisExpression(n *ThisExpression) {
+	w.walk(n)
+}
+func (w *walker) VisitThrowStatement(n *ThrowStatement) {
+	w.walk(n)
+}
+func (w *walker) VisitTryStatement(n *TryStatement) {
+	w.walk(n)
+}
+func (w *walker) VisitUnaryExpression(n *UnaryExpression) {
+	w.walk(n)
+}
+func (w *walker) VisitUpdateExpression(n *UpdateExpression) {
+	w.walk(n)
+}
+func (w *walker) VisitVariableDeclaration(n *VariableDeclaration) {
+	w.walk(n)
+}
+func (w *walker) VisitVariableDeclarator(n *VariableDeclarator) {
+	w.walk(n)
+}
+func (w *walker) VisitVariableDeclarators(n *VariableDeclarators) {
+	w.walk(n)
+}
+func (w *walker) VisitWhileStatement(n *WhileStatement) {
+	w.walk(n)
+}
+func (w *walker) VisitWithStatement(n *WithStatement) {
+	w.walk(n)
+}
+func (w *walker) VisitYieldExpression(n *YieldExpression) {
+	w.walk(n)
+}
+
+// Folder rewrites a tree by returning the replacement of every node held
+// through an interface, such as the Expr of an Expression or the Stmt of a
+// Statement. Hooks receive the node before its children are folded; call
+// FoldChildrenWith to fold them, as NoopFolder does. Nodes held with a
+// concrete type, such as the Name of a FunctionDeclaration, cannot be
+// replaced and only have their children folded.
+//
+// FoldStmt may return any number of statements. In a statement list they
+// take the place of the statement; elsewhere, as in the Consequent of an
+// IfStatement, none become an EmptyStatement and several a BlockStatement.
+type Folder interface {
+	FoldBody(n Body) Body
+	FoldElement(n Element) Element
+	FoldExpr(n Expr) Expr
+	FoldForLoopInit(n ForLoopInit) ForLoopInit
+	FoldInto(n Into) Into
+	FoldMemberProp(n MemberProp) MemberProp
+	FoldProp(n Prop) Prop
+	FoldStmt(n Stmt) []Stmt
+	FoldTarget(n Target) Target
+}
+type NoopFolder struct {
+	F Folder
+}
+
+func (nf *NoopFolder) FoldBody(n Body) Body {
+	n.FoldChildrenWith(nf.F)
+	return n
+}
+func (nf *NoopFolder) FoldElement(n Element) Element {
+	n.FoldChildrenWith(nf.F)
+	return n
+}
+func (nf *NoopFolder) FoldExpr(n Expr) Expr {
+	n.FoldChildrenWith(nf.F)
+	return n
+}
+func (nf *NoopFolder) FoldForLoopInit(n ForLoopInit) ForLoopInit {
+	n.FoldChildrenWith(nf.F)
+	return n
+}
+func (nf *NoopFolder) FoldInto(n Into) Into {
+	n.FoldChildrenWith(nf.F)
+	return n
+}
+func (nf *NoopFolder) FoldMemberProp(n MemberProp) MemberProp {
+	n.FoldChildrenWith(nf.F)
+	return n
+}
+func (nf *NoopFolder) FoldProp(n Prop) Prop {
+	n.FoldChildrenWith(nf.F)
+	return n
+}
+func (nf *NoopFolder) FoldStmt(n Stmt) []Stmt {
+	n.FoldChildrenWith(nf.F)
+	return []Stmt{n}
+}
+func (nf *NoopFolder) FoldTarget(n Target) Target {
+	n.FoldChildrenWith(nf.F)
+	return n
+}
+func (n *ArrayLiteral) FoldChildrenWith(f Folder) {
+	n.Value.FoldChildrenWith(f)
+}
+func (n *ArrayPattern) FoldChildrenWith(f Folder) {
+	n.Elements.FoldChildrenWith(f)
+	n.Rest.FoldChildrenWith(f)
+}
+func (n *ArrowFunctionLiteral) FoldChildrenWith(f Folder) {
+	n.ParameterList.FoldChildrenWith(f)
+	n.Body.FoldChildrenWith(f)
+}
+func (n *AssignExpression) FoldChildrenWith(f Folder) {
+	n.Left.FoldChildrenWith(f)
+	n.Right.FoldChildrenWith(f)
+}
+func (n *AwaitExpression) FoldChildrenWith(f Folder) {
+	n.Argument.FoldChildrenWith(f)
+}
+func (n *BadStatement) FoldChildrenWith(f Folder) {
+}
+func (n *BigIntLiteral) FoldChildrenWith(f Folder) {
+}
+func (n *BinaryExpression) FoldChildrenWith(f Folder) {
+	n.Left.FoldChildrenWith(f)
+	n.Right.FoldChildrenWith(f)
+}
+func (n *BindingTarget) FoldChildrenWith(f Folder) {
+	n.Target = f.FoldTarget(n.Target)
+}
+func (n *BlockStatement) FoldChildrenWith(f Folder) {
+	n.List.FoldChildrenWith(f)
+}
+func (n *BooleanLiteral) FoldChildrenWith(f Folder) {
+}
+func (n *BreakStatement) FoldChildrenWith(f Folder) {
+	if n.Label != nil {
+		n.Label.FoldChildrenWith(f)
+	}
+}
+func (n *CallExpression) FoldChildrenWith(f Folder) {
+	n.Callee.FoldChildrenWith(f)
+	n.ArgumentList.FoldChildrenWith(f)
+}
+func (n *CaseStatement) FoldChildrenWith(f Folder) {
+	if n.Test != nil {
+		n.Test.FoldChildrenWith(f)
+	}
+	n.Consequent.FoldChildrenWith(f)
+}
+func (n *CaseStatements) FoldChildrenWith(f Folder) {
+	for i := range *n {
+		(*n)[i].FoldChildrenWith(f)
+	}
+}
+func (n *CatchStatement) FoldChildrenWith(f Folder) {
+	if n.Parameter != nil {
+		n.Parameter.FoldChildrenWith(f)
+	}
+	n.Body.FoldChildrenWith(f)
+}
+func (n *ClassDeclaration) FoldChildrenWith(f Folder) {
+	n.Class.FoldChildrenWith(f)
+}
+func (n *ClassElement) FoldChildrenWith(f Folder) {
+	n.Element = f.FoldElement(n.Element)
+}
+func (n *ClassElements) FoldChildrenWith(f Folder) {
+	for i := range *n {
+		(*n)[i].FoldChildrenWith(f)
+	}
+}
+func (n *ClassLiteral) FoldChildrenWith(f Folder) {
+	if n.Name != nil {
+		n.Name.FoldChildrenWith(f)
+	}
+	if n.SuperClass != nil {
+		n.SuperClass.FoldChildrenWith(f)
+	}
+	n.Body.FoldChildrenWith(f)
+}
+func (n *ClassStaticBlock) FoldChildrenWith(f Folder) {
+	n.Block.FoldChildrenWith(f)
+}
+func (n *ComputedProperty) FoldChildrenWith(f Folder) {
+	n.Expr.FoldChildrenWith(f)
+}
+func (n *ConciseBody) FoldChildrenWith(f Folder) {
+	n.Body = f.FoldBody(n.Body)
+}
+func (n *ConditionalExpression) FoldChildrenWith(f Folder) {
+	n.Test.FoldChildrenWith(f)
+	n.Consequent.FoldChildrenWith(f)
+	n.Alternate.FoldChildrenWith(f)
+}
+func (n *ContinueStatement) FoldChildrenWith(f Folder) {
+	if n.Label != nil {
+		n.Label.FoldChildrenWith(f)
+	}
+}
+func (n *DebuggerStatement) FoldChildrenWith(f Folder) {
+}
+func (n *DoWhileStatement) FoldChildrenWith(f Folder) {
+	n.Test.FoldChildrenWith(f)
+	n.Body.FoldChildrenWith(f)
+}
+func (n *EmptyStatement) FoldChildrenWith(f Folder) {
+}
+func (n *Expression) FoldChildrenWith(f Folder) {
+	if n.Expr != nil {
+		n.Expr = f.FoldExpr(n.Expr)
+	}
+}
+func (n *ExpressionStatement) FoldChildrenWith(f Folder) {
+	n.Expression.FoldChildrenWith(f)
+}
+func (n *Expressions) FoldChildrenWith(f Folder) {
+	for i := range *n {
+		(*n)[i].FoldChildrenWith(f)
+	}
+}
+func (n *FieldDefinition) FoldChildrenWith(f Folder) {
+	n.Key.FoldChildrenWith(f)
+	if n.Initializer != nil {
+		n.Initializer.FoldChildrenWith(f)
+	}
+}
+func (n *ForInStatement) FoldChildrenWith(f Folder) {
+	n.Into.FoldChildrenWith(f)
+	n.Source.FoldChildrenWith(f)
+	n.Body.FoldChildrenWith(f)
+}
+func (n *ForInto) FoldChildrenWith(f Folder) {
+	n.Into = f.FoldInto(n.Into)
+}
+func (n *ForLoopInitializer) FoldChildrenWith(f Folder) {
+	n.Initializer = f.FoldForLoopInit(n.Initializer)
+}
+func (n *ForOfStatement) FoldChildrenWith(f Folder) {
+	n.Into.FoldChildrenWith(f)
+	n.Source.FoldChildrenWith(f)
+	n.Body.FoldChildrenWith(f)
+}
+func (n *ForStatement) FoldChildrenWith(f Folder) {
+	if n.Initializer != nil {
+		n.Initializer.FoldChildrenWith(f)
+	}
+	n.Update.FoldChildrenWith(f)
+	n.Test.FoldChildrenWith(f)
+	n.Body.FoldChildrenWith(f)
+}
+func (n *FunctionDeclaration) FoldChildrenWith(f Folder) {
+	n.Function.FoldChildrenWith(f)
+}
+func (n *FunctionLiteral) FoldChildrenWith(f Folder) {
+	if n.Name != nil {
+		n.Name.FoldChildrenWith(f)
+	}
+	n.ParameterList.FoldChildrenWith(f)
+	n.Body.FoldChildrenWith(f)
+}
+func (n *Identifier) FoldChildrenWith(f Folder) {
+}
+func (n *IfStatement) FoldChildrenWith(f Folder) {
+	n.Test.FoldChildrenWith(f)
+	n.Consequent.FoldChildrenWith(f)
+	if n.Alternate != nil {
+		n.Alternate.FoldChildrenWith(f)
+	}
+}
+func (n *InvalidExpression) FoldChildrenWith(f Folder) {
+}
+func (n *LabelledStatement) FoldChildrenWith(f Folder) {
+	n.Label.FoldChildrenWith(f)
+	n.Statement.FoldChildrenWith(f)
+}
+func (n *LogicalExpression) FoldChildrenWith(f Folder) {
+	n.Left.FoldChildrenWith(f)
+	n.Right.FoldChildrenWith(f)
+}
+func (n *MemberExpression) FoldChildrenWith(f Folder) {
+	n.Object.FoldChildrenWith(f)
+	n.Property.FoldChildrenWith(f)
+}
+func (n *MemberProperty) FoldChildrenWith(f Folder) {
+	n.Prop = f.FoldMemberProp(n.Prop)
+}
+func (n *MetaProperty) FoldChildrenWith(f Folder) {
+	n.Meta.FoldChildrenWith(f)
+	n.Property.FoldChildrenWith(f)
+}
+func (n *MethodDefinition) FoldChildrenWith(f Folder) {
+	n.Key.FoldChildrenWith(f)
+	n.Body.FoldChildrenWith(f)
+}
+func (n *NewExpression) FoldChildrenWith(f Folder) {
+	n.Callee.FoldChildrenWith(f)
+	n.ArgumentList.FoldChildrenWith(f)
+}
+func (n *NullLiteral) FoldChildrenWith(f Folder) {
+}
+func (n *NumberLiteral) FoldChildrenWith(f Folder) {
+}
+func (n *ObjectLiteral) FoldChildrenWith(f Folder) {
+	n.Value.FoldChildrenWith(f)
+}
+func (n *ObjectPattern) FoldChildrenWith(f Folder) {
+	n.Properties.FoldChildrenWith(f)
+	if n.Rest != nil {
+		n.Rest = f.FoldExpr(n.Rest)
+	}
+}
+func (n *Optional) FoldChildrenWith(f Folder) {
+	n.Expr.FoldChildrenWith(f)
+}
+func (n *OptionalChain) FoldChildrenWith(f Folder) {
+	n.Base.FoldChildrenWith(f)
+}
+func (n *ParameterList) FoldChildrenWith(f Folder) {
+	n.List.FoldChildrenWith(f)
+	if n.Rest != nil {
+		n.Rest = f.FoldExpr(n.Rest)
+	}
+}
+func (n *PrivateDotExpression) FoldChildrenWith(f Folder) {
+	n.Left.FoldChildrenWith(f)
+	n.Identifier.FoldChildrenWith(f)
+}
+func (n *PrivateIdentifier) FoldChildrenWith(f Folder) {
+	n.Identifier.FoldChildrenWith(f)
+}
+func (n *Program) FoldChildrenWith(f Folder) {
+	n.Body.FoldChildrenWith(f)
+}
+func (n *Properties) FoldChildrenWith(f Folder) {
+	for i := range *n {
+		(*n)[i].FoldChildrenWith(f)
+	}
+}
+func (n *Property) FoldChildrenWith(f Folder) {
+	n.Prop = f.FoldProp(n.Prop)
+}
+func (n *PropertyKeyed) FoldChildrenWith(f Folder) {
+	n.Key.FoldChildrenWith(f)
+	n.Value.FoldChildrenWith(f)
+}
+func (n *PropertyShort) FoldChildrenWith(f Folder) {
+	n.Name.FoldChildrenWith(f)
+	n.Initializer.FoldChildrenWith(f)
+}
+func (n *RegExpLiteral) FoldChildrenWith(f Folder) {
+}
+func (n *ReturnStatement) FoldChildrenWith(f Folder) {
+	if n.Argument != nil {
+		n.Argument.FoldChildrenWith(f)
+	}
+}
+func (n *SequenceExpression) FoldChildrenWith(f Folder) {
+	n.Sequence.FoldChildrenWith(f)
+}
+func (n *SpreadElement) FoldChildrenWith(f Folder) {
+	n.Expression.FoldChildrenWith(f)
+}
+func (n *Statement) FoldChildrenWith(f Folder) {
+	if n.Stmt != nil {
+		n.Stmt = foldStmt(f, n.Stmt)
+	}
+}
+func (n *Statements) FoldChildrenWith(f Folder) {
+	foldStatements(f, n)
+}
+func (n *StringLiteral) FoldChildrenWith(f Folder) {
+}
+func (n *SuperExpression) FoldChildrenWith(f Folder) {
+}
+func (n *SwitchStatement) FoldChildrenWith(f Folder) {
+	n.Discriminant.FoldChildrenWith(f)
+	n.Body.FoldChildrenWith(f)
+}
+func (n *TemplateElement) FoldChildrenWith(f Folder) {
+}
+func (n *TemplateElements) FoldChildrenWith(f Folder) {
+	for i := range *n {
+		(*n)[i].FoldChildrenWith(f)
+	}
+}
+func (n *TemplateLiteral) FoldChildrenWith(f Folder) {
+	if n.Tag != nil {
+		n.Tag.FoldChildrenWith(f)
+	}
+	n.Elements.FoldChildrenWith(f)
+	n.Expressions.FoldChildrenWith(f)
+}
+func (n *ThisExpression) FoldChildrenWith(f Folder) {
+}
+func (n *ThrowStatement) FoldChildrenWith(f Folder) {
+	n.Argument.FoldChildrenWith(f)
+}
+func (n *TryStatement) FoldChildrenWith(f Folder) {
+	n.Body.FoldChildrenWith(f)
+	if n.Catch != nil {
+		n.Catch.FoldChildrenWith(f)
+	}
+	if n.Finally != nil {
+		n.Finally.FoldChildrenWith(f)
+	}
+}
+func (n *UnaryExpression) FoldChildrenWith(f Folder) {
+	n.Operand.FoldChildrenWith(f)
+}
+func (n *UpdateExpression) FoldChildrenWith(f Folder) {
+	n.Operand.FoldChildrenWith(f)
+}
+func (n *VariableDeclaration) FoldChildrenWith(f Folder) {
+	n.List.FoldChildrenWith(f)
+}
+func (n *VariableDeclarator) FoldChildrenWith(f Folder) {
+	n.Target.FoldChildrenWith(f)
+	if n.Initializer != nil {
+		n.Initializer.FoldChildrenWith(f)
+	}
+}
+func (n *VariableDeclarators) FoldChildrenWith(f Folder) {
+	for i := range *n {
+		(*n)[i].FoldChildrenWith(f)
+	}
+}
+func (n *WhileStatement) FoldChildrenWith(f Folder) {
+	n.Test.FoldChildrenWith(f)
+	n.Body.FoldChildrenWith(f)
+}
+func (n *WithStatement) FoldChildrenWith(f Folder) {
+	n.Object.FoldChildrenWith(f)
+	n.Body.FoldChildrenWith(f)
+}
+func (n *YieldExpression) FoldChildrenWith(f Folder) {
+	n.Argument.FoldChildrenWith(f)
+}
@@ -0,0 +1,85 @@
+package ast
+
+import "iter"
+
+// Action tells Walk how to go on after an enter or exit hook.
+type Action uint8
+
+const (
+	// Continue walks on as usual.
+	Continue Action = iota
+	// SkipChildren, returned by an enter hook, skips the children of the
+	// node and its exit hook. Returned by an exit hook it is the same as
+	// Continue.
+	SkipChildren
+	// Stop ends the walk; no further hooks are called.
+	Stop
+)
+
+// Walk walks the tree rooted at n depth first. For every node, wrappers such
+// as Expression and lists such as Statements included, it calls the
+// matching enter hook of v, walks the children and then calls the exit
+// hook. Embed NoopEnterExitVisitor to implement only the hooks needed.
+func Walk(n VisitableNode, v EnterExitVisitor) {
+	w := &walker{
+		enter: func(n VisitableNode) Action { return enterNode(v, n) },
+		exit:  func(n VisitableNode) Action { return exitNode(v, n) },
+	}
+	n.VisitWith(w)
+}
+
+// Inspect walks the tree rooted at n depth first, calling f for every node.
+// If f returns true, Inspect walks the children of the node and then calls
+// f(nil).
+func Inspect(n VisitableNode, f func(VisitableNode) bool) {
+	w := &walker{
+		enter: func(n VisitableNode) Action {
+			if f(n) {
+				return Continue
+			}
+			return SkipChildren
+		},
+		exit: func(VisitableNode) Action {
+			f(nil)
+			return Continue
+		},
+	}
+	n.VisitWith(w)
+}
+
+// Preorder yields the nodes of the tree rooted at n depth first, parents
+// before their children.
+func Preorder(n VisitableNode) iter.Seq[VisitableNode] {
+	return func(yield func(VisitableNode) bool) {
+		w := &walker{enter: func(n VisitableNode) Action {
+			if yield(n) {
+				return Continue
+			}
+			return Stop
+		}}
+		n.VisitWith(w)
+	}
+}
+
+// walker is the Visitor behind Walk, Inspect and Preorder. exit may be nil.
+type walker struct {
+	enter, exit func(n VisitableNode) Action
+	stopped     bool
+}
+
+func (w *walker) walk(n VisitableNode) {
+	if w.stopped {
+		return
+	}
+	switch w.enter(n) {
+	case Stop:
+		w.stopped = true
+		return
+	case SkipChildren:
+		return
+	}
+	n.VisitChildrenWith(w)
+	if w.exit != nil && !w.stopped && w.exit(n) == Stop {
+		w.stopped = true
+	}
+}
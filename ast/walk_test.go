@@ -0,0 +1,122 @@
+package ast_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/t14raptor/go-fast/ast"
+)
+
+// callRecorder records the calls it gets, and skips functions.
+type callRecorder struct {
+	ast.NoopEnterExitVisitor
+	calls []string
+	// stopAt stops the walk on entering the identifier with this name.
+	stopAt string
+}
+
+func (r *callRecorder) EnterIdentifier(n *ast.Identifier) ast.Action {
+	r.calls = append(r.calls, "enter "+n.Name)
+	if n.Name == r.stopAt {
+		return ast.Stop
+	}
+	return ast.Continue
+}
+
+func (r *callRecorder) ExitIdentifier(n *ast.Identifier) ast.Action {
+	r.calls = append(r.calls, "exit "+n.Name)
+	return ast.Continue
+}
+
+func (r *callRecorder) EnterFunctionLiteral(n *ast.FunctionLiteral) ast.Action {
+	r.calls = append(r.calls, "skip function")
+	return ast.SkipChildren
+}
+
+func (r *callRecorder) ExitFunctionLiteral(n *ast.FunctionLiteral) ast.Action {
+	r.calls = append(r.calls, "exit function")
+	return ast.Continue
+}
+
+func (r *callRecorder) ExitBinaryExpression(n *ast.BinaryExpression) ast.Action {
+	r.calls = append(r.calls, "exit binary")
+	return ast.Continue
+}
+
+func TestWalk(t *testing.T) {
+	tests := []struct {
+		src    string
+		stopAt string
+		want   []string
+	}{
+		{
+			src:  "a + b; (function () { c; }); d;",
+			want: []string{"enter a", "exit a", "enter b", "exit b", "exit binary", "skip function", "enter d", "exit d"},
+		},
+		{
+			src:    "a + b; c;",
+			stopAt: "b",
+			want:   []string{"enter a", "exit a", "enter b"},
+		},
+	}
+	for _, tt := range tests {
+		r := &callRecorder{stopAt: tt.stopAt}
+		ast.Walk(parse(t, tt.src), r)
+		if !slices.Equal(r.calls, tt.want) {
+			t.Errorf("%q: calls = %v; want %v", tt.src, r.calls, tt.want)
+		}
+	}
+}
+
+func TestInspect(t *testing.T) {
+	var names []string
+	open := 0
+	ast.Inspect(parse(t, "a; { b; }"), func(n ast.VisitableNode) bool {
+		switch n := n.(type) {
+		case nil:
+			open--
+			return true
+		case *ast.BlockStatement:
+			return false
+		case *ast.Identifier:
+			names = append(names, n.Name)
+		}
+		open++
+		return true
+	})
+	if want := []string{"a"}; !slices.Equal(names, want) {
+		t.Errorf("names = %v; want %v", names, want)
+	}
+	if open != 0 {
+		t.Errorf("%d nodes were not closed by f(nil)", open)
+	}
+}
+
+func TestPreorder(t *testing.T) {
+	var got []string
+	for n := range ast.Preorder(parse(t, "f(a, b, c);")) {
+		id, ok := n.(*ast.Identifier)
+		if !ok {
+			continue
+		}
+		if got = append(got, id.Name); id.Name == "b" {
+			break
+		}
+	}
+	if want := []string{"f", "a", "b"}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+
+	var types []string
+	for n := range ast.Preorder(parse(t, "x;")) {
+		types = append(types, fmt.Sprintf("%T", n))
+	}
+	want := []string{
+		"*ast.Program", "*ast.Statements", "*ast.Statement", "*ast.ExpressionStatement",
+		"*ast.Expression", "*ast.Identifier",
+	}
+	if !slices.Equal(types, want) {
+		t.Errorf("types = %v; want %v", types, want)
+	}
+}
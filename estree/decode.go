@@ -0,0 +1,913 @@
+package estree
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/parser/scanner/token"
+)
+
+// Decode builds a program from ESTree JSON, as produced by Marshal, Acorn,
+// Espree or Babel with its estree plugin. A Babel "File" is accepted in place
+// of the Program it wraps. The mapping is the inverse of Marshal's.
+//
+// Positions are read from "start" and "end", or from "range", and are byte
+// offsets unless opts.Source is set. The program is unresolved: every
+// ScopeContext is zero until it is run through the resolver.
+//
+// Node types with no go-fAST equivalent, such as modules and JSX, make
+// Decode fail with an error wrapping ErrUnsupported. So that a decoded
+// program can be printed, walked and resolved, Decode also fails when the
+// tree breaks one of the invariants checked by ast.Validate.
+func Decode(data []byte, opts Options) (*ast.Program, error) {
+	var root map[string]any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("estree: %w", err)
+	}
+	d := &decoder{}
+	if opts.Source != "" {
+		d.pos = newPositions(opts.Source)
+	}
+	program := d.program(root)
+	if d.err != nil {
+		return nil, d.err
+	}
+	if problems := ast.Validate(program); problems != nil {
+		return nil, fmt.Errorf("estree: invalid tree: %v", problems[0])
+	}
+	return program, nil
+}
+
+// decoder builds go-fAST nodes from decoded JSON. Like encoder it keeps the
+// first error and yields nil for nodes it cannot convert.
+type decoder struct {
+	pos *positions
+	err error
+}
+
+func (d *decoder) fail(n map[string]any, format string, args ...any) {
+	if d.err == nil {
+		d.err = fmt.Errorf("estree: %s at offset %d: %s", d.typ(n), d.start(n), fmt.Sprintf(format, args...))
+	}
+}
+
+func (d *decoder) unsupported(n map[string]any) {
+	if d.err == nil {
+		d.err = fmt.Errorf("estree: %w: type %q at offset %d", ErrUnsupported, d.typ(n), d.start(n))
+	}
+}
+
+func (d *decoder) typ(n map[string]any) string {
+	s, _ := n["type"].(string)
+	return s
+}
+
+// node returns the member key of n as a node, or nil if it is null or
+// missing.
+func (d *decoder) node(n map[string]any, key string) map[string]any {
+	m, _ := n[key].(map[string]any)
+	return m
+}
+
+// required is like node but fails if the member is not a node.
+func (d *decoder) required(n map[string]any, key string) map[string]any {
+	m, ok := n[key].(map[string]any)
+	if !ok {
+		d.fail(n, "missing %q", key)
+	}
+	return m
+}
+
+func (d *decoder) list(n map[string]any, key string) []any {
+	l, ok := n[key].([]any)
+	if !ok && n[key] != nil {
+		d.fail(n, "%q is not an array", key)
+	}
+	return l
+}
+
+func (d *decoder) str(n map[string]any, key string) string {
+	s, ok := n[key].(string)
+	if !ok {
+		d.fail(n, "missing %q", key)
+	}
+	return s
+}
+
+func (d *decoder) bool(n map[string]any, key string) bool {
+	b, _ := n[key].(bool)
+	return b
+}
+
+func (d *decoder) offset(v any) ast.Idx {
+	f, _ := v.(float64)
+	if f < 0 {
+		return 0
+	}
+	if d.pos != nil {
+		return d.pos.index(int(f))
+	}
+	return ast.Idx(f)
+}
+
+func (d *decoder) start(n map[string]any) ast.Idx {
+	if v, ok := n["start"]; ok {
+		return d.offset(v)
+	}
+	if r, ok := n["range"].([]any); ok && len(r) == 2 {
+		return d.offset(r[0])
+	}
+	return 0
+}
+
+func (d *decoder) end(n map[string]any) ast.Idx {
+	if v, ok := n["end"]; ok {
+		return d.offset(v)
+	}
+	if r, ok := n["range"].([]any); ok && len(r) == 2 {
+		return d.offset(r[1])
+	}
+	return 0
+}
+
+// last returns the offset of the final character of n, such as its closing
+// brace.
+func (d *decoder) last(n map[string]any) ast.Idx {
+	if end := d.end(n); end > 0 {
+		return end - 1
+	}
+	return 0
+}
+
+// colon returns the offset of the colon of the SwitchCase n. ESTree does not
+// record it, so a case with statements gets the end of its test, or of its
+// default keyword, instead.
+func (d *decoder) colon(n map[string]any) ast.Idx {
+	if len(d.list(n, "consequent")) == 0 {
+		return d.last(n)
+	}
+	if test := d.node(n, "test"); test != nil {
+		return d.end(test)
+	}
+	return d.start(n) + ast.Idx(len("default"))
+}
+
+func (d *decoder) program(n map[string]any) *ast.Program {
+	switch d.typ(n) {
+	case "File":
+		return d.program(d.required(n, "program"))
+	case "Program":
+		return &ast.Program{Body: d.statements(d.list(n, "body"))}
+	}
+	d.fail(n, "not a Program")
+	return nil
+}
+
+func (d *decoder) statements(list []any) ast.Statements {
+	out := make(ast.Statements, len(list))
+	for i, v := range list {
+		s, _ := v.(map[string]any)
+		out[i].Stmt = d.stmt(s)
+	}
+	return out
+}
+
+func (d *decoder) block(n map[string]any) *ast.BlockStatement {
+	if n == nil {
+		return nil
+	}
+	if d.typ(n) != "BlockStatement" {
+		d.fail(n, "not a BlockStatement")
+		return nil
+	}
+	return &ast.BlockStatement{
+		List:       d.statements(d.list(n, "body")),
+		LeftBrace:  d.start(n),
+		RightBrace: d.last(n),
+	}
+}
+
+// statement returns the member key of n as a statement wrapper.
+func (d *decoder) statement(n map[string]any, key string) *ast.Statement {
+	return &ast.Statement{Stmt: d.stmt(d.required(n, key))}
+}
+
+func (d *decoder) stmt(n map[string]any) ast.Stmt {
+	if n == nil {
+		return nil
+	}
+	switch d.typ(n) {
+	case "ExpressionStatement":
+		return &ast.ExpressionStatement{Expression: d.expression(n, "expression")}
+	case "BlockStatement":
+		return d.block(n)
+	case "EmptyStatement":
+		return &ast.EmptyStatement{Semicolon: d.start(n)}
+	case "DebuggerStatement":
+		return &ast.DebuggerStatement{Debugger: d.start(n)}
+	case "VariableDeclaration":
+		return d.variableDeclaration(n)
+	case "FunctionDeclaration":
+		return &ast.FunctionDeclaration{Function: d.function(n)}
+	case "ClassDeclaration":
+		return &ast.ClassDeclaration{Class: d.class(n)}
+	case "ReturnStatement":
+		return &ast.ReturnStatement{Argument: d.optExpression(n, "argument"), Return: d.start(n)}
+	case "ThrowStatement":
+		return &ast.ThrowStatement{Argument: d.expression(n, "argument"), Throw: d.start(n)}
+	case "BreakStatement":
+		return &ast.BreakStatement{Label: d.optIdent(d.node(n, "label")), Idx: d.start(n)}
+	case "ContinueStatement":
+		return &ast.ContinueStatement{Label: d.optIdent(d.node(n, "label")), Idx: d.start(n)}
+	case "LabeledStatement":
+		label := d.required(n, "label")
+		return &ast.LabelledStatement{
+			Label:     d.ident(label),
+			Statement: d.statement(n, "body"),
+			Colon:     d.end(label),
+		}
+	case "IfStatement":
+		var alternate *ast.Statement
+		if n["alternate"] != nil {
+			alternate = d.statement(n, "alternate")
+		}
+		return &ast.IfStatement{
+			Test:       d.expression(n, "test"),
+			Consequent: d.statement(n, "consequent"),
+			Alternate:  alternate,
+			If:         d.start(n),
+		}
+	case "WithStatement":
+		return &ast.WithStatement{
+			Object: d.expression(n, "object"),
+			Body:   d.statement(n, "body"),
+			With:   d.start(n),
+		}
+	case "WhileStatement":
+		return &ast.WhileStatement{
+			Test:  d.expression(n, "test"),
+			Body:  d.statement(n, "body"),
+			While: d.start(n),
+		}
+	case "DoWhileStatement":
+		return &ast.DoWhileStatement{
+			Test:             d.expression(n, "test"),
+			Body:             d.statement(n, "body"),
+			Do:               d.start(n),
+			RightParenthesis: d.last(n),
+		}
+	case "ForStatement":
+		var init *ast.ForLoopInitializer
+		if i := d.node(n, "init"); i != nil {
+			if d.typ(i) == "VariableDeclaration" {
+				init = &ast.ForLoopInitializer{Initializer: d.variableDeclaration(i)}
+			} else {
+				init = &ast.ForLoopInitializer{Initializer: &ast.Expression{Expr: d.expr(i)}}
+			}
+		}
+		return &ast.ForStatement{
+			Initializer: init,
+			Test:        &ast.Expression{Expr: d.expr(d.node(n, "test"))},
+			Update:      &ast.Expression{Expr: d.expr(d.node(n, "update"))},
+			Body:        d.statement(n, "body"),
+			For:         d.start(n),
+		}
+	case "ForInStatement":
+		return &ast.ForInStatement{
+			Into:   d.forInto(d.required(n, "left")),
+			Source: d.expression(n, "right"),
+			Body:   d.statement(n, "body"),
+			For:    d.start(n),
+		}
+	case "ForOfStatement":
+		return &ast.ForOfStatement{
+			Into:   d.forInto(d.required(n, "left")),
+			Source: d.expression(n, "right"),
+			Body:   d.statement(n, "body"),
+			For:    d.start(n),
+			Await:  d.bool(n, "await"),
+		}
+	case "SwitchStatement":
+		s := &ast.SwitchStatement{
+			Discriminant: d.expression(n, "discriminant"),
+			Default:      -1,
+			Switch:       d.start(n),
+			RightBrace:   d.last(n),
+		}
+		for i, v := range d.list(n, "cases") {
+			c, _ := v.(map[string]any)
+			if c == nil || d.typ(c) != "SwitchCase" {
+				d.fail(n, "case %d is not a SwitchCase", i)
+				continue
+			}
+			if c["test"] == nil {
+				s.Default = i
+			}
+			s.Body = append(s.Body, ast.CaseStatement{
+				Test:       d.optExpression(c, "test"),
+				Consequent: d.statements(d.list(c, "consequent")),
+				Case:       d.start(c),
+				Colon:      d.colon(c),
+			})
+		}
+		return s
+	case "TryStatement":
+		t := &ast.TryStatement{
+			Body:    d.block(d.required(n, "block")),
+			Finally: d.block(d.node(n, "finalizer")),
+			Try:     d.start(n),
+		}
+		if h := d.node(n, "handler"); h != nil {
+			var param *ast.BindingTarget
+			if p := d.node(h, "param"); p != nil {
+				param = d.binding(p)
+			}
+			t.Catch = &ast.CatchStatement{
+				Parameter: param,
+				Body:      d.block(d.required(h, "body")),
+				Catch:     d.start(h),
+			}
+		}
+		return t
+	}
+	d.unsupported(n)
+	return nil
+}
+
+func (d *decoder) variableDeclaration(n map[string]any) *ast.VariableDeclaration {
+	var kind token.Token
+	switch k := d.str(n, "kind"); k {
+	case "var":
+		kind = token.Var
+	case "let":
+		kind = token.Let
+	case "const":
+		kind = token.Const
+	default:
+		d.fail(n, "unsupported declaration kind %q", k)
+	}
+	decl := &ast.VariableDeclaration{Idx: d.start(n), Token: kind}
+	for _, v := range d.list(n, "declarations") {
+		dn, _ := v.(map[string]any)
+		if dn == nil || d.typ(dn) != "VariableDeclarator" {
+			d.fail(n, "declaration is not a VariableDeclarator")
+			continue
+		}
+		decl.List = append(decl.List, ast.VariableDeclarator{
+			Target:      d.binding(d.required(dn, "id")),
+			Initializer: d.optExpression(dn, "init"),
+		})
+	}
+	return decl
+}
+
+func (d *decoder) forInto(n map[string]any) *ast.ForInto {
+	if d.typ(n) == "VariableDeclaration" {
+		return &ast.ForInto{Into: d.variableDeclaration(n)}
+	}
+	return &ast.ForInto{Into: &ast.Expression{Expr: d.pattern(n)}}
+}
+
+func (d *decoder) ident(n map[string]any) *ast.Identifier {
+	if n == nil {
+		return nil
+	}
+	if d.typ(n) != "Identifier" {
+		d.fail(n, "not an Identifier")
+		return nil
+	}
+	return &ast.Identifier{Name: d.str(n, "name"), Idx: d.start(n)}
+}
+
+func (d *decoder) optIdent(n map[string]any) *ast.Identifier {
+	if n == nil {
+		return nil
+	}
+	return d.ident(n)
+}
+
+// expression returns the member key of n as an expression wrapper.
+func (d *decoder) expression(n map[string]any, key string) *ast.Expression {
+	return &ast.Expression{Expr: d.expr(d.required(n, key))}
+}
+
+// optExpression is like expression but returns nil if the member is null.
+func (d *decoder) optExpression(n map[string]any, key string) *ast.Expression {
+	e := d.node(n, key)
+	if e == nil {
+		return nil
+	}
+	return &ast.Expression{Expr: d.expr(e)}
+}
+
+func (d *decoder) exprs(list []any) ast.Expressions {
+	out := make(ast.Expressions, len(list))
+	for i, v := range list {
+		e, _ := v.(map[string]any)
+		out[i].Expr = d.expr(e)
+	}
+	return out
+}
+
+func (d *decoder) expr(n map[string]any) ast.Expr {
+	if n == nil {
+		return nil
+	}
+	switch d.typ(n) {
+	case "Identifier":
+		return d.ident(n)
+	case "PrivateIdentifier":
+		return &ast.PrivateIdentifier{Identifier: &ast.Identifier{Name: d.str(n, "name"), Idx: d.start(n)}}
+	case "ThisExpression":
+		return &ast.ThisExpression{Idx: d.start(n)}
+	case "Super":
+		return &ast.SuperExpression{Idx: d.start(n)}
+	case "Literal":
+		return d.literal(n)
+	case "TemplateLiteral":
+		return d.template(n)
+	case "TaggedTemplateExpression":
+		t := d.template(d.required(n, "quasi"))
+		if t != nil {
+			t.Tag = d.expression(n, "tag")
+		}
+		return t
+	case "ArrayExpression":
+		return &ast.ArrayLiteral{
+			Value:        d.exprs(d.list(n, "elements")),
+			LeftBracket:  d.start(n),
+			RightBracket: d.last(n),
+		}
+	case "ObjectExpression":
+		o := &ast.ObjectLiteral{LeftBrace: d.start(n), RightBrace: d.last(n)}
+		for _, v := range d.list(n, "properties") {
+			p, _ := v.(map[string]any)
+			o.Value = append(o.Value, ast.Property{Prop: d.property(p)})
+		}
+		return o
+	case "FunctionExpression":
+		return d.function(n)
+	case "ArrowFunctionExpression":
+		body := d.required(n, "body")
+		var concise ast.Body
+		if d.typ(body) == "BlockStatement" {
+			concise = d.block(body)
+		} else {
+			concise = &ast.Expression{Expr: d.expr(body)}
+		}
+		return &ast.ArrowFunctionLiteral{
+			ParameterList: d.params(n, body),
+			Body:          &ast.ConciseBody{Body: concise},
+			Start:         d.start(n),
+			Async:         d.bool(n, "async"),
+		}
+	case "ClassExpression":
+		return d.class(n)
+	case "UnaryExpression":
+		op, ok := operator[ast.UnaryOperator](d.str(n, "operator"))
+		if !ok {
+			d.fail(n, "unknown operator %q", n["operator"])
+		}
+		return &ast.UnaryExpression{Operand: d.expression(n, "argument"), Operator: op, Idx: d.start(n)}
+	case "UpdateExpression":
+		op, ok := operator[ast.UpdateOperator](d.str(n, "operator"))
+		if !ok {
+			d.fail(n, "unknown operator %q", n["operator"])
+		}
+		arg := d.required(n, "argument")
+		u := &ast.UpdateExpression{
+			Operand:  &ast.Expression{Expr: d.expr(arg)},
+			Operator: op,
+			Postfix:  !d.bool(n, "prefix"),
+			Idx:      d.start(n),
+		}
+		if u.Postfix {
+			u.Idx = d.end(arg)
+		}
+		return u
+	case "BinaryExpression":
+		op, ok := operator[ast.BinaryOperator](d.str(n, "operator"))
+		if !ok {
+			d.fail(n, "unknown operator %q", n["operator"])
+		}
+		return &ast.BinaryExpression{Left: d.expression(n, "left"), Right: d.expression(n, "right"), Operator: op}
+	case "LogicalExpression":
+		op, ok := operator[ast.LogicalOperator](d.str(n, "operator"))
+		if !ok {
+			d.fail(n, "unknown operator %q", n["operator"])
+		}
+		return &ast.LogicalExpression{Left: d.expression(n, "left"), Right: d.expression(n, "right"), Operator: op}
+	case "AssignmentExpression":
+		op, ok := operator[ast.AssignmentOperator](d.str(n, "operator"))
+		if !ok {
+			d.fail(n, "unknown operator %q", n["operator"])
+		}
+		return &ast.AssignExpression{
+			Left:     &ast.Expression{Expr: d.pattern(d.required(n, "left"))},
+			Right:    d.expression(n, "right"),
+			Operator: op,
+		}
+	case "ConditionalExpression":
+		return &ast.ConditionalExpression{
+			Test:       d.expression(n, "test"),
+			Consequent: d.expression(n, "consequent"),
+			Alternate:  d.expression(n, "alternate"),
+		}
+	case "SequenceExpression":
+		list := d.list(n, "expressions")
+		if len(list) == 0 {
+			d.fail(n, "no expressions")
+			return nil
+		}
+		return &ast.SequenceExpression{Sequence: d.exprs(list)}
+	case "YieldExpression":
+		return &ast.YieldExpression{
+			Argument: d.optExpression(n, "argument"),
+			Yield:    d.start(n),
+			Delegate: d.bool(n, "delegate"),
+		}
+	case "AwaitExpression":
+		return &ast.AwaitExpression{Argument: d.expression(n, "argument"), Await: d.start(n)}
+	case "MemberExpression":
+		object := d.optional(n, "object")
+		prop := d.required(n, "property")
+		switch {
+		case d.typ(prop) == "PrivateIdentifier":
+			private, _ := d.expr(prop).(*ast.PrivateIdentifier)
+			return &ast.PrivateDotExpression{Left: object, Identifier: private}
+		case d.bool(n, "computed"):
+			return &ast.MemberExpression{
+				Object:   object,
+				Property: &ast.MemberProperty{Prop: &ast.ComputedProperty{Expr: &ast.Expression{Expr: d.expr(prop)}, RightBracket: d.last(n)}},
+			}
+		}
+		return &ast.MemberExpression{Object: object, Property: &ast.MemberProperty{Prop: d.ident(prop)}}
+	case "CallExpression":
+		callee := d.required(n, "callee")
+		return &ast.CallExpression{
+			Callee:           d.optional(n, "callee"),
+			ArgumentList:     d.exprs(d.list(n, "arguments")),
+			LeftParenthesis:  d.end(callee),
+			RightParenthesis: d.last(n),
+		}
+	case "NewExpression":
+		callee := d.required(n, "callee")
+		args := d.exprs(d.list(n, "arguments"))
+		return &ast.NewExpression{
+			Callee:           &ast.Expression{Expr: d.expr(callee)},
+			ArgumentList:     args,
+			New:              d.start(n),
+			LeftParenthesis:  d.end(callee),
+			RightParenthesis: d.last(n),
+		}
+	case "ChainExpression":
+		return &ast.OptionalChain{Base: d.expression(n, "expression")}
+	case "ParenthesizedExpression":
+		return d.expr(d.required(n, "expression"))
+	case "SpreadElement":
+		return &ast.SpreadElement{Expression: d.expression(n, "argument")}
+	case "MetaProperty":
+		return &ast.MetaProperty{
+			Meta:     d.ident(d.required(n, "meta")),
+			Property: d.ident(d.required(n, "property")),
+			Idx:      d.start(n),
+		}
+	case "ArrayPattern", "ObjectPattern", "AssignmentPattern":
+		return d.pattern(n)
+	}
+	d.unsupported(n)
+	return nil
+}
+
+// optional returns the object or callee member key of n, wrapped in an
+// Optional if n is marked "optional".
+func (d *decoder) optional(n map[string]any, key string) *ast.Expression {
+	x := d.expression(n, key)
+	if d.bool(n, "optional") {
+		return &ast.Expression{Expr: &ast.Optional{Expr: x}}
+	}
+	return x
+}
+
+// operator looks up the operator of type T written as s.
+func operator[T interface {
+	~uint8
+	String() string
+}](s string) (T, bool) {
+	for op := T(0); op.String() != ""; op++ {
+		if op.String() == s {
+			return op, true
+		}
+	}
+	return 0, false
+}
+
+func (d *decoder) literal(n map[string]any) ast.Expr {
+	idx := d.start(n)
+	var raw *string
+	if r, ok := n["raw"].(string); ok {
+		raw = &r
+	}
+	if re := d.node(n, "regex"); re != nil {
+		pattern, flags := d.str(re, "pattern"), d.str(re, "flags")
+		literal := "/" + pattern + "/" + flags
+		if raw != nil {
+			literal = *raw
+		}
+		return &ast.RegExpLiteral{Literal: literal, Pattern: pattern, Flags: flags, Idx: idx}
+	}
+	if digits, ok := n["bigint"].(string); ok {
+		v, ok := new(big.Int).SetString(digits, 0)
+		if !ok {
+			d.fail(n, "invalid bigint %q", digits)
+		}
+		return &ast.BigIntLiteral{Value: v, Raw: raw, Idx: idx}
+	}
+	switch v := n["value"].(type) {
+	case nil:
+		return &ast.NullLiteral{Idx: idx}
+	case bool:
+		return &ast.BooleanLiteral{Value: v, Idx: idx}
+	case float64:
+		return &ast.NumberLiteral{Value: v, Raw: raw, Idx: idx}
+	case string:
+		return &ast.StringLiteral{Value: v, Raw: raw, Idx: idx}
+	}
+	d.fail(n, "unsupported literal value %v", n["value"])
+	return nil
+}
+
+func (d *decoder) template(n map[string]any) *ast.TemplateLiteral {
+	if n == nil {
+		return nil
+	}
+	if d.typ(n) != "TemplateLiteral" {
+		d.fail(n, "not a TemplateLiteral")
+		return nil
+	}
+	t := &ast.TemplateLiteral{
+		Expressions: d.exprs(d.list(n, "expressions")),
+		OpenQuote:   d.start(n),
+		CloseQuote:  d.last(n),
+	}
+	for _, v := range d.list(n, "quasis") {
+		q, _ := v.(map[string]any)
+		if q == nil {
+			d.fail(n, "missing template element")
+			continue
+		}
+		value := d.required(q, "value")
+		cooked, _ := value["cooked"].(string)
+		t.Elements = append(t.Elements, ast.TemplateElement{
+			Literal: d.str(value, "raw"),
+			Parsed:  cooked,
+			// Idx is that of the backtick or brace before the text.
+			Idx: max(d.start(q), 1) - 1,
+		})
+	}
+	return t
+}
+
+// key converts a property key. Identifiers that are not computed are stored
+// as string literals with the name as their raw text, as the parser does.
+func (d *decoder) key(n map[string]any, computed bool) *ast.Expression {
+	k := d.required(n, "key")
+	if !computed && d.typ(k) == "Identifier" {
+		name := d.str(k, "name")
+		return &ast.Expression{Expr: &ast.StringLiteral{Value: name, Raw: &name, Idx: d.start(k)}}
+	}
+	return &ast.Expression{Expr: d.expr(k)}
+}
+
+func (d *decoder) property(n map[string]any) ast.Prop {
+	if n == nil {
+		return nil
+	}
+	switch d.typ(n) {
+	case "SpreadElement":
+		return &ast.SpreadElement{Expression: d.expression(n, "argument")}
+	case "Property":
+	default:
+		d.unsupported(n)
+		return nil
+	}
+
+	if d.bool(n, "shorthand") {
+		short := &ast.PropertyShort{Name: d.ident(d.required(n, "key")), Initializer: &ast.Expression{}}
+		if v := d.required(n, "value"); d.typ(v) == "AssignmentPattern" {
+			short.Initializer = d.expression(v, "right")
+		}
+		return short
+	}
+
+	computed := d.bool(n, "computed")
+	keyed := &ast.PropertyKeyed{Key: d.key(n, computed), Computed: computed}
+	switch kind := d.str(n, "kind"); {
+	case kind == "get" || kind == "set":
+		keyed.Kind = ast.PropertyKind(kind)
+	case kind == "init" && d.bool(n, "method"):
+		keyed.Kind = ast.PropertyKindMethod
+	case kind == "init":
+		keyed.Kind = ast.PropertyKindValue
+	default:
+		d.fail(n, "unknown property kind %q", kind)
+	}
+	if keyed.Kind == ast.PropertyKindValue {
+		keyed.Value = d.expression(n, "value")
+	} else {
+		keyed.Value = &ast.Expression{Expr: d.method(d.required(n, "value"))}
+	}
+	return keyed
+}
+
+// binding converts the target of a declaration, parameter or catch clause.
+func (d *decoder) binding(n map[string]any) *ast.BindingTarget {
+	x := d.pattern(n)
+	t, ok := x.(ast.Target)
+	if !ok {
+		if x != nil {
+			d.fail(n, "not a binding target")
+		}
+		return nil
+	}
+	return &ast.BindingTarget{Target: t}
+}
+
+// pattern converts the target of a binding or assignment.
+func (d *decoder) pattern(n map[string]any) ast.Expr {
+	if n == nil {
+		return nil
+	}
+	switch d.typ(n) {
+	case "ArrayPattern":
+		p := &ast.ArrayPattern{Rest: &ast.Expression{}, LeftBracket: d.start(n), RightBracket: d.last(n)}
+		for _, v := range d.list(n, "elements") {
+			e, _ := v.(map[string]any)
+			if d.typ(e) == "RestElement" {
+				p.Rest.Expr = d.pattern(d.required(e, "argument"))
+				continue
+			}
+			p.Elements = append(p.Elements, ast.Expression{Expr: d.pattern(e)})
+		}
+		return p
+	case "ObjectPattern":
+		p := &ast.ObjectPattern{LeftBrace: d.start(n), RightBrace: d.last(n)}
+		for _, v := range d.list(n, "properties") {
+			e, _ := v.(map[string]any)
+			switch {
+			case e == nil:
+				d.fail(n, "missing property")
+			case d.typ(e) == "RestElement":
+				p.Rest = d.pattern(d.required(e, "argument"))
+			case d.typ(e) != "Property":
+				d.unsupported(e)
+			case d.bool(e, "shorthand"):
+				p.Properties = append(p.Properties, ast.Property{Prop: d.property(e)})
+			default:
+				computed := d.bool(e, "computed")
+				p.Properties = append(p.Properties, ast.Property{Prop: &ast.PropertyKeyed{
+					Key:      d.key(e, computed),
+					Kind:     ast.PropertyKindValue,
+					Value:    &ast.Expression{Expr: d.pattern(d.required(e, "value"))},
+					Computed: computed,
+				}})
+			}
+		}
+		return p
+	case "AssignmentPattern":
+		return &ast.AssignExpression{
+			Left:     &ast.Expression{Expr: d.pattern(d.required(n, "left"))},
+			Right:    d.expression(n, "right"),
+			Operator: ast.AssignmentAssign,
+		}
+	case "ParenthesizedExpression":
+		return d.pattern(d.required(n, "expression"))
+	case "ArrayExpression", "ObjectExpression":
+		d.fail(n, "expression used as a pattern")
+		return nil
+	}
+	return d.expr(n)
+}
+
+// params converts the parameters of function n, whose body is body.
+func (d *decoder) params(n, body map[string]any) *ast.ParameterList {
+	list := &ast.ParameterList{}
+	params := d.list(n, "params")
+	for _, v := range params {
+		p, _ := v.(map[string]any)
+		switch d.typ(p) {
+		case "RestElement":
+			list.Rest = d.pattern(d.required(p, "argument"))
+		case "AssignmentPattern":
+			list.List = append(list.List, ast.VariableDeclarator{
+				Target:      d.binding(d.required(p, "left")),
+				Initializer: d.expression(p, "right"),
+			})
+		default:
+			list.List = append(list.List, ast.VariableDeclarator{Target: d.binding(p)})
+		}
+	}
+	// The parentheses are not recorded; place them around the parameters.
+	list.Closing = max(d.start(body), 1) - 1
+	list.Opening = list.Closing
+	if len(params) > 0 {
+		first, _ := params[0].(map[string]any)
+		last, _ := params[len(params)-1].(map[string]any)
+		list.Opening = max(d.start(first), 1) - 1
+		list.Closing = d.end(last)
+	}
+	return list
+}
+
+func (d *decoder) function(n map[string]any) *ast.FunctionLiteral {
+	if n == nil {
+		return nil
+	}
+	switch d.typ(n) {
+	case "FunctionDeclaration", "FunctionExpression":
+	default:
+		d.fail(n, "not a function")
+		return nil
+	}
+	body := d.required(n, "body")
+	return &ast.FunctionLiteral{
+		Name:          d.optIdent(d.node(n, "id")),
+		ParameterList: d.params(n, body),
+		Body:          d.block(body),
+		Function:      d.start(n),
+		Async:         d.bool(n, "async"),
+		Generator:     d.bool(n, "generator"),
+	}
+}
+
+// method converts the function of a method, getter or setter. ESTree starts
+// it at the opening parenthesis.
+func (d *decoder) method(n map[string]any) *ast.FunctionLiteral {
+	f := d.function(n)
+	if f != nil {
+		f.ParameterList.Opening = d.start(n)
+	}
+	return f
+}
+
+func (d *decoder) class(n map[string]any) *ast.ClassLiteral {
+	body := d.required(n, "body")
+	c := &ast.ClassLiteral{
+		Name:       d.optIdent(d.node(n, "id")),
+		SuperClass: d.optExpression(n, "superClass"),
+		Class:      d.start(n),
+		RightBrace: d.last(body),
+	}
+	for _, v := range d.list(body, "body") {
+		e, _ := v.(map[string]any)
+		c.Body = append(c.Body, ast.ClassElement{Element: d.classElement(e)})
+	}
+	return c
+}
+
+func (d *decoder) classElement(n map[string]any) ast.Element {
+	if n == nil {
+		return nil
+	}
+	computed := d.bool(n, "computed")
+	switch d.typ(n) {
+	case "MethodDefinition":
+		kind := ast.PropertyKind(d.str(n, "kind"))
+		switch kind {
+		case "constructor":
+			kind = ast.PropertyKindMethod
+		case ast.PropertyKindMethod, ast.PropertyKindGet, ast.PropertyKindSet:
+		default:
+			d.fail(n, "unknown method kind %q", kind)
+		}
+		return &ast.MethodDefinition{
+			Key:      d.key(n, computed),
+			Kind:     kind,
+			Body:     d.method(d.required(n, "value")),
+			Idx:      d.start(n),
+			Computed: computed,
+			Static:   d.bool(n, "static"),
+		}
+	case "PropertyDefinition":
+		return &ast.FieldDefinition{
+			Key:         d.key(n, computed),
+			Initializer: d.optExpression(n, "value"),
+			Idx:         d.start(n),
+			Computed:    computed,
+			Static:      d.bool(n, "static"),
+		}
+	case "StaticBlock":
+		return &ast.ClassStaticBlock{
+			Block: &ast.BlockStatement{
+				List:       d.statements(d.list(n, "body")),
+				LeftBrace:  d.start(n) + 7, // "static "
+				RightBrace: d.last(n),
+			},
+			Static: d.start(n),
+		}
+	}
+	d.unsupported(n)
+	return nil
+}
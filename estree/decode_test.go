@@ -0,0 +1,149 @@
+package estree_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/t14raptor/go-fast/estree"
+	"github.com/t14raptor/go-fast/generator"
+	"github.com/t14raptor/go-fast/parser"
+	"github.com/t14raptor/go-fast/resolver"
+)
+
+func TestDecodeRoundTrip(t *testing.T) {
+	sources := []string{
+		"class A extends B { constructor() { super(); } static m() {} #x = 1; y; static { a; } get #z() {} m2() { this.#x; #x in this; } [k] = 2; }",
+		"a?.b.c; a?.(); a?.[b]; (a?.b).c; x **= 2; [a, {b, c: d = 1, ...e}] = c; for ([a] of b); try {} catch {} finally {}",
+		"switch (a) { case 1: break; default: } do x; while (y); async function* g() { yield* a; await b; }",
+		"label: for (;;) { continue label; } a ? b : c; a++; --a; typeof a; `x${1}y`; t`a\\n${b}`;",
+		"o = {a, b: 1, c() {}, set d(v) {}, ...f, 1: 2, 's': 3, [k]: 4};",
+		"function f(a, {b} = {}, [c] = [], ...d) { 'use strict'; return new.target; }",
+		"var x = /re/gi, y = 10n, z = null, w = !0, u = void 0; (async () => { await 1; })(); x => ({}); new Foo(1);",
+		"if (a) b; else if (c) d; else { e; } while (x) { with (o) {} } for (var k in o) debugger; for (let i = 0, j; i < 1; i++);",
+		"s = 'é😀';\nlet [, ...rest] = s;",
+	}
+	for _, src := range sources {
+		program, err := parser.ParseFile(src)
+		if err != nil {
+			t.Fatalf("parse %q: %v", src, err)
+		}
+		opts := estree.Options{Source: src}
+		want, err := estree.Marshal(program, opts)
+		if err != nil {
+			t.Fatalf("marshal %q: %v", src, err)
+		}
+
+		decoded, err := estree.Decode(want, opts)
+		if err != nil {
+			t.Fatalf("decode %q: %v", src, err)
+		}
+		got, err := estree.Marshal(decoded, opts)
+		if err != nil {
+			t.Fatalf("marshal decoded %q: %v", src, err)
+		}
+		if string(got) != string(want) {
+			t.Errorf("%s: round trip differs\ngot:  %s\nwant: %s", src, got, want)
+		}
+		if got, want := generator.GenerateMinified(decoded), generator.GenerateMinified(program); got != want {
+			t.Errorf("%s: generated %q; want %q", src, got, want)
+		}
+	}
+}
+
+func TestDecodeWithoutPositions(t *testing.T) {
+	const src = "const {a, ...b} = obj?.x; f(...(b))"
+	data := `{"type": "Program", "sourceType": "script", "body": [
+		{"type": "VariableDeclaration", "kind": "const", "declarations": [
+			{"type": "VariableDeclarator",
+				"id": {"type": "ObjectPattern", "properties": [
+					{"type": "Property", "kind": "init", "shorthand": true, "computed": false, "method": false,
+						"key": {"type": "Identifier", "name": "a"}, "value": {"type": "Identifier", "name": "a"}},
+					{"type": "RestElement", "argument": {"type": "Identifier", "name": "b"}}]},
+				"init": {"type": "ChainExpression", "expression":
+					{"type": "MemberExpression", "optional": true, "computed": false,
+						"object": {"type": "Identifier", "name": "obj"},
+						"property": {"type": "Identifier", "name": "x"}}}}]},
+		{"type": "ExpressionStatement", "expression":
+			{"type": "CallExpression", "optional": false,
+				"callee": {"type": "Identifier", "name": "f"},
+				"arguments": [{"type": "SpreadElement", "argument":
+					{"type": "ParenthesizedExpression", "expression": {"type": "Identifier", "name": "b"}}}]}}]}`
+
+	program, err := estree.Decode([]byte(data), estree.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolver.Resolve(program)
+
+	parsed, err := parser.ParseFile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := generator.GenerateMinified(program), generator.GenerateMinified(parsed); got != want {
+		t.Errorf("generated %q; want %q", got, want)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+		// unsupported reports whether the error wraps ErrUnsupported.
+		unsupported bool
+	}{
+		{
+			name:        "module declaration",
+			data:        `{"type": "Program", "body": [{"type": "ImportDeclaration", "start": 0, "end": 20, "specifiers": []}]}`,
+			want:        `type "ImportDeclaration" at offset 0`,
+			unsupported: true,
+		},
+		{
+			name:        "JSX",
+			data:        `{"type": "Program", "body": [{"type": "ExpressionStatement", "expression": {"type": "JSXElement", "start": 3}}]}`,
+			want:        `type "JSXElement" at offset 3`,
+			unsupported: true,
+		},
+		{
+			name: "missing member",
+			data: `{"type": "Program", "body": [{"type": "ThrowStatement", "start": 4}]}`,
+			want: `ThrowStatement at offset 4: missing "argument"`,
+		},
+		{
+			name: "unknown operator",
+			data: `{"type": "Program", "body": [{"type": "ExpressionStatement", "expression":
+				{"type": "BinaryExpression", "operator": "<=>", "left": {"type": "Identifier", "name": "a"}, "right": {"type": "Identifier", "name": "b"}}}]}`,
+			want: `unknown operator "<=>"`,
+		},
+		{
+			name: "empty sequence",
+			data: `{"type": "Program", "body": [{"type": "ExpressionStatement", "expression": {"type": "SequenceExpression", "start": 2, "expressions": []}}]}`,
+			want: `SequenceExpression at offset 2: no expressions`,
+		},
+		{
+			name: "invalid tree",
+			data: `{"type": "Program", "body": [{"type": "ExpressionStatement", "expression": {"type": "Identifier", "name": ""}}]}`,
+			want: "invalid tree: Body[0].Stmt.Expression.Expr: Identifier has no name",
+		},
+		{
+			name: "not a program",
+			data: `{"type": "Identifier", "name": "a"}`,
+			want: "not a Program",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := estree.Decode([]byte(tt.data), estree.Options{})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q does not mention %q", err, tt.want)
+			}
+			if errors.Is(err, estree.ErrUnsupported) != tt.unsupported {
+				t.Errorf("errors.Is(%v, ErrUnsupported) = %v; want %v", err, !tt.unsupported, tt.unsupported)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Package estree converts go-fAST syntax trees to and from ESTree JSON, the
+// format used by Acorn, Espree, ESLint and astexplorer.
+//
+// # Positions
+//
+// Every node carries "start", "end" and "range" taken from Idx0 and Idx1.
+// Without Options.Source these are the byte offsets stored in the tree. With
+// it they are converted to UTF-16 code units, as JavaScript tools count them,
+// and a "loc" with 1-based lines and 0-based columns is added. Positions are
+// only as precise as the tree's own: go-fAST does not record every
+// delimiter, so the end of a statement excludes its semicolon.
+//
+// # Mapping
+//
+// Most go-fAST nodes have an ESTree counterpart of the same shape. The
+// exceptions are:
+//
+//	go-fAST                            ESTree
+//	-------                            ------
+//	Expression, Statement,             the wrapped node; an empty wrapper
+//	BindingTarget, ForInto,            becomes null
+//	ForLoopInitializer, ConciseBody,
+//	MemberProperty, Property,
+//	ClassElement
+//	OptionalChain                      ChainExpression
+//	Optional                           sets "optional" on the MemberExpression
+//	                                   or CallExpression it is the object or
+//	                                   callee of
+//	PrivateDotExpression               MemberExpression with a PrivateIdentifier
+//	                                   property
+//	TemplateLiteral with a Tag         TaggedTemplateExpression
+//	PropertyShort                      Property with "shorthand"; its
+//	                                   initializer becomes an AssignmentPattern
+//	PropertyKeyed                      Property with kind "init", "get" or
+//	                                   "set"; "method" when Kind is method
+//	MethodDefinition                   MethodDefinition, kind "constructor" for
+//	                                   the class constructor
+//	FieldDefinition                    PropertyDefinition
+//	ClassStaticBlock                   StaticBlock
+//	LabelledStatement                  LabeledStatement
+//	CaseStatement                      SwitchCase
+//	CatchStatement                     CatchClause
+//	BooleanLiteral, NullLiteral,       Literal, with "regex" or "bigint" where
+//	NumberLiteral, StringLiteral,      ESTree requires them
+//	RegExpLiteral, BigIntLiteral
+//	StringLiteral key written without  Identifier
+//	quotes
+//	AssignExpression in a pattern      AssignmentPattern
+//	VariableDeclarator with an         AssignmentPattern
+//	initializer in a ParameterList
+//	ParameterList.Rest,                RestElement, appended to the list
+//	ArrayPattern.Rest,
+//	ObjectPattern.Rest
+//	ParameterList                      the "params" array of the function
+//
+// Leading string expression statements of a program or function body get a
+// "directive" member. Programs are always reported with sourceType "script".
+//
+// Decode applies the mapping in reverse, also accepting the
+// ParenthesizedExpression nodes some parsers emit.
+//
+// BadStatement, InvalidExpression and lazily parsed function bodies have no
+// ESTree form; converting a tree that contains them fails with an error
+// wrapping ErrUnsupported. So does decoding ESTree nodes go-fAST lacks.
+package estree
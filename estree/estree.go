@@ -0,0 +1,764 @@
+package estree
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nukilabs/ftoa"
+	"github.com/t14raptor/go-fast/ast"
+)
+
+// ErrUnsupported is reported for nodes that have no ESTree equivalent.
+var ErrUnsupported = errors.New("unsupported node")
+
+// Options configures Marshal and Decode.
+type Options struct {
+	// Source is the text the program was parsed from. When set, positions
+	// are reported in UTF-16 code units and every node gets a "loc"; Decode
+	// reads positions as UTF-16 offsets into it.
+	Source string
+	// Indent, when non-empty, makes Marshal pretty-print its output using it
+	// as the indentation of each level.
+	Indent string
+}
+
+// Marshal returns the ESTree JSON encoding of program. See the package
+// documentation for how go-fAST nodes are mapped.
+func Marshal(program *ast.Program, opts Options) ([]byte, error) {
+	e := &encoder{src: opts.Source}
+	if opts.Source != "" {
+		e.pos = newPositions(opts.Source)
+	}
+	node := e.program(program)
+	if e.err != nil {
+		return nil, e.err
+	}
+	b := appendJSON(nil, node)
+	if opts.Indent == "" {
+		return b, nil
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, b, "", opts.Indent); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encoder converts a tree to ESTree objects. The first error is kept in err
+// and conversion carries on, producing null for the offending nodes.
+type encoder struct {
+	src string
+	pos *positions
+	err error
+}
+
+func (e *encoder) fail(n ast.Node, format string, args ...any) any {
+	if e.err == nil {
+		e.err = fmt.Errorf("estree: %w: %s at offset %d", ErrUnsupported, fmt.Sprintf(format, args...), n.Idx0())
+	}
+	return nil
+}
+
+// node returns an ESTree node of type typ spanning [start, end). kv holds the
+// remaining members as alternating keys and values.
+func (e *encoder) node(typ string, start, end ast.Idx, kv ...any) object {
+	o := make(object, 0, 5+len(kv)/2)
+	o = append(o, member{"type", typ})
+	if e.pos == nil {
+		o = append(o,
+			member{"start", int(start)},
+			member{"end", int(end)},
+			member{"range", []any{int(start), int(end)}},
+		)
+	} else {
+		s, sl, sc := e.pos.lookup(start)
+		f, el, ec := e.pos.lookup(end)
+		o = append(o,
+			member{"start", s},
+			member{"end", f},
+			member{"loc", object{
+				{"start", object{{"line", sl}, {"column", sc}}},
+				{"end", object{{"line", el}, {"column", ec}}},
+			}},
+			member{"range", []any{s, f}},
+		)
+	}
+	for i := 0; i < len(kv); i += 2 {
+		o = append(o, member{kv[i].(string), kv[i+1]})
+	}
+	return o
+}
+
+// span is a shorthand for node using the range of n.
+func (e *encoder) span(typ string, n ast.Node, kv ...any) object {
+	return e.node(typ, n.Idx0(), n.Idx1(), kv...)
+}
+
+// back returns the offset of tok if it ends right before idx, ignoring
+// whitespace, and idx otherwise.
+func (e *encoder) back(idx ast.Idx, tok string) ast.Idx {
+	i := min(int(idx), len(e.src))
+	s := strings.TrimRight(e.src[:i], " \t\n\r")
+	if strings.HasSuffix(s, tok) {
+		return ast.Idx(len(s) - len(tok))
+	}
+	return idx
+}
+
+// forward returns the offset of the first c at or after idx, or idx if the
+// source is unknown.
+func (e *encoder) forward(idx ast.Idx, c byte) ast.Idx {
+	if int(idx) < len(e.src) {
+		if k := strings.IndexByte(e.src[idx:], c); k >= 0 {
+			return idx + ast.Idx(k)
+		}
+	}
+	return idx
+}
+
+func (e *encoder) program(n *ast.Program) any {
+	start, end := n.Idx0(), n.Idx1()
+	if e.pos != nil {
+		start, end = 0, ast.Idx(len(e.src))
+	}
+	return e.node("Program", start, end,
+		"body", e.statements(n.Body, true),
+		"sourceType", "script",
+	)
+}
+
+// statements converts list. If prologue is set, its leading string
+// expression statements are marked as directives.
+func (e *encoder) statements(list ast.Statements, prologue bool) []any {
+	out := make([]any, 0, len(list))
+	for _, s := range list {
+		if prologue {
+			if es, ok := s.Stmt.(*ast.ExpressionStatement); ok {
+				if lit, ok := es.Expression.Expr.(*ast.StringLiteral); ok && lit.Raw != nil && len(*lit.Raw) >= 2 {
+					o := e.span("ExpressionStatement", es, "expression", e.expr(lit))
+					out = append(out, append(o, member{"directive", (*lit.Raw)[1 : len(*lit.Raw)-1]}))
+					continue
+				}
+			}
+			prologue = false
+		}
+		out = append(out, e.stmt(s.Stmt))
+	}
+	return out
+}
+
+func (e *encoder) block(n *ast.BlockStatement, prologue bool) object {
+	return e.span("BlockStatement", n, "body", e.statements(n.List, prologue))
+}
+
+func (e *encoder) stmt(s ast.Stmt) any {
+	switch n := s.(type) {
+	case nil:
+		return nil
+	case *ast.BlockStatement:
+		return e.block(n, false)
+	case *ast.EmptyStatement:
+		return e.span("EmptyStatement", n)
+	case *ast.DebuggerStatement:
+		return e.span("DebuggerStatement", n)
+	case *ast.ExpressionStatement:
+		return e.span("ExpressionStatement", n, "expression", e.expr(n.Expression.Expr))
+	case *ast.VariableDeclaration:
+		return e.variableDeclaration(n)
+	case *ast.FunctionDeclaration:
+		return e.function("FunctionDeclaration", n.Function, n.Idx0())
+	case *ast.ClassDeclaration:
+		return e.class("ClassDeclaration", n.Class)
+	case *ast.ReturnStatement:
+		return e.span("ReturnStatement", n, "argument", e.optExpr(n.Argument))
+	case *ast.ThrowStatement:
+		return e.span("ThrowStatement", n, "argument", e.expr(n.Argument.Expr))
+	case *ast.BreakStatement:
+		return e.span("BreakStatement", n, "label", e.optIdent(n.Label))
+	case *ast.ContinueStatement:
+		return e.span("ContinueStatement", n, "label", e.optIdent(n.Label))
+	case *ast.LabelledStatement:
+		return e.span("LabeledStatement", n,
+			"label", e.ident(n.Label),
+			"body", e.stmt(n.Statement.Stmt),
+		)
+	case *ast.IfStatement:
+		var alternate any
+		if n.Alternate != nil {
+			alternate = e.stmt(n.Alternate.Stmt)
+		}
+		return e.span("IfStatement", n,
+			"test", e.expr(n.Test.Expr),
+			"consequent", e.stmt(n.Consequent.Stmt),
+			"alternate", alternate,
+		)
+	case *ast.WithStatement:
+		return e.span("WithStatement", n,
+			"object", e.expr(n.Object.Expr),
+			"body", e.stmt(n.Body.Stmt),
+		)
+	case *ast.WhileStatement:
+		return e.span("WhileStatement", n,
+			"test", e.expr(n.Test.Expr),
+			"body", e.stmt(n.Body.Stmt),
+		)
+	case *ast.DoWhileStatement:
+		return e.span("DoWhileStatement", n,
+			"body", e.stmt(n.Body.Stmt),
+			"test", e.expr(n.Test.Expr),
+		)
+	case *ast.ForStatement:
+		var init any
+		if n.Initializer != nil {
+			switch i := n.Initializer.Initializer.(type) {
+			case *ast.VariableDeclaration:
+				init = e.variableDeclaration(i)
+			case *ast.Expression:
+				init = e.expr(i.Expr)
+			}
+		}
+		return e.span("ForStatement", n,
+			"init", init,
+			"test", e.optExpr(n.Test),
+			"update", e.optExpr(n.Update),
+			"body", e.stmt(n.Body.Stmt),
+		)
+	case *ast.ForInStatement:
+		return e.span("ForInStatement", n,
+			"left", e.forInto(n.Into),
+			"right", e.expr(n.Source.Expr),
+			"body", e.stmt(n.Body.Stmt),
+		)
+	case *ast.ForOfStatement:
+		return e.span("ForOfStatement", n,
+			"await", n.Await,
+			"left", e.forInto(n.Into),
+			"right", e.expr(n.Source.Expr),
+			"body", e.stmt(n.Body.Stmt),
+		)
+	case *ast.SwitchStatement:
+		cases := make([]any, len(n.Body))
+		for i := range n.Body {
+			c := &n.Body[i]
+			cases[i] = e.span("SwitchCase", c,
+				"test", e.optExpr(c.Test),
+				"consequent", e.statements(c.Consequent, false),
+			)
+		}
+		return e.span("SwitchStatement", n,
+			"discriminant", e.expr(n.Discriminant.Expr),
+			"cases", cases,
+		)
+	case *ast.TryStatement:
+		var handler, finalizer any
+		if c := n.Catch; c != nil {
+			var param any
+			if c.Parameter != nil {
+				param = e.pattern(c.Parameter.Target)
+			}
+			handler = e.span("CatchClause", c,
+				"param", param,
+				"body", e.block(c.Body, false),
+			)
+		}
+		if n.Finally != nil {
+			finalizer = e.block(n.Finally, false)
+		}
+		return e.span("TryStatement", n,
+			"block", e.block(n.Body, false),
+			"handler", handler,
+			"finalizer", finalizer,
+		)
+	case *ast.BadStatement:
+		return e.fail(n, "bad statement")
+	}
+	return e.fail(s, "statement %T", s)
+}
+
+func (e *encoder) variableDeclaration(n *ast.VariableDeclaration) object {
+	declarations := make([]any, len(n.List))
+	for i := range n.List {
+		d := &n.List[i]
+		declarations[i] = e.span("VariableDeclarator", d,
+			"id", e.pattern(d.Target.Target),
+			"init", e.optExpr(d.Initializer),
+		)
+	}
+	return e.span("VariableDeclaration", n,
+		"declarations", declarations,
+		"kind", n.Token.String(),
+	)
+}
+
+func (e *encoder) forInto(n *ast.ForInto) any {
+	switch into := n.Into.(type) {
+	case *ast.VariableDeclaration:
+		return e.variableDeclaration(into)
+	case *ast.Expression:
+		return e.pattern(into.Expr)
+	}
+	return nil
+}
+
+func (e *encoder) ident(n *ast.Identifier) object {
+	return e.span("Identifier", n, "name", n.Name)
+}
+
+func (e *encoder) optIdent(n *ast.Identifier) any {
+	if n == nil {
+		return nil
+	}
+	return e.ident(n)
+}
+
+func (e *encoder) optExpr(n *ast.Expression) any {
+	if n == nil {
+		return nil
+	}
+	return e.expr(n.Expr)
+}
+
+func (e *encoder) exprs(list ast.Expressions) []any {
+	out := make([]any, len(list))
+	for i := range list {
+		out[i] = e.expr(list[i].Expr)
+	}
+	return out
+}
+
+func (e *encoder) expr(x ast.Expr) any {
+	switch n := x.(type) {
+	case nil:
+		return nil
+	case *ast.Identifier:
+		return e.ident(n)
+	case *ast.PrivateIdentifier:
+		return e.span("PrivateIdentifier", n, "name", n.Identifier.Name)
+	case *ast.ThisExpression:
+		return e.span("ThisExpression", n)
+	case *ast.SuperExpression:
+		return e.span("Super", n)
+	case *ast.NullLiteral:
+		return e.span("Literal", n, "value", nil, "raw", "null")
+	case *ast.BooleanLiteral:
+		raw := "false"
+		if n.Value {
+			raw = "true"
+		}
+		return e.node("Literal", n.Idx, n.Idx+ast.Idx(len(raw)), "value", n.Value, "raw", raw)
+	case *ast.NumberLiteral:
+		raw := ftoa.FormatFloat(n.Value, 'g', -1, 64)
+		if n.Raw != nil {
+			raw = *n.Raw
+		}
+		return e.span("Literal", n, "value", n.Value, "raw", raw)
+	case *ast.BigIntLiteral:
+		var digits, raw string
+		if n.Value != nil {
+			digits = n.Value.String()
+			raw = digits + "n"
+		}
+		if n.Raw != nil {
+			raw = *n.Raw
+		}
+		return e.span("Literal", n, "value", nil, "raw", raw, "bigint", digits)
+	case *ast.StringLiteral:
+		raw := ""
+		if n.Raw != nil {
+			raw = *n.Raw
+		} else {
+			raw = string(appendString(nil, n.Value))
+		}
+		return e.span("Literal", n, "value", n.Value, "raw", raw)
+	case *ast.RegExpLiteral:
+		return e.span("Literal", n,
+			"value", nil,
+			"raw", n.Literal,
+			"regex", object{{"pattern", n.Pattern}, {"flags", n.Flags}},
+		)
+	case *ast.TemplateLiteral:
+		if n.Tag != nil && n.Tag.Expr != nil {
+			return e.node("TaggedTemplateExpression", n.Tag.Expr.Idx0(), n.Idx1(),
+				"tag", e.expr(n.Tag.Expr),
+				"quasi", e.template(n),
+			)
+		}
+		return e.template(n)
+	case *ast.ArrayLiteral:
+		return e.span("ArrayExpression", n, "elements", e.exprs(n.Value))
+	case *ast.ObjectLiteral:
+		props := make([]any, len(n.Value))
+		for i := range n.Value {
+			props[i] = e.property(n.Value[i].Prop)
+		}
+		return e.span("ObjectExpression", n, "properties", props)
+	case *ast.FunctionLiteral:
+		return e.function("FunctionExpression", n, n.Idx0())
+	case *ast.ArrowFunctionLiteral:
+		var body any
+		expression := false
+		switch b := n.Body.Body.(type) {
+		case *ast.BlockStatement:
+			body = e.block(b, true)
+		case *ast.Expression:
+			body = e.expr(b.Expr)
+			expression = true
+		}
+		return e.span("ArrowFunctionExpression", n,
+			"id", nil,
+			"expression", expression,
+			"generator", false,
+			"async", n.Async,
+			"params", e.params(n.ParameterList),
+			"body", body,
+		)
+	case *ast.ClassLiteral:
+		return e.class("ClassExpression", n)
+	case *ast.UnaryExpression:
+		return e.span("UnaryExpression", n,
+			"operator", n.Operator.String(),
+			"prefix", true,
+			"argument", e.expr(n.Operand.Expr),
+		)
+	case *ast.UpdateExpression:
+		return e.span("UpdateExpression", n,
+			"operator", n.Operator.String(),
+			"prefix", !n.Postfix,
+			"argument", e.expr(n.Operand.Expr),
+		)
+	case *ast.BinaryExpression:
+		return e.span("BinaryExpression", n,
+			"left", e.expr(n.Left.Expr),
+			"operator", n.Operator.String(),
+			"right", e.expr(n.Right.Expr),
+		)
+	case *ast.LogicalExpression:
+		return e.span("LogicalExpression", n,
+			"left", e.expr(n.Left.Expr),
+			"operator", n.Operator.String(),
+			"right", e.expr(n.Right.Expr),
+		)
+	case *ast.AssignExpression:
+		return e.span("AssignmentExpression", n,
+			"operator", n.Operator.String(),
+			"left", e.pattern(n.Left.Expr),
+			"right", e.expr(n.Right.Expr),
+		)
+	case *ast.ConditionalExpression:
+		return e.span("ConditionalExpression", n,
+			"test", e.expr(n.Test.Expr),
+			"consequent", e.expr(n.Consequent.Expr),
+			"alternate", e.expr(n.Alternate.Expr),
+		)
+	case *ast.SequenceExpression:
+		return e.span("SequenceExpression", n, "expressions", e.exprs(n.Sequence))
+	case *ast.YieldExpression:
+		return e.span("YieldExpression", n,
+			"delegate", n.Delegate,
+			"argument", e.optExpr(n.Argument),
+		)
+	case *ast.AwaitExpression:
+		return e.span("AwaitExpression", n, "argument", e.expr(n.Argument.Expr))
+	case *ast.MemberExpression:
+		obj, optional := e.optional(n.Object)
+		var property any
+		computed := false
+		switch p := n.Property.Prop.(type) {
+		case *ast.Identifier:
+			property = e.ident(p)
+		case *ast.ComputedProperty:
+			property = e.expr(p.Expr.Expr)
+			computed = true
+		}
+		return e.span("MemberExpression", n,
+			"object", obj,
+			"property", property,
+			"computed", computed,
+			"optional", optional,
+		)
+	case *ast.PrivateDotExpression:
+		obj, optional := e.optional(n.Left)
+		return e.span("MemberExpression", n,
+			"object", obj,
+			"property", e.expr(n.Identifier),
+			"computed", false,
+			"optional", optional,
+		)
+	case *ast.CallExpression:
+		callee, optional := e.optional(n.Callee)
+		return e.span("CallExpression", n,
+			"callee", callee,
+			"arguments", e.exprs(n.ArgumentList),
+			"optional", optional,
+		)
+	case *ast.NewExpression:
+		return e.span("NewExpression", n,
+			"callee", e.expr(n.Callee.Expr),
+			"arguments", e.exprs(n.ArgumentList),
+		)
+	case *ast.OptionalChain:
+		return e.span("ChainExpression", n, "expression", e.expr(n.Base.Expr))
+	case *ast.Optional:
+		return e.expr(n.Expr.Expr)
+	case *ast.SpreadElement:
+		return e.node("SpreadElement", e.back(n.Idx0(), "..."), n.Idx1(), "argument", e.expr(n.Expression.Expr))
+	case *ast.MetaProperty:
+		return e.span("MetaProperty", n,
+			"meta", e.ident(n.Meta),
+			"property", e.ident(n.Property),
+		)
+	case *ast.ArrayPattern, *ast.ObjectPattern:
+		return e.pattern(n)
+	case *ast.InvalidExpression:
+		return e.fail(n, "invalid expression")
+	}
+	return e.fail(x, "expression %T", x)
+}
+
+// optional converts the object or callee x, reporting whether it is
+// followed by `?.`.
+func (e *encoder) optional(x *ast.Expression) (any, bool) {
+	if o, ok := x.Expr.(*ast.Optional); ok {
+		return e.expr(o.Expr.Expr), true
+	}
+	return e.expr(x.Expr), false
+}
+
+func (e *encoder) template(n *ast.TemplateLiteral) object {
+	quasis := make([]any, len(n.Elements))
+	for i, el := range n.Elements {
+		// Idx is that of the backtick or brace before the text.
+		start := el.Idx + 1
+		quasis[i] = e.node("TemplateElement", start, start+ast.Idx(len(el.Literal)),
+			"value", object{{"raw", el.Literal}, {"cooked", el.Parsed}},
+			"tail", i == len(n.Elements)-1,
+		)
+	}
+	return e.node("TemplateLiteral", n.OpenQuote, n.CloseQuote+1,
+		"expressions", e.exprs(n.Expressions),
+		"quasis", quasis,
+	)
+}
+
+// key converts a property key. Names written without quotes are stored as
+// string literals and become identifiers.
+func (e *encoder) key(k *ast.Expression, computed bool) any {
+	if lit, ok := k.Expr.(*ast.StringLiteral); ok && !computed && lit.Raw != nil {
+		if raw := *lit.Raw; raw != "" && raw[0] != '"' && raw[0] != '\'' {
+			return e.span("Identifier", lit, "name", lit.Value)
+		}
+	}
+	return e.expr(k.Expr)
+}
+
+// method converts the function of a method, getter or setter, which ESTree
+// starts at its parameter list.
+func (e *encoder) method(f *ast.FunctionLiteral) any {
+	return e.function("FunctionExpression", f, f.ParameterList.Opening)
+}
+
+func (e *encoder) property(p ast.Prop) any {
+	switch n := p.(type) {
+	case *ast.PropertyShort:
+		value := any(e.ident(n.Name))
+		if n.Initializer != nil && n.Initializer.Expr != nil {
+			value = e.span("AssignmentPattern", n, "left", value, "right", e.expr(n.Initializer.Expr))
+		}
+		return e.span("Property", n,
+			"key", e.ident(n.Name),
+			"value", value,
+			"kind", "init",
+			"method", false,
+			"shorthand", true,
+			"computed", false,
+		)
+	case *ast.PropertyKeyed:
+		kind := string(n.Kind)
+		var value any
+		switch n.Kind {
+		case ast.PropertyKindValue:
+			kind = "init"
+			value = e.expr(n.Value.Expr)
+		default:
+			if n.Kind == ast.PropertyKindMethod {
+				kind = "init"
+			}
+			if f, ok := n.Value.Expr.(*ast.FunctionLiteral); ok {
+				value = e.method(f)
+			} else {
+				value = e.expr(n.Value.Expr)
+			}
+		}
+		return e.node("Property", e.back(n.Idx0(), "["), n.Idx1(),
+			"key", e.key(n.Key, n.Computed),
+			"value", value,
+			"kind", kind,
+			"method", n.Kind == ast.PropertyKindMethod,
+			"shorthand", false,
+			"computed", n.Computed,
+		)
+	case *ast.SpreadElement:
+		return e.expr(n)
+	}
+	return e.fail(p, "property %T", p)
+}
+
+// pattern converts the target of a binding or assignment.
+func (e *encoder) pattern(x ast.Expr) any {
+	switch n := x.(type) {
+	case *ast.ArrayPattern:
+		elements := make([]any, 0, len(n.Elements)+1)
+		for i := range n.Elements {
+			elements = append(elements, e.optPattern(n.Elements[i].Expr))
+		}
+		if n.Rest != nil && n.Rest.Expr != nil {
+			elements = append(elements, e.rest(n.Rest.Expr))
+		}
+		return e.span("ArrayPattern", n, "elements", elements)
+	case *ast.ArrayLiteral:
+		elements := make([]any, len(n.Value))
+		for i := range n.Value {
+			elements[i] = e.optPattern(n.Value[i].Expr)
+		}
+		return e.span("ArrayPattern", n, "elements", elements)
+	case *ast.ObjectPattern:
+		props := make([]any, 0, len(n.Properties)+1)
+		for i := range n.Properties {
+			props = append(props, e.patternProperty(n.Properties[i].Prop))
+		}
+		if n.Rest != nil {
+			props = append(props, e.rest(n.Rest))
+		}
+		return e.span("ObjectPattern", n, "properties", props)
+	case *ast.ObjectLiteral:
+		props := make([]any, len(n.Value))
+		for i := range n.Value {
+			props[i] = e.patternProperty(n.Value[i].Prop)
+		}
+		return e.span("ObjectPattern", n, "properties", props)
+	case *ast.AssignExpression:
+		if n.Operator == ast.AssignmentAssign {
+			return e.span("AssignmentPattern", n,
+				"left", e.pattern(n.Left.Expr),
+				"right", e.expr(n.Right.Expr),
+			)
+		}
+	case *ast.SpreadElement:
+		return e.rest(n.Expression.Expr)
+	}
+	return e.expr(x)
+}
+
+func (e *encoder) optPattern(x ast.Expr) any {
+	if x == nil {
+		return nil
+	}
+	return e.pattern(x)
+}
+
+func (e *encoder) rest(x ast.Expr) object {
+	return e.node("RestElement", e.back(x.Idx0(), "..."), x.Idx1(), "argument", e.pattern(x))
+}
+
+func (e *encoder) patternProperty(p ast.Prop) any {
+	switch n := p.(type) {
+	case *ast.PropertyKeyed:
+		return e.node("Property", e.back(n.Idx0(), "["), n.Idx1(),
+			"key", e.key(n.Key, n.Computed),
+			"value", e.pattern(n.Value.Expr),
+			"kind", "init",
+			"method", false,
+			"shorthand", false,
+			"computed", n.Computed,
+		)
+	case *ast.SpreadElement:
+		return e.rest(n.Expression.Expr)
+	}
+	return e.property(p)
+}
+
+func (e *encoder) params(n *ast.ParameterList) []any {
+	params := make([]any, 0, len(n.List)+1)
+	for i := range n.List {
+		d := &n.List[i]
+		if d.Initializer != nil && d.Initializer.Expr != nil {
+			params = append(params, e.span("AssignmentPattern", d,
+				"left", e.pattern(d.Target.Target),
+				"right", e.expr(d.Initializer.Expr),
+			))
+			continue
+		}
+		params = append(params, e.pattern(d.Target.Target))
+	}
+	if n.Rest != nil {
+		params = append(params, e.rest(n.Rest))
+	}
+	return params
+}
+
+func (e *encoder) function(typ string, f *ast.FunctionLiteral, start ast.Idx) any {
+	if f.Lazy != nil {
+		return e.fail(f, "lazily parsed function body")
+	}
+	return e.node(typ, start, f.Idx1(),
+		"id", e.optIdent(f.Name),
+		"generator", f.Generator,
+		"async", f.Async,
+		"params", e.params(f.ParameterList),
+		"body", e.block(f.Body, true),
+	)
+}
+
+func (e *encoder) class(typ string, c *ast.ClassLiteral) object {
+	var superClass any
+	head := c.Class + 5 // "class"
+	if c.Name != nil {
+		head = c.Name.Idx1()
+	}
+	if c.SuperClass != nil && c.SuperClass.Expr != nil {
+		superClass = e.expr(c.SuperClass.Expr)
+		head = c.SuperClass.Expr.Idx1()
+	}
+
+	body := make([]any, len(c.Body))
+	for i := range c.Body {
+		body[i] = e.classElement(c, c.Body[i].Element)
+	}
+	return e.span(typ, c,
+		"id", e.optIdent(c.Name),
+		"superClass", superClass,
+		"body", e.node("ClassBody", e.forward(head, '{'), c.RightBrace+1, "body", body),
+	)
+}
+
+// classElement encodes el, an element of the class c.
+func (e *encoder) classElement(c *ast.ClassLiteral, el ast.Element) any {
+	switch n := el.(type) {
+	case *ast.MethodDefinition:
+		kind := string(n.Kind)
+		if lit, ok := n.Key.Expr.(*ast.StringLiteral); ok && !n.Static && !n.Computed &&
+			n.Kind == ast.PropertyKindMethod && lit.Value == "constructor" {
+			kind = "constructor"
+		}
+		return e.span("MethodDefinition", n,
+			"key", e.key(n.Key, n.Computed),
+			"value", e.method(n.Body),
+			"kind", kind,
+			"computed", n.Computed,
+			"static", n.Static,
+		)
+	case *ast.FieldDefinition:
+		return e.span("PropertyDefinition", n,
+			"key", e.key(n.Key, n.Computed),
+			"value", e.optExpr(n.Initializer),
+			"computed", n.Computed,
+			"static", n.Static,
+		)
+	case *ast.ClassStaticBlock:
+		return e.span("StaticBlock", n, "body", e.statements(n.Block.List, false))
+	}
+	return e.fail(c, "class element %T", el)
+}
@@ -0,0 +1,267 @@
+package estree_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/estree"
+	"github.com/t14raptor/go-fast/parser"
+)
+
+// shape decodes data and drops position members, re-encoding the rest with
+// sorted keys so it can be compared against a literal.
+func shape(t *testing.T, data []byte) string {
+	t.Helper()
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, data)
+	}
+	var strip func(v any)
+	strip = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for _, k := range []string{"start", "end", "range", "loc"} {
+				delete(v, k)
+			}
+			for _, e := range v {
+				strip(e)
+			}
+		case []any:
+			for _, e := range v {
+				strip(e)
+			}
+		}
+	}
+	strip(v)
+	out, _ := json.Marshal(v)
+	return string(out)
+}
+
+// bodyOf returns the shape of the first statement of the program parsed
+// from src.
+func bodyOf(t *testing.T, src string) string {
+	t.Helper()
+	program, err := parser.ParseFile(src)
+	if err != nil {
+		t.Fatalf("parse %q: %v", src, err)
+	}
+	data, err := estree.Marshal(program, estree.Options{Source: src})
+	if err != nil {
+		t.Fatalf("marshal %q: %v", src, err)
+	}
+	var v struct{ Body []json.RawMessage }
+	if err := json.Unmarshal(data, &v); err != nil || len(v.Body) == 0 {
+		t.Fatalf("marshal %q: bad program %s", src, data)
+	}
+	return shape(t, v.Body[0])
+}
+
+func normalize(t *testing.T, want string) string {
+	t.Helper()
+	return shape(t, []byte(want))
+}
+
+func TestMarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "optional chain",
+			src:  "a?.b();",
+			want: `{"type":"ExpressionStatement","expression":{"type":"ChainExpression","expression":
+				{"type":"CallExpression","optional":false,"arguments":[],"callee":
+					{"type":"MemberExpression","computed":false,"optional":true,
+						"object":{"type":"Identifier","name":"a"},
+						"property":{"type":"Identifier","name":"b"}}}}}`,
+		},
+		{
+			name: "shorthand properties",
+			src:  "({a, 'b': 1, [c]: 2, get d() {}});",
+			want: `{"type":"ExpressionStatement","expression":{"type":"ObjectExpression","properties":[
+				{"type":"Property","kind":"init","method":false,"shorthand":true,"computed":false,
+					"key":{"type":"Identifier","name":"a"},"value":{"type":"Identifier","name":"a"}},
+				{"type":"Property","kind":"init","method":false,"shorthand":false,"computed":false,
+					"key":{"type":"Literal","value":"b","raw":"'b'"},"value":{"type":"Literal","value":1,"raw":"1"}},
+				{"type":"Property","kind":"init","method":false,"shorthand":false,"computed":true,
+					"key":{"type":"Identifier","name":"c"},"value":{"type":"Literal","value":2,"raw":"2"}},
+				{"type":"Property","kind":"get","method":false,"shorthand":false,"computed":false,
+					"key":{"type":"Identifier","name":"d"},"value":{"type":"FunctionExpression",
+						"id":null,"generator":false,"async":false,"params":[],"body":{"type":"BlockStatement","body":[]}}}]}}`,
+		},
+		{
+			name: "binding patterns",
+			src:  "let [a = 1, , ...b] = c, {d: e, f = 2, ...g} = h;",
+			want: `{"type":"VariableDeclaration","kind":"let","declarations":[
+				{"type":"VariableDeclarator","init":{"type":"Identifier","name":"c"},"id":{"type":"ArrayPattern","elements":[
+					{"type":"AssignmentPattern","left":{"type":"Identifier","name":"a"},"right":{"type":"Literal","value":1,"raw":"1"}},
+					null,
+					{"type":"RestElement","argument":{"type":"Identifier","name":"b"}}]}},
+				{"type":"VariableDeclarator","init":{"type":"Identifier","name":"h"},"id":{"type":"ObjectPattern","properties":[
+					{"type":"Property","kind":"init","method":false,"shorthand":false,"computed":false,
+						"key":{"type":"Identifier","name":"d"},"value":{"type":"Identifier","name":"e"}},
+					{"type":"Property","kind":"init","method":false,"shorthand":true,"computed":false,
+						"key":{"type":"Identifier","name":"f"},"value":{"type":"AssignmentPattern",
+							"left":{"type":"Identifier","name":"f"},"right":{"type":"Literal","value":2,"raw":"2"}}},
+					{"type":"RestElement","argument":{"type":"Identifier","name":"g"}}]}}]}`,
+		},
+		{
+			name: "parameters",
+			src:  "(a, b = 1, ...c) => a;",
+			want: `{"type":"ExpressionStatement","expression":{"type":"ArrowFunctionExpression",
+				"id":null,"expression":true,"generator":false,"async":false,"params":[
+					{"type":"Identifier","name":"a"},
+					{"type":"AssignmentPattern","left":{"type":"Identifier","name":"b"},"right":{"type":"Literal","value":1,"raw":"1"}},
+					{"type":"RestElement","argument":{"type":"Identifier","name":"c"}}],
+				"body":{"type":"Identifier","name":"a"}}}`,
+		},
+		{
+			name: "class",
+			src:  "class A { constructor() {} static #x = 1; static {} }",
+			want: `{"type":"ClassDeclaration","id":{"type":"Identifier","name":"A"},"superClass":null,"body":{"type":"ClassBody","body":[
+				{"type":"MethodDefinition","kind":"constructor","computed":false,"static":false,
+					"key":{"type":"Identifier","name":"constructor"},"value":{"type":"FunctionExpression",
+						"id":null,"generator":false,"async":false,"params":[],"body":{"type":"BlockStatement","body":[]}}},
+				{"type":"PropertyDefinition","computed":false,"static":true,
+					"key":{"type":"PrivateIdentifier","name":"x"},"value":{"type":"Literal","value":1,"raw":"1"}},
+				{"type":"StaticBlock","body":[]}]}}`,
+		},
+		{
+			name: "tagged template",
+			src:  "t`a${b}`;",
+			want: `{"type":"ExpressionStatement","expression":{"type":"TaggedTemplateExpression",
+				"tag":{"type":"Identifier","name":"t"},"quasi":{"type":"TemplateLiteral",
+					"expressions":[{"type":"Identifier","name":"b"}],"quasis":[
+						{"type":"TemplateElement","tail":false,"value":{"raw":"a","cooked":"a"}},
+						{"type":"TemplateElement","tail":true,"value":{"raw":"","cooked":""}}]}}}`,
+		},
+		{
+			name: "literals",
+			src:  "[/a/g, 1n, null, true];",
+			want: `{"type":"ExpressionStatement","expression":{"type":"ArrayExpression","elements":[
+				{"type":"Literal","value":null,"raw":"/a/g","regex":{"pattern":"a","flags":"g"}},
+				{"type":"Literal","value":null,"raw":"1n","bigint":"1"},
+				{"type":"Literal","value":null,"raw":"null"},
+				{"type":"Literal","value":true,"raw":"true"}]}}`,
+		},
+		{
+			name: "directive",
+			src:  "'use strict';",
+			want: `{"type":"ExpressionStatement","directive":"use strict",
+				"expression":{"type":"Literal","value":"use strict","raw":"'use strict'"}}`,
+		},
+		{
+			name: "labelled loop",
+			src:  "l: for (const x of y) continue l;",
+			want: `{"type":"LabeledStatement","label":{"type":"Identifier","name":"l"},"body":
+				{"type":"ForOfStatement","await":false,
+					"left":{"type":"VariableDeclaration","kind":"const","declarations":[
+						{"type":"VariableDeclarator","id":{"type":"Identifier","name":"x"},"init":null}]},
+					"right":{"type":"Identifier","name":"y"},
+					"body":{"type":"ContinueStatement","label":{"type":"Identifier","name":"l"}}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := bodyOf(t, tt.src), normalize(t, tt.want); got != want {
+				t.Errorf("%s\ngot:  %s\nwant: %s", tt.src, got, want)
+			}
+		})
+	}
+}
+
+func TestPositions(t *testing.T) {
+	src := "'é😀';\nfoo.bar"
+	program, err := parser.ParseFile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type loc struct {
+		Start, End struct{ Line, Column int }
+	}
+	type node struct {
+		Start, End int
+		Range      []int
+		Loc        loc
+		Expression struct {
+			Start, End int
+			Loc        loc
+			Property   struct {
+				Start, End int
+				Loc        loc
+			}
+		}
+	}
+	var got struct {
+		node
+		Body []node
+	}
+
+	data, err := estree.Marshal(program, estree.Options{Source: src})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	// "é" is one UTF-16 unit, the emoji two.
+	if got.Start != 0 || got.End != 14 {
+		t.Errorf("program range = [%d, %d]; want [0, 14]", got.Start, got.End)
+	}
+	if lit := got.Body[0].Expression; lit.Start != 0 || lit.End != 5 {
+		t.Errorf("string literal range = [%d, %d]; want [0, 5]", lit.Start, lit.End)
+	}
+	prop := got.Body[1].Expression.Property
+	if prop.Start != 11 || prop.End != 14 {
+		t.Errorf("property range = [%d, %d]; want [11, 14]", prop.Start, prop.End)
+	}
+	if l := prop.Loc; l.Start.Line != 2 || l.Start.Column != 4 || l.End.Line != 2 || l.End.Column != 7 {
+		t.Errorf("property loc = %+v; want 2:4-2:7", l)
+	}
+
+	// Without the source, positions are byte offsets and there is no loc.
+	data, err = estree.Marshal(program, estree.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = struct {
+		node
+		Body []node
+	}{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	prop = got.Body[1].Expression.Property
+	if prop.Start != 14 || prop.End != 17 || prop.Loc.Start.Line != 0 {
+		t.Errorf("byte range = [%d, %d], loc %+v; want [14, 17] and no loc", prop.Start, prop.End, prop.Loc)
+	}
+}
+
+func TestMarshalUnsupported(t *testing.T) {
+	program, err := parser.ParseFileWithOptions("function f() { return 1 }", parser.Options{LazyFunctions: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := estree.Marshal(program, estree.Options{}); !errors.Is(err, estree.ErrUnsupported) {
+		t.Fatalf("lazy function: err = %v; want ErrUnsupported", err)
+	}
+
+	fn := program.Body[0].Stmt.(*ast.FunctionDeclaration).Function
+	if err := fn.ParseBody(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := estree.Marshal(program, estree.Options{}); err != nil {
+		t.Fatalf("after ParseBody: %v", err)
+	}
+
+	class := &ast.Program{Body: ast.Statements{{Stmt: &ast.ExpressionStatement{
+		Expression: &ast.Expression{Expr: &ast.ClassLiteral{Body: ast.ClassElements{{}}}},
+	}}}}
+	if _, err := estree.Marshal(class, estree.Options{}); !errors.Is(err, estree.ErrUnsupported) {
+		t.Errorf("empty class element: err = %v; want ErrUnsupported", err)
+	}
+}
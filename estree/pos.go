@@ -0,0 +1,74 @@
+package estree
+
+import (
+	"sort"
+	"unicode/utf8"
+
+	"github.com/t14raptor/go-fast/ast"
+)
+
+// positions translates byte offsets into a source text to the UTF-16
+// offsets, lines and columns reported by JavaScript tools.
+type positions struct {
+	src string
+	// lines holds the byte offset at which each line starts, and units the
+	// UTF-16 offset of the same point.
+	lines []int
+	units []int
+}
+
+func newPositions(src string) *positions {
+	p := &positions{src: src, lines: []int{0}, units: []int{0}}
+	unit := 0
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		i += size
+		unit += utf16Len(r)
+		switch r {
+		case '\r':
+			if i < len(src) && src[i] == '\n' {
+				i++
+				unit++
+			}
+		case '\n', '\u2028', '\u2029':
+		default:
+			continue
+		}
+		p.lines = append(p.lines, i)
+		p.units = append(p.units, unit)
+	}
+	return p
+}
+
+// lookup returns the UTF-16 offset of idx together with its 1-based line
+// and 0-based UTF-16 column.
+func (p *positions) lookup(idx ast.Idx) (offset, line, column int) {
+	i := min(int(idx), len(p.src))
+	line = sort.Search(len(p.lines), func(k int) bool { return p.lines[k] > i }) - 1
+	for _, r := range p.src[p.lines[line]:i] {
+		column += utf16Len(r)
+	}
+	return p.units[line] + column, line + 1, column
+}
+
+func utf16Len(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
+
+// index returns the byte offset of the UTF-16 offset unit.
+func (p *positions) index(unit int) ast.Idx {
+	line := sort.Search(len(p.units), func(k int) bool { return p.units[k] > unit }) - 1
+	if line < 0 {
+		return 0
+	}
+	i, u := p.lines[line], p.units[line]
+	for i < len(p.src) && u < unit {
+		r, size := utf8.DecodeRuneInString(p.src[i:])
+		i += size
+		u += utf16Len(r)
+	}
+	return ast.Idx(i)
+}
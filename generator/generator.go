@@ -1,6 +1,8 @@
 package generator
 
 import (
+	"errors"
+	"fmt"
 	"math"
 	"strconv"
 	"unsafe"
@@ -13,8 +15,16 @@ type Options struct {
 	// Minified disables pretty printing: no newlines, no indentation, and
 	// line (`//`) comments are omitted to keep the output on a single line.
 	Minified bool
+	// MaxDepth bounds how deeply statements and expressions may nest. Zero
+	// means no limit. Only GenerateChecked enforces it; the other functions
+	// ignore it.
+	MaxDepth int
 }
 
+// ErrMaxDepthExceeded is reported by GenerateChecked when node nests deeper
+// than Options.MaxDepth.
+var ErrMaxDepthExceeded = errors.New("maximum nesting depth exceeded")
+
 // Generate renders node as JavaScript source using the default (pretty) options.
 func Generate(node ast.VisitableNode) string {
 	return GenerateWithOptions(node, Options{})
@@ -26,14 +36,40 @@ func GenerateMinified(node ast.VisitableNode) string {
 	return GenerateWithOptions(node, Options{Minified: true})
 }
 
-// GenerateWithOptions renders node as JavaScript source using the supplied options.
+// GenerateWithOptions renders node as JavaScript source using the supplied
+// options, ignoring opts.MaxDepth.
 func GenerateWithOptions(node ast.VisitableNode, opts Options) string {
+	opts.MaxDepth = 0
+	return generate(node, opts)
+}
+
+func generate(node ast.VisitableNode, opts Options) string {
 	g := &GenVisitor{opts: opts}
 	g.V = g
 	g.gen(node)
 	return unsafe.String(unsafe.SliceData(g.buf), len(g.buf))
 }
 
+// depthExceeded is the panic value raised when Options.MaxDepth is exceeded.
+type depthExceeded struct {
+	err error
+}
+
+// GenerateChecked is like GenerateWithOptions but enforces opts.MaxDepth,
+// reporting an error when node nests deeper.
+func GenerateChecked(node ast.VisitableNode, opts Options) (out string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			d, ok := r.(depthExceeded)
+			if !ok {
+				panic(r)
+			}
+			out, err = "", d.err
+		}
+	}()
+	return generate(node, opts), nil
+}
+
 type GenVisitor struct {
 	ast.NoopVisitor
 
@@ -48,6 +84,21 @@ type GenVisitor struct {
 	ctx  context
 
 	binaryStack []binaryExprEntry
+
+	depth int
+}
+
+// enter records one more level of nesting, enforcing Options.MaxDepth.
+func (g *GenVisitor) enter() {
+	g.depth++
+	if g.opts.MaxDepth > 0 && g.depth > g.opts.MaxDepth {
+		panic(depthExceeded{err: fmt.Errorf("%w (%d)", ErrMaxDepthExceeded, g.opts.MaxDepth)})
+	}
+}
+
+// leave undoes a previous enter.
+func (g *GenVisitor) leave() {
+	g.depth--
 }
 
 func (g *GenVisitor) writeByte(c byte) {
@@ -66,10 +117,12 @@ func (g *GenVisitor) gen(node ast.VisitableNode) {
 // expression visitor reads g.prec/g.ctx to decide whether to wrap in parens,
 // and calls genExpr on children with the appropriate child precedence.
 func (g *GenVisitor) genExpr(expr ast.Expr, prec ast.Precedence, ctx context) {
+	g.enter()
 	savedPrec, savedCtx := g.prec, g.ctx
 	g.prec, g.ctx = prec, ctx
 	expr.VisitWith(g)
 	g.prec, g.ctx = savedPrec, savedCtx
+	g.leave()
 }
 
 func (g *GenVisitor) line() {
@@ -363,7 +416,17 @@ func (g *GenVisitor) VisitFunctionLiteral(n *ast.FunctionLiteral) {
 	}
 	g.gen(n.ParameterList)
 	g.space()
-	g.gen(n.Body)
+	g.genFunctionBody(n)
+}
+
+// genFunctionBody writes the body of f, copying the source of bodies that
+// were skipped by lazy parsing verbatim.
+func (g *GenVisitor) genFunctionBody(f *ast.FunctionLiteral) {
+	if f.Lazy != nil {
+		g.writeString(f.Lazy.Source)
+		return
+	}
+	g.gen(f.Body)
 }
 
 func (g *GenVisitor) VisitClassLiteral(n *ast.ClassLiteral) {
@@ -397,7 +460,7 @@ func (g *GenVisitor) VisitClassLiteral(n *ast.ClassLiteral) {
 			}
 			g.gen(e.Body.ParameterList)
 			g.space()
-			g.gen(e.Body.Body)
+			g.genFunctionBody(e.Body)
 		}
 	}
 	g.indent--
@@ -534,6 +597,14 @@ func (g *GenVisitor) VisitArrayPattern(n *ast.ArrayPattern) {
 			g.space()
 		}
 	}
+	if n.Rest != nil && n.Rest.Expr != nil {
+		if len(n.Elements) > 0 {
+			g.writeByte(',')
+			g.space()
+		}
+		g.writeString("...")
+		g.genExpr(n.Rest.Expr, ast.PrecedenceAssign, 0)
+	}
 	g.writeByte(']')
 }
 
@@ -600,6 +671,12 @@ func (g *GenVisitor) VisitBlockStatement(n *ast.BlockStatement) {
 	g.writeByte('}')
 }
 
+func (g *GenVisitor) VisitStatement(n *ast.Statement) {
+	g.enter()
+	n.VisitChildrenWith(g)
+	g.leave()
+}
+
 func (g *GenVisitor) VisitExpressionStatement(n *ast.ExpressionStatement) {
 	switch e := n.Expression.Expr.(type) {
 	case *ast.ObjectLiteral, *ast.FunctionLiteral, *ast.ClassLiteral:
@@ -931,6 +1008,10 @@ func (g *GenVisitor) VisitParameterList(n *ast.ParameterList) {
 	}
 
 	if n.Rest != nil {
+		if len(n.List) > 0 {
+			g.writeByte(',')
+			g.space()
+		}
 		g.writeString("...")
 		g.gen(n.Rest)
 	}
@@ -963,7 +1044,7 @@ func (g *GenVisitor) VisitPropertyKeyed(n *ast.PropertyKeyed) {
 		f := n.Value.Expr.(*ast.FunctionLiteral)
 		g.gen(f.ParameterList)
 		g.space()
-		g.gen(f.Body)
+		g.genFunctionBody(f)
 		return
 	}
 	if n.Computed {
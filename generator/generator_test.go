@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/t14raptor/go-fast/parser"
@@ -198,3 +199,53 @@ func TestSequenceExpressionInNewExpression(t *testing.T) {
 		})
 	}
 }
+
+func TestRestElementRegressions(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "rest parameter after parameters",
+			input: "function f(a, ...b) {}",
+			want:  "function f(a,...b){}",
+		},
+		{
+			name:  "array binding rest",
+			input: "var [x, ...y] = z;",
+			want:  "var [x,...y]=z;",
+		},
+		{
+			name:  "array binding rest after hole",
+			input: "let [, ...y] = z;",
+			want:  "let [,...y]=z;",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertMinified(t, tt.input, tt.want)
+		})
+	}
+}
+
+func TestMaxDepth(t *testing.T) {
+	p, err := parser.ParseFile("a = [[[[[1]]]]]")
+	if err != nil {
+		t.Fatalf("Failed to parse input: %v", err)
+	}
+
+	if _, err := GenerateChecked(p, Options{MaxDepth: 4}); !errors.Is(err, ErrMaxDepthExceeded) {
+		t.Errorf("GenerateChecked error = %v; want ErrMaxDepthExceeded", err)
+	}
+	got, err := GenerateChecked(p, Options{Minified: true, MaxDepth: 32})
+	if err != nil {
+		t.Fatalf("GenerateChecked: unexpected error %v", err)
+	}
+	if want := "a=[[[[[1]]]]];"; got != want {
+		t.Errorf("gen = %q; want %q", got, want)
+	}
+	if got := GenerateWithOptions(p, Options{Minified: true, MaxDepth: 4}); got != "a=[[[[[1]]]]];" {
+		t.Errorf("GenerateWithOptions = %q; want the limit ignored", got)
+	}
+}
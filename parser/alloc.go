@@ -361,9 +361,9 @@ func (a *nodeAllocator) MemberProperty(prop ast.MemberProp) *ast.MemberProperty
 	return n
 }
 
-func (a *nodeAllocator) ComputedProperty(expr *ast.Expression) *ast.ComputedProperty {
+func (a *nodeAllocator) ComputedProperty(expr *ast.Expression, rb ast.Idx) *ast.ComputedProperty {
 	n := a.compProp.make()
-	*n = ast.ComputedProperty{Expr: expr}
+	*n = ast.ComputedProperty{Expr: expr, RightBracket: rb}
 	return n
 }
 
@@ -403,7 +403,11 @@ func (p *parser) parseMethodDefinition(keyStartIdx ast.Idx, kind ast.PropertyKin
 	node := p.alloc.FunctionLiteral(keyStartIdx, async)
 	node.ParameterList = parameterList
 	node.Generator = generator
-	node.Body = p.parseFunctionBlock(async, async, generator)
+	if p.lazy && p.currentKind() == token.LeftBrace {
+		node.Body, node.Lazy = p.skipFunctionBlock(async, generator)
+	} else {
+		node.Body = p.parseFunctionBlock(async, async, generator)
+	}
 	p.scope.allowYield = savedYield
 	p.scope.allowAwait = savedAwait
 	return node
@@ -557,14 +561,17 @@ func (p *parser) parseDotMember(left ast.Expr) ast.Expr {
 func (p *parser) parseBracketMember(left ast.Expr) *ast.MemberExpression {
 	p.expect(token.LeftBracket)
 	member := p.parseExpression()
-	p.expect(token.RightBracket)
+	rb := p.expect(token.RightBracket)
 	return p.alloc.MemberExpression(
 		p.alloc.Expression(left),
-		p.alloc.MemberProperty(p.alloc.ComputedProperty(member)),
+		p.alloc.MemberProperty(p.alloc.ComputedProperty(member, rb)),
 	)
 }
 
 func (p *parser) parseNewExpression() ast.Expr {
+	p.enter()
+	defer p.leave()
+
 	idx := p.expect(token.New)
 	if p.currentKind() == token.Period {
 		p.next()
@@ -702,6 +709,9 @@ func (p *parser) parseUpdateExpression() ast.Expr {
 }
 
 func (p *parser) parseUnaryExpression() ast.Expr {
+	p.enter()
+	defer p.leave()
+
 	kind := p.currentKind()
 	if isUnaryOperator(kind) {
 		idx := p.currentOffset()
@@ -878,6 +888,9 @@ func (p *parser) parseSingleArgArrowFunction(start ast.Idx, async bool) ast.Expr
 }
 
 func (p *parser) parseAssignmentExpression() *ast.Expression {
+	p.enter()
+	defer p.leave()
+
 	start := p.currentOffset()
 	parenthesis := false
 	async := false
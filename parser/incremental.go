@@ -0,0 +1,399 @@
+package parser
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/parser/scanner/token"
+)
+
+// Edit describes a text change: the bytes in [Start, End) of the old source
+// are replaced by Text.
+type Edit struct {
+	Start, End ast.Idx
+	Text       string
+}
+
+// Apply returns src with the edit applied.
+func (e Edit) Apply(src string) string {
+	return src[:e.Start] + e.Text + src[e.End:]
+}
+
+// delta returns how far text after the edit moves.
+func (e Edit) delta() int {
+	return len(e.Text) - int(e.End-e.Start)
+}
+
+// Reparse updates program, which was parsed from src, to reflect edit. Only
+// the statements of the innermost block, function body or program that
+// enclose the edit are parsed again; positions of the untouched nodes after
+// the edit are shifted in place. If no such region can be reparsed on its
+// own the whole file is parsed from scratch. The new source is edit.Apply(src).
+//
+// program is modified and must not be used afterwards. Reparsed statements
+// are unresolved.
+func Reparse(program *ast.Program, src string, edit Edit) (*ast.Program, error) {
+	return ReparseWithOptions(program, src, edit, Options{})
+}
+
+// ReparseWithOptions is like Reparse but parses with opts, which should match
+// the options program was originally parsed with.
+func ReparseWithOptions(program *ast.Program, src string, edit Edit, opts Options) (*ast.Program, error) {
+	newSrc := edit.Apply(src)
+
+	f := &regionFinder{start: edit.Start, end: edit.End, prologue: -1}
+	f.V = f
+	prologue, strict := directives(program.Body)
+	f.ctx.strict = strict
+	f.regions = append(f.regions, region{
+		list:     &program.Body,
+		from:     0,
+		to:       ast.Idx(len(src)),
+		last:     token.Eof,
+		ctx:      f.ctx,
+		prologue: prologue,
+	})
+	program.Body.VisitWith(f)
+
+	for k := len(f.regions) - 1; k >= 0; k-- {
+		r := f.regions[k]
+		i, j, start, end := r.span(src, edit)
+		last := token.Undetermined
+		if j+1 == len(*r.list) {
+			last = r.last
+		}
+
+		list, ok := parseRegion(newSrc, start, ast.Idx(int(end)+edit.delta()), last, r.ctx, opts)
+		if !ok {
+			continue
+		}
+
+		old := *r.list
+		stmts := make(ast.Statements, 0, len(old)-(j+1-i)+len(list))
+		stmts = append(stmts, old[:i]...)
+		stmts = append(stmts, list...)
+		stmts = append(stmts, old[j+1:]...)
+		if i <= r.prologue {
+			// A "use strict" directive added or removed changes how the
+			// whole list parses.
+			_, before := directives(old)
+			if _, after := directives(stmts); after != before {
+				continue
+			}
+		}
+
+		shiftIdx(reflect.ValueOf(program), edit.End, edit.delta())
+		*r.list = stmts
+		return program, nil
+	}
+
+	return ParseFileWithOptions(newSrc, opts)
+}
+
+// regionContext is the parser state a region's statements are parsed in.
+type regionContext struct {
+	inFunction  bool
+	async       bool
+	generator   bool
+	inIteration bool
+	inSwitch    bool
+	strict      bool
+}
+
+// region is a statement list that can be reparsed on its own.
+type region struct {
+	list *ast.Statements
+	// from and to delimit the text of the list in the old source.
+	from, to ast.Idx
+	// last is the token that follows the list: `}` or the end of input.
+	last token.Token
+	ctx  regionContext
+	// prologue is the number of directives the list begins with if it is a
+	// function body or program, and -1 otherwise.
+	prologue int
+}
+
+// span returns the statements list[i:j+1] to replace for edit, together with
+// the old source range [start, end) they occupy. A statement's range runs up
+// to the start of the next one, so the edit never touches the first token of
+// a statement that is kept.
+func (r region) span(src string, edit Edit) (i, j int, start, end ast.Idx) {
+	list := *r.list
+	bound := func(k int) ast.Idx {
+		if k == len(list) {
+			return r.to
+		}
+		return list[k].Stmt.Idx0()
+	}
+
+	for i+1 < len(list) && bound(i+1) < edit.Start {
+		i++
+	}
+	// The first statement only stands on its own if the one before it is
+	// explicitly terminated; otherwise the edit may change how it ends.
+	for i > 0 && !terminated(src, list[i-1].Stmt, bound(i)) {
+		i--
+	}
+	j = i - 1
+	for j+1 < len(list) && bound(j+1) <= edit.End {
+		j++
+	}
+
+	start = bound(i)
+	if i == 0 {
+		start = r.from
+	}
+	return i, j, start, bound(j + 1)
+}
+
+// terminated reports whether stmt, whose successor starts at next, ends in a
+// way that no following token could continue it.
+func terminated(src string, stmt ast.Stmt, next ast.Idx) bool {
+	k := int(next) - 1
+	for k >= 0 && (src[k] == ' ' || src[k] == '\t' || src[k] == '\n' || src[k] == '\r') {
+		k--
+	}
+	if k < 0 {
+		return true
+	}
+	switch src[k] {
+	case ';':
+		return true
+	case '}':
+		switch stmt.(type) {
+		case *ast.BlockStatement, *ast.FunctionDeclaration, *ast.ClassDeclaration,
+			*ast.TryStatement, *ast.SwitchStatement:
+			return true
+		}
+	}
+	return false
+}
+
+// directives returns the number of directives list, a function body or
+// program, begins with, and whether one is "use strict".
+func directives(list ast.Statements) (n int, strict bool) {
+	for _, stmt := range list {
+		expr, ok := stmt.Stmt.(*ast.ExpressionStatement)
+		if !ok {
+			break
+		}
+		lit, ok := expr.Expression.Expr.(*ast.StringLiteral)
+		if !ok {
+			break
+		}
+		n++
+		strict = strict || isUseStrict(lit)
+	}
+	return n, strict
+}
+
+// errRegionBoundary reports a reparsed region that does not end where the
+// statements following it begin.
+var errRegionBoundary = errors.New("reparsed region does not end at its boundary")
+
+// parseRegion parses the statements of src in [start, end). It reports false
+// if they contain errors or do not end exactly at end, followed by last
+// unless last is token.Undetermined.
+func parseRegion(src string, start, end ast.Idx, last token.Token, ctx regionContext, opts Options) (ast.Statements, bool) {
+	p := newParser(src)
+	p.setOptions(opts)
+	list, err := p.parseRegion(start, end, last, ctx)
+	return list, err == nil
+}
+
+func (p *parser) parseRegion(start, end ast.Idx, last token.Token, ctx regionContext) (list ast.Statements, err error) {
+	defer p.catchBailout(&err)
+
+	p.openScope()
+	p.scope.inFunction = ctx.inFunction
+	p.scope.inAsync = ctx.async
+	p.scope.allowAwait = ctx.async
+	p.scope.allowYield = ctx.generator
+	p.scope.inIteration = ctx.inIteration
+	p.scope.inSwitch = ctx.inSwitch
+	p.scope.strict = ctx.strict
+
+	p.scanner.Seek(start)
+	p.next()
+	mark := len(p.stmtBuf)
+	for p.currentKind() != token.Eof && p.currentOffset() < end {
+		p.scope.allowLet = true
+		p.stmtBuf = append(p.stmtBuf, *p.parseStatement())
+	}
+	list = p.finishStmtBuf(mark)
+	p.closeScope()
+
+	if p.errors != nil {
+		return nil, p.errors
+	}
+	if p.currentOffset() != end || last != token.Undetermined && p.currentKind() != last {
+		return nil, errRegionBoundary
+	}
+	return list, nil
+}
+
+// regionFinder collects the statement lists that enclose an edit, outermost
+// first, along with the context needed to parse them.
+type regionFinder struct {
+	ast.NoopVisitor
+
+	start, end ast.Idx
+
+	ctx     regionContext
+	regions []region
+	// prologue is the prologue of the next block, if it is a function
+	// body, as for region.
+	prologue int
+}
+
+func (f *regionFinder) contains(from, to ast.Idx) bool {
+	return from <= f.start && f.end <= to
+}
+
+func (f *regionFinder) VisitStatements(n *ast.Statements) {
+	for k := range *n {
+		if (*n)[k].Stmt.Idx0() > f.end {
+			break
+		}
+		if k+1 < len(*n) && (*n)[k+1].Stmt.Idx0() <= f.start {
+			continue
+		}
+		(*n)[k].VisitWith(f)
+	}
+}
+
+func (f *regionFinder) VisitBlockStatement(n *ast.BlockStatement) {
+	prologue := f.prologue
+	f.prologue = -1
+	if !f.contains(n.LeftBrace+1, n.RightBrace) {
+		return
+	}
+	f.regions = append(f.regions, region{
+		list:     &n.List,
+		from:     n.LeftBrace + 1,
+		to:       n.RightBrace,
+		last:     token.RightBrace,
+		ctx:      f.ctx,
+		prologue: prologue,
+	})
+	n.List.VisitWith(f)
+}
+
+func (f *regionFinder) VisitFunctionLiteral(n *ast.FunctionLiteral) {
+	if n.Lazy != nil || !f.contains(n.Body.LeftBrace+1, n.Body.RightBrace) {
+		return
+	}
+	f.visitBody(n.Body, regionContext{inFunction: true, async: n.Async, generator: n.Generator})
+}
+
+func (f *regionFinder) VisitArrowFunctionLiteral(n *ast.ArrowFunctionLiteral) {
+	body, ok := n.Body.Body.(*ast.BlockStatement)
+	if !ok || !f.contains(body.LeftBrace+1, body.RightBrace) {
+		return
+	}
+	f.visitBody(body, regionContext{inFunction: true, async: n.Async})
+}
+
+// visitBody visits the body of a function, parsed in ctx and strict mode
+// code if the function is or its directives make it so.
+func (f *regionFinder) visitBody(body *ast.BlockStatement, ctx regionContext) {
+	saved := f.ctx
+	prologue, strict := directives(body.List)
+	ctx.strict = saved.strict || strict
+	f.ctx, f.prologue = ctx, prologue
+	body.VisitWith(f)
+	f.ctx = saved
+}
+
+func (f *regionFinder) VisitClassLiteral(n *ast.ClassLiteral) {
+	saved := f.ctx.strict
+	f.ctx.strict = true
+	n.VisitChildrenWith(f)
+	f.ctx.strict = saved
+}
+
+func (f *regionFinder) VisitClassStaticBlock(n *ast.ClassStaticBlock) {
+	// Static blocks have their own restrictions; reparse the enclosing class.
+}
+
+func (f *regionFinder) VisitForStatement(n *ast.ForStatement)         { f.visitLoop(n) }
+func (f *regionFinder) VisitForInStatement(n *ast.ForInStatement)     { f.visitLoop(n) }
+func (f *regionFinder) VisitForOfStatement(n *ast.ForOfStatement)     { f.visitLoop(n) }
+func (f *regionFinder) VisitWhileStatement(n *ast.WhileStatement)     { f.visitLoop(n) }
+func (f *regionFinder) VisitDoWhileStatement(n *ast.DoWhileStatement) { f.visitLoop(n) }
+
+func (f *regionFinder) visitLoop(n ast.VisitableNode) {
+	saved := f.ctx.inIteration
+	f.ctx.inIteration = true
+	n.VisitChildrenWith(f)
+	f.ctx.inIteration = saved
+}
+
+func (f *regionFinder) VisitSwitchStatement(n *ast.SwitchStatement) {
+	saved := f.ctx.inSwitch
+	f.ctx.inSwitch = true
+	n.VisitChildrenWith(f)
+	f.ctx.inSwitch = saved
+}
+
+var (
+	idxType     = reflect.TypeFor[ast.Idx]()
+	funcLitType = reflect.TypeFor[ast.FunctionLiteral]()
+	astPkgPath  = idxType.PkgPath()
+)
+
+// shiftIdx adds delta to every position at or after from in the tree rooted
+// at v.
+func shiftIdx(v reflect.Value, from ast.Idx, delta int) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			shiftIdx(v.Elem(), from, delta)
+		}
+	case reflect.Slice:
+		if v.Type().Elem().PkgPath() != astPkgPath {
+			return
+		}
+		for i := range v.Len() {
+			shiftIdx(v.Index(i), from, delta)
+		}
+	case reflect.Struct:
+		if v.Type().PkgPath() != astPkgPath {
+			return
+		}
+		if v.Type() == funcLitType {
+			shiftLazyBody(v.Addr().Interface().(*ast.FunctionLiteral), from, delta)
+		}
+		for i := range v.NumField() {
+			field := v.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+			if field.Type() == idxType {
+				if idx := ast.Idx(field.Uint()); idx >= from {
+					field.SetUint(uint64(int(idx) + delta))
+				}
+				continue
+			}
+			shiftIdx(field, from, delta)
+		}
+	}
+}
+
+// shiftLazyBody makes a lazily parsed body that moves by delta produce nodes
+// at its new position once parsed.
+func shiftLazyBody(f *ast.FunctionLiteral, from ast.Idx, delta int) {
+	if f.Lazy == nil || f.Body.LeftBrace < from {
+		return
+	}
+	lazy := f.Lazy
+	f.Lazy = ast.NewLazyBody(lazy.Source, func() (*ast.BlockStatement, error) {
+		fn := &ast.FunctionLiteral{Lazy: lazy}
+		if err := fn.ParseBody(); err != nil {
+			return nil, err
+		}
+		shiftIdx(reflect.ValueOf(fn.Body), 0, delta)
+		return fn.Body, nil
+	})
+}
@@ -0,0 +1,200 @@
+package parser
+
+import (
+	"errors"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/parser/scanner/token"
+)
+
+// braceKind records what an open brace belongs to while skipping a body.
+type braceKind uint8
+
+const (
+	braceBlock braceKind = iota
+	braceObject
+	braceTemplate
+	// braceFunction opens the body of a function expression, which ends the
+	// expression as an object literal does.
+	braceFunction
+)
+
+// parenKind records what an open parenthesis belongs to while skipping a
+// body.
+type parenKind uint8
+
+const (
+	parenGroup parenKind = iota
+	// parenHead encloses the head of an if, while, for or with statement.
+	parenHead
+	// parenParams encloses the parameters of a function expression.
+	parenParams
+)
+
+// skipFunctionBlock scans over a function body without building its AST. It
+// returns an empty block spanning the braces along with a LazyBody that parses
+// the body on demand.
+func (p *parser) skipFunctionBlock(async, allowYield bool) (*ast.BlockStatement, *ast.LazyBody) {
+	node := p.alloc.BlockStatement()
+	node.LeftBrace = p.currentOffset()
+	node.RightBrace = p.skipBraces()
+	p.next()
+
+	src := p.str
+	start, end := node.LeftBrace, node.RightBrace+1
+	maxDepth, lazy, strict := p.limits.maxDepth, p.lazy, p.scope.strict
+	parse := func() (*ast.BlockStatement, error) {
+		q := newParser(src[:end])
+		q.limits.maxDepth = maxDepth
+		q.lazy = lazy
+		q.scanner.Seek(start)
+		return q.parseLazyBody(async, allowYield, strict)
+	}
+	return node, ast.NewLazyBody(src[start:end], parse)
+}
+
+// parseLazyBody parses a function body previously skipped by skipFunctionBlock,
+// in strict mode code if strict is set.
+func (p *parser) parseLazyBody(async, allowYield, strict bool) (body *ast.BlockStatement, err error) {
+	defer p.catchBailout(&err)
+
+	p.openScope()
+	p.scope.strict = strict
+	p.next()
+	body = p.parseFunctionBlock(async, async, allowYield)
+	p.closeScope()
+	return body, p.errors
+}
+
+// skipBraces advances from the current `{` to its matching `}` and returns
+// the offset of the latter, aborting the parse if the input ends first.
+// Regular expressions are told apart from divisions by the token preceding
+// the slash, with braces and parentheses classified by what opened them.
+func (p *parser) skipBraces() ast.Idx {
+	braces := []braceKind{braceBlock}
+	var parens []parenKind
+	// function is set between a function expression's keyword and its
+	// parameters, and params right after its parameters.
+	var function, params bool
+
+	// prev is the kind of the previous token, with keywords used as names
+	// turned into identifiers, and before the one preceding it. async is set
+	// if the previous token was async.
+	prev, before := token.LeftBrace, token.Undetermined
+	var async bool
+	for {
+		p.next()
+		kind := p.currentKind()
+		isAsync := kind == token.Async
+		switch {
+		case (prev == token.Period || prev == token.QuestionDot) && kind >= token.Identifier && kind <= token.Yield:
+			// A keyword after a dot is a property name, as in a.return.
+			kind = token.Identifier
+		case kind == token.Of || kind == token.Identifier && !p.scanner.Token.HasEscape && p.currentString() == "of":
+			// of separates the head of a for-of statement after its target,
+			// and is a name anywhere else.
+			kind = token.Identifier
+			if n := len(parens); n > 0 && parens[n-1] == parenHead && token.EndsExpression(prev) {
+				kind = token.Of
+			}
+		case kind == token.Let || kind == token.Static || kind == token.Async:
+			// Other contextual keywords are names wherever a slash may
+			// follow them.
+			kind = token.Identifier
+		}
+		afterParams := params
+		params = false
+		switch kind {
+		case token.Eof:
+			p.abort(errors.New(errUnexpectedEndOfInput))
+		case token.Function:
+			at := prev
+			if async {
+				at = before
+			}
+			function = startsExpression(at, braces[len(braces)-1])
+		case token.LeftBrace:
+			if afterParams {
+				braces = append(braces, braceFunction)
+			} else {
+				braces = append(braces, classifyBrace(prev, braces[len(braces)-1]))
+			}
+		case token.TemplateHead:
+			braces = append(braces, braceTemplate)
+		case token.RightBrace:
+			top := braces[len(braces)-1]
+			braces = braces[:len(braces)-1]
+			switch {
+			case top == braceTemplate:
+				p.scanner.NextTemplatePart()
+				kind = p.currentKind()
+				if kind == token.TemplateMiddle {
+					braces = append(braces, braceTemplate)
+				}
+			case len(braces) == 0:
+				return p.currentOffset()
+			case top == braceObject || top == braceFunction:
+				// An object literal or function expression ends an
+				// expression.
+				kind = token.RightParenthesis
+			default:
+				// A block ends a statement.
+				kind = token.Semicolon
+			}
+		case token.LeftParenthesis:
+			switch {
+			case function:
+				parens = append(parens, parenParams)
+				function = false
+			case prev == token.If || prev == token.While || prev == token.For || prev == token.With,
+				prev == token.Await && before == token.For:
+				parens = append(parens, parenHead)
+			default:
+				parens = append(parens, parenGroup)
+			}
+		case token.RightParenthesis:
+			if n := len(parens); n > 0 {
+				switch parens[n-1] {
+				case parenHead:
+					kind = token.Semicolon
+				case parenParams:
+					params = true
+				}
+				parens = parens[:n-1]
+			}
+		case token.Slash, token.QuotientAssign:
+			if !token.EndsExpression(prev) {
+				p.scanner.ParseRegExp()
+				kind = token.String
+			}
+		}
+		prev, before, async = kind, prev, isAsync
+	}
+}
+
+// startsExpression reports whether a token following prev, inside a brace of
+// kind enclosing, begins an expression rather than a statement.
+func startsExpression(prev token.Token, enclosing braceKind) bool {
+	return prev == token.Arrow || classifyBrace(prev, enclosing) == braceObject
+}
+
+// classifyBrace decides whether a `{` following prev opens an object literal
+// or pattern, or a block, given the kind of the enclosing brace.
+func classifyBrace(prev token.Token, enclosing braceKind) braceKind {
+	if prev >= token.Plus && prev <= token.GreaterOrEqual && prev != token.Increment && prev != token.Decrement {
+		return braceObject
+	}
+	switch prev {
+	case token.LeftParenthesis, token.LeftBracket, token.Comma, token.QuestionMark, token.Ellipsis,
+		token.TemplateHead, token.TemplateMiddle,
+		token.Return, token.Throw, token.Case, token.Typeof, token.Void, token.Delete,
+		token.In, token.InstanceOf, token.New, token.Yield, token.Await,
+		token.Var, token.Const:
+		return braceObject
+	case token.Colon:
+		if enclosing == braceObject {
+			return braceObject
+		}
+	}
+	return braceBlock
+}
@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DefaultMaxDepth is the nesting limit used by ParseFile and by
+// ParseFileWithOptions when Options.MaxDepth is zero.
+const DefaultMaxDepth = 10000
+
+var (
+	// ErrMaxDepthExceeded is reported when the input nests statements or
+	// expressions deeper than the configured limit.
+	ErrMaxDepthExceeded = errors.New("maximum nesting depth exceeded")
+	// ErrTokenLimitExceeded is reported when the input contains more tokens
+	// than the configured budget.
+	ErrTokenLimitExceeded = errors.New("token limit exceeded")
+)
+
+// Options configures ParseFileWithOptions.
+type Options struct {
+	// MaxDepth bounds how deeply statements and expressions may nest. Zero
+	// selects DefaultMaxDepth and a negative value disables the check.
+	MaxDepth int
+	// MaxTokens bounds the number of tokens scanned. Zero means unlimited.
+	MaxTokens int
+	// Context, when non-nil, aborts parsing as soon as it is done.
+	Context context.Context
+	// LazyFunctions skips the bodies of function declarations, expressions
+	// and methods, only scanning them to find where they end. See
+	// ast.FunctionLiteral.ParseBody.
+	LazyFunctions bool
+}
+
+// ctxCheckInterval is the number of tokens scanned between two checks of
+// the parser's context.
+const ctxCheckInterval = 1024
+
+// limits holds the resource bounds of a single parse.
+type limits struct {
+	depth    int
+	maxDepth int
+
+	tokens    int
+	maxTokens int
+
+	ctx context.Context
+}
+
+// bailout is the panic value used to unwind the parser once a limit is hit.
+type bailout struct {
+	err error
+}
+
+func (p *parser) setOptions(opts Options) {
+	switch {
+	case opts.MaxDepth == 0:
+		p.limits.maxDepth = DefaultMaxDepth
+	case opts.MaxDepth > 0:
+		p.limits.maxDepth = opts.MaxDepth
+	}
+	p.limits.maxTokens = opts.MaxTokens
+	p.limits.ctx = opts.Context
+	p.lazy = opts.LazyFunctions
+}
+
+// enter records one more level of nesting and aborts the parse if it goes
+// past the configured maximum. Every call must be paired with leave.
+func (p *parser) enter() {
+	p.limits.depth++
+	if p.limits.maxDepth > 0 && p.limits.depth > p.limits.maxDepth {
+		p.abort(fmt.Errorf("%w (%d) at offset %d", ErrMaxDepthExceeded, p.limits.maxDepth, p.currentOffset()))
+	}
+}
+
+// leave undoes a previous enter.
+func (p *parser) leave() {
+	p.limits.depth--
+}
+
+// tick accounts for a scanned token, enforcing the token budget and
+// periodically checking the context.
+func (p *parser) tick() {
+	p.limits.tokens++
+	if p.limits.maxTokens > 0 && p.limits.tokens > p.limits.maxTokens {
+		p.abort(fmt.Errorf("%w (%d)", ErrTokenLimitExceeded, p.limits.maxTokens))
+	}
+	if p.limits.ctx != nil && p.limits.tokens%ctxCheckInterval == 0 {
+		if err := p.limits.ctx.Err(); err != nil {
+			p.abort(err)
+		}
+	}
+}
+
+// abort stops the parse immediately with err.
+func (p *parser) abort(err error) {
+	panic(bailout{err: err})
+}
+
+// catchBailout recovers a bailout raised by abort and stores its error in
+// *err. Any other panic is propagated.
+func (p *parser) catchBailout(err *error) {
+	if r := recover(); r != nil {
+		b, ok := r.(bailout)
+		if !ok {
+			panic(r)
+		}
+		*err = errors.Join(p.errors, b.err)
+	}
+}
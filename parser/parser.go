@@ -23,6 +23,11 @@ type parser struct {
 
 	alloc nodeAllocator
 
+	limits limits
+
+	// lazy enables skipping function bodies, see lazy.go.
+	lazy bool
+
 	// Scratch buffers used as a stack for building Expression/Statement
 	// slices without per-call heap allocations. Each builder saves
 	// len(buf) as a mark, appends elements, copies the subslice to the
@@ -53,14 +58,25 @@ func newParser(src string) *parser {
 // ParseFile parses the source code of a single JavaScript/ECMAScript source file and returns
 // the corresponding ast.Program node.
 func ParseFile(src string) (*ast.Program, error) {
-	return newParser(src).parse()
+	return ParseFileWithOptions(src, Options{})
+}
+
+// ParseFileWithOptions is like ParseFile but enforces the nesting, token and
+// cancellation limits in opts. When a limit is hit parsing stops immediately
+// and a nil program is returned together with the error.
+func ParseFileWithOptions(src string, opts Options) (*ast.Program, error) {
+	p := newParser(src)
+	p.setOptions(opts)
+	return p.parse()
 }
 
 // parse ...
-func (p *parser) parse() (*ast.Program, error) {
+func (p *parser) parse() (program *ast.Program, err error) {
+	defer p.catchBailout(&err)
+
 	p.openScope()
 	p.next()
-	program := p.parseProgram()
+	program = p.parseProgram()
 	p.closeScope()
 	return program, p.errors
 }
@@ -68,6 +84,7 @@ func (p *parser) parse() (*ast.Program, error) {
 // next ...
 func (p *parser) next() {
 	p.scanner.Next()
+	p.tick()
 }
 
 type parserState struct {
@@ -188,7 +205,7 @@ func (p *parser) finishElemBuf(mark int) ast.ClassElements {
 }
 
 func (p *parser) expect(value token.Token) ast.Idx {
-	idx := p.scanner.Offset()
+	idx := p.currentOffset()
 	if p.scanner.Token.Kind != value {
 		p.errorUnexpectedToken(p.scanner.Token.Kind)
 	}
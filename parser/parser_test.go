@@ -1,7 +1,10 @@
 package parser_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"testing"
 
@@ -1696,6 +1699,54 @@ func TestMemberCallChainAST(t *testing.T) {
 	}
 }
 
+func TestComputedMemberSpan(t *testing.T) {
+	// The span ends at the closing bracket, wherever it is.
+	cases := []string{
+		"a[b]",
+		"a[ b ]",
+		"a[b /* c */]",
+		"a[\n\tb\n]",
+		"a.b[ c ]",
+	}
+	for _, code := range cases {
+		expr := exprOf(firstStmt(mustParse(t, code), 0))
+		if got := int(expr.Idx1() - expr.Idx0()); got != len(code) {
+			t.Errorf("%q: span length = %d; want %d", code, got, len(code))
+		}
+	}
+}
+
+func TestStatementSpan(t *testing.T) {
+	// The span ends at the closing token, wherever it is.
+	cases := []string{
+		"do x; while (a )",
+		"do x; while (a /* b */)",
+		"switch (a) {}",
+		"switch (a) { case 1 : }",
+		"switch (a) { default : }",
+		"switch (a) {\n\tcase 1: b\n}",
+	}
+	for _, code := range cases {
+		stmt := firstStmt(mustParse(t, code), 0)
+		if got := int(stmt.Idx1() - stmt.Idx0()); got != len(code) {
+			t.Errorf("%q: span length = %d; want %d", code, got, len(code))
+		}
+	}
+
+	// A case without statements ends at its colon.
+	for _, code := range []string{"case 1 :", "default :", "case (a) /* b */:"} {
+		s := firstStmt(mustParse(t, "switch (a) { "+code+" }"), 0).(*ast.SwitchStatement)
+		c := s.Body[0]
+		if got := int(c.Idx1() - c.Idx0()); got != len(code) {
+			t.Errorf("%q: case span length = %d; want %d", code, got, len(code))
+		}
+	}
+
+	if _, err := parser.ParseFile("switch (a) { case 1:"); err == nil {
+		t.Error("unterminated switch: expected an error")
+	}
+}
+
 func TestOptionalChainSyntax(t *testing.T) {
 	cases := []string{
 		"a?.b",
@@ -2068,3 +2119,279 @@ func TestYieldExpressionAST(t *testing.T) {
 		t.Error("yield* should be delegate")
 	}
 }
+
+func TestNestingLimit(t *testing.T) {
+	tests := []string{
+		strings.Repeat("[", 100000),
+		strings.Repeat("(", 100000) + "x",
+		strings.Repeat("!", 100000) + "x",
+		strings.Repeat("new ", 100000) + "x",
+		strings.Repeat("{", 100000),
+		strings.Repeat("x => ", 100000) + "x",
+	}
+	for _, code := range tests {
+		p, err := parser.ParseFile(code)
+		if !errors.Is(err, parser.ErrMaxDepthExceeded) {
+			t.Errorf("parse(%.8q...) error = %v; want ErrMaxDepthExceeded", code, err)
+		}
+		if p != nil {
+			t.Errorf("parse(%.8q...) returned a program", code)
+		}
+	}
+
+	code := strings.Repeat("[", 50) + strings.Repeat("]", 50)
+	if _, err := parser.ParseFileWithOptions(code, parser.Options{MaxDepth: 20}); !errors.Is(err, parser.ErrMaxDepthExceeded) {
+		t.Errorf("MaxDepth 20: error = %v; want ErrMaxDepthExceeded", err)
+	}
+	if _, err := parser.ParseFileWithOptions(code, parser.Options{MaxDepth: -1}); err != nil {
+		t.Errorf("unlimited depth: unexpected error %v", err)
+	}
+}
+
+func TestTokenLimit(t *testing.T) {
+	code := strings.Repeat("a;", 100)
+	if _, err := parser.ParseFileWithOptions(code, parser.Options{MaxTokens: 50}); !errors.Is(err, parser.ErrTokenLimitExceeded) {
+		t.Errorf("error = %v; want ErrTokenLimitExceeded", err)
+	}
+	if _, err := parser.ParseFileWithOptions(code, parser.Options{MaxTokens: 1000}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestParseCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	code := strings.Repeat("a;", 10000)
+	if _, err := parser.ParseFileWithOptions(code, parser.Options{Context: ctx}); !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v; want context.Canceled", err)
+	}
+}
+
+func TestLazyFunctions(t *testing.T) {
+	code := "var f = function(x) { if (x) /}/.test(x); return `${ {a: 1}.a }}` / 2 }\n" +
+		"class C { m() { return {} / 1 } }\n" +
+		"function g() { return function h() { return /\\/{/ } }"
+
+	eager := mustParse(t, code)
+	lazy, err := parser.ParseFileWithOptions(code, parser.Options{LazyFunctions: true})
+	if err != nil {
+		t.Fatalf("lazy parse: %v", err)
+	}
+	if got := generator.Generate(lazy); !strings.Contains(got, "return `${ {a: 1}.a }}` / 2") {
+		t.Errorf("lazy body not copied verbatim:\n%s", got)
+	}
+
+	var fns []*ast.FunctionLiteral
+	f := initializerExpr(firstStmt(lazy, 0)).(*ast.FunctionLiteral)
+	m := firstStmt(lazy, 1).(*ast.ClassDeclaration).Class.Body[0].Element.(*ast.MethodDefinition).Body
+	g := firstStmt(lazy, 2).(*ast.FunctionDeclaration).Function
+	fns = append(fns, f, m, g)
+	for _, fn := range fns {
+		if !fn.IsLazy() {
+			t.Fatalf("function at %d was parsed eagerly", fn.Idx0())
+		}
+		if err := fn.ParseBody(); err != nil {
+			t.Fatalf("ParseBody: %v", err)
+		}
+	}
+
+	h := g.Body.List[0].Stmt.(*ast.ReturnStatement).Argument.Expr.(*ast.FunctionLiteral)
+	if !h.IsLazy() {
+		t.Fatal("nested function should stay lazy")
+	}
+	if err := h.ParseBody(); err != nil {
+		t.Fatalf("ParseBody: %v", err)
+	}
+
+	if got, want := generator.Generate(lazy), generator.Generate(eager); got != want {
+		t.Errorf("lazy parse = %q; want %q", got, want)
+	}
+}
+
+func TestLazyFunctionsKeywordProperties(t *testing.T) {
+	for _, code := range []string{
+		"function f() { x = a.return / 2 }",
+		"function f() { x = a.typeof / 2 }",
+		"function f() { x = a?.in / 2; a?.(b); }",
+		"function f() { x = function(){} / 2; }",
+		"function f() { x = async function(){} / 2; }",
+		"function f() { x = y => function(){} / 2; }",
+		"function f() { function g(){} /}/.test(x); }",
+		"function f() { for (x of /}/g) ; }",
+		"async function f() { for await (x of /}/g) ; }",
+		"function f() { for (const {of} of /}/g) ; }",
+		"function f() { var of = 4, let = 2; x = of / 2 + let / 2; }",
+	} {
+		lazy, err := parser.ParseFileWithOptions(code, parser.Options{LazyFunctions: true})
+		if err != nil {
+			t.Fatalf("%s: lazy parse: %v", code, err)
+		}
+		fn := firstStmt(lazy, 0).(*ast.FunctionDeclaration).Function
+		if err := fn.ParseBody(); err != nil {
+			t.Fatalf("%s: ParseBody: %v", code, err)
+		}
+		if got, want := generator.Generate(lazy), generator.Generate(mustParse(t, code)); got != want {
+			t.Errorf("lazy parse = %q; want %q", got, want)
+		}
+	}
+}
+
+func TestLazyFunctionsTruncated(t *testing.T) {
+	for _, code := range []string{
+		"function f() {",
+		"function f() { if (x) {",
+		"function f() { return `${",
+		"var g = function () { /x/",
+	} {
+		if _, err := parser.ParseFileWithOptions(code, parser.Options{LazyFunctions: true}); err == nil {
+			t.Errorf("%q: expected an error", code)
+		}
+	}
+}
+
+func TestLazyFunctionsStrict(t *testing.T) {
+	for _, tt := range []struct {
+		code   string
+		strict bool
+	}{
+		{"function f() { with (o) x; }", false},
+		{"'use strict'; function f() { with (o) x; }", true},
+		{"function g() { 'use strict'; var f = function() { with (o) x; }; }", true},
+		{"class C { m() { with (o) x; } }", true},
+		{"function f() { with (o) x; } 'use strict';", false},
+	} {
+		_, err := parser.ParseFile(tt.code)
+		if (err != nil) != tt.strict {
+			t.Errorf("%q: ParseFile error = %v; want an error %v", tt.code, err, tt.strict)
+		}
+		lazy, err := parser.ParseFileWithOptions(tt.code, parser.Options{LazyFunctions: true})
+		if err != nil {
+			t.Fatalf("%q: lazy parse: %v", tt.code, err)
+		}
+		var bodyErr error
+		ast.Inspect(lazy, func(n ast.VisitableNode) bool {
+			if fn, ok := n.(*ast.FunctionLiteral); ok && fn.IsLazy() {
+				bodyErr = errors.Join(bodyErr, fn.ParseBody())
+			}
+			return true
+		})
+		if (bodyErr != nil) != tt.strict {
+			t.Errorf("%q: ParseBody error = %v; want an error %v", tt.code, bodyErr, tt.strict)
+		}
+	}
+}
+
+func TestLazyFunctionsClone(t *testing.T) {
+	lazy, err := parser.ParseFileWithOptions("function f() { g(); }", parser.Options{LazyFunctions: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn := firstStmt(lazy, 0).(*ast.FunctionDeclaration).Function
+	clone := fn.Clone()
+	if err := clone.ParseBody(); err != nil {
+		t.Fatal(err)
+	}
+	if !fn.IsLazy() || clone.IsLazy() {
+		t.Errorf("IsLazy = %v, clone %v; want true, false", fn.IsLazy(), clone.IsLazy())
+	}
+}
+
+// identCollector records the name, scope context and position of every
+// identifier.
+type identCollector struct {
+	ast.NoopVisitor
+	idents []string
+}
+
+func (c *identCollector) VisitIdentifier(n *ast.Identifier) {
+	c.idents = append(c.idents, fmt.Sprintf("%s#%d@%d", n.Name, n.ScopeContext, n.Idx))
+}
+
+func identsOf(p *ast.Program) []string {
+	c := &identCollector{}
+	c.V = c
+	p.VisitWith(c)
+	return c.idents
+}
+
+// spansOf returns the spans of the statements and expressions of p, in
+// visiting order.
+func spansOf(p *ast.Program) [][2]ast.Idx {
+	var spans [][2]ast.Idx
+	ast.Inspect(p, func(n ast.VisitableNode) bool {
+		switch n := n.(type) {
+		case ast.Stmt:
+			spans = append(spans, [2]ast.Idx{n.Idx0(), n.Idx1()})
+		case ast.Expr:
+			spans = append(spans, [2]ast.Idx{n.Idx0(), n.Idx1()})
+		}
+		return true
+	})
+	return spans
+}
+
+func TestReparse(t *testing.T) {
+	code := `var a = 1;
+function f(x) {
+	if (x) {
+		return x + a;
+	}
+	for (;;) { break }
+	a[ x /* key */ ] = x
+	return g(x)
+}
+let y = f(2)
+y++
+function h() {
+	'use strict';
+	h()
+}`
+
+	tests := []struct {
+		name        string
+		find        string
+		text        string
+		incremental bool
+	}{
+		{"inside block", "x + a", "x * a + 1", true},
+		{"new statement", "return g(x)", "x++; return g(x)", true},
+		{"break in loop", "break", "continue", true},
+		{"asi join", "y++", "(y)", true},
+		{"top level", "var a = 1", "var a = 10, b", true},
+		{"unbalanced", "if (x) {", "if (x) {{", false},
+		{"delete", "\n\treturn g(x)", "", true},
+		{"with in strict code", "h()", "with (y) h()", false},
+		{"remove directive", "'use strict';", "", true},
+		{"add directive", "\n\tif (x) {", "'use strict'; with (x) {", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := strings.Index(code, tt.find)
+			edit := parser.Edit{Start: ast.Idx(start), End: ast.Idx(start + len(tt.find)), Text: tt.text}
+			newCode := edit.Apply(code)
+
+			want, wantErr := parser.ParseFile(newCode)
+			old := mustParse(t, code)
+			got, err := parser.Reparse(old, code, edit)
+			if (err != nil) != (wantErr != nil) {
+				t.Fatalf("Reparse error = %v; want %v", err, wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if incremental := got == old; incremental != tt.incremental {
+				t.Errorf("incremental = %v; want %v", incremental, tt.incremental)
+			}
+			if g, w := generator.Generate(got), generator.Generate(want); g != w {
+				t.Errorf("Reparse =\n%s\nwant\n%s", g, w)
+			}
+			if g, w := identsOf(got), identsOf(want); !slices.Equal(g, w) {
+				t.Errorf("identifiers = %v; want %v", g, w)
+			}
+			if g, w := spansOf(got), spansOf(want); !slices.Equal(g, w) {
+				t.Errorf("spans = %v; want %v", g, w)
+			}
+		})
+	}
+}
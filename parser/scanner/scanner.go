@@ -69,6 +69,12 @@ func (s *Scanner) Rewind(c Checkpoint) {
 	*s.errors = c.errors
 }
 
+// Seek moves the scanner to pos. The current token is left unchanged until
+// the next call to Next.
+func (s *Scanner) Seek(pos ast.Idx) {
+	s.src.SetPosition(pos)
+}
+
 func (s *Scanner) Offset() ast.Idx {
 	return s.src.Offset()
 }
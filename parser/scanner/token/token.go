@@ -37,6 +37,19 @@ func ID(token Token) bool {
 	return token >= Identifier
 }
 
+// EndsExpression reports whether a token of kind can end an expression, in
+// which case a following slash is a division rather than a regular expression.
+func EndsExpression(kind Token) bool {
+	switch kind {
+	case Identifier, Keyword, EscapedReservedWord, PrivateIdentifier,
+		Number, String, Boolean, Null, This, Super,
+		NoSubstitutionTemplate, TemplateTail,
+		RightParenthesis, RightBracket, Increment, Decrement:
+		return true
+	}
+	return false
+}
+
 // UnreservedWord ...
 func UnreservedWord(token Token) bool {
 	return token > EscapedReservedWord
@@ -11,6 +11,8 @@ type scope struct {
 	inAsync      bool
 	allowAwait   bool
 	allowYield   bool
+	// strict is set in strict mode code.
+	strict bool
 
 	labels []string
 }
@@ -20,6 +22,7 @@ func (p *parser) openScope() {
 	*s = scope{
 		outer:   p.scope,
 		allowIn: true,
+		strict:  p.scope != nil && p.scope.strict,
 	}
 	p.scope = s
 }
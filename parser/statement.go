@@ -8,7 +8,7 @@ import (
 func (p *parser) parseBlockStatement() *ast.BlockStatement {
 	node := p.alloc.BlockStatement()
 	node.LeftBrace = p.expect(token.LeftBrace)
-	node.List = p.parseStatementList()
+	node.List = p.parseStatementList(false)
 	node.RightBrace = p.expect(token.RightBrace)
 
 	return node
@@ -19,17 +19,55 @@ func (p *parser) parseEmptyStatement() ast.Stmt {
 	return p.alloc.EmptyStatement(idx)
 }
 
-func (p *parser) parseStatementList() (list ast.Statements) {
+// parseStatementList parses the statements of a block, or of a function body
+// if directives is set.
+func (p *parser) parseStatementList(directives bool) (list ast.Statements) {
 	mark := len(p.stmtBuf)
 	for p.currentKind() != token.RightBrace && p.currentKind() != token.Eof {
-		p.scope.allowLet = true
-		p.stmtBuf = append(p.stmtBuf, *p.parseStatement())
+		directives = p.parseListStatement(directives)
 	}
 
 	return p.finishStmtBuf(mark)
 }
 
+// parseListStatement parses the next statement of a list onto stmtBuf.
+// directives tells whether the list is still in its directive prologue, and
+// the result whether it is after the statement.
+func (p *parser) parseListStatement(directives bool) bool {
+	p.scope.allowLet = true
+	directives = directives && p.currentKind() == token.String
+	stmt := p.parseStatement()
+	p.stmtBuf = append(p.stmtBuf, *stmt)
+	return directives && p.directive(stmt.Stmt)
+}
+
+// directive applies stmt, which begins with a string, as a directive,
+// reporting false if it is not one and so ends the directive prologue.
+func (p *parser) directive(stmt ast.Stmt) bool {
+	expr, ok := stmt.(*ast.ExpressionStatement)
+	if !ok {
+		return false
+	}
+	lit, ok := expr.Expression.Expr.(*ast.StringLiteral)
+	if !ok {
+		return false
+	}
+	if isUseStrict(lit) {
+		p.scope.strict = true
+	}
+	return true
+}
+
+// isUseStrict reports whether lit is a "use strict" directive, which must be
+// written without escapes.
+func isUseStrict(lit *ast.StringLiteral) bool {
+	return lit.Raw != nil && (*lit.Raw == `"use strict"` || *lit.Raw == `'use strict'`)
+}
+
 func (p *parser) parseStatement() *ast.Statement {
+	p.enter()
+	defer p.leave()
+
 	tok := p.currentKind()
 	if tok == token.Eof {
 		p.errorUnexpectedToken(tok)
@@ -212,7 +250,11 @@ func (p *parser) parseFunction(declaration, async bool, start ast.Idx) *ast.Func
 	}
 
 	node.ParameterList = p.parseFunctionParameterList()
-	node.Body = p.parseFunctionBlock(async, async, p.scope.allowYield)
+	if p.lazy && p.currentKind() == token.LeftBrace {
+		node.Body, node.Lazy = p.skipFunctionBlock(async, p.scope.allowYield)
+	} else {
+		node.Body = p.parseFunctionBlock(async, async, p.scope.allowYield)
+	}
 
 	p.scope.allowAwait = savedAwait
 	p.scope.allowYield = savedYield
@@ -225,7 +267,10 @@ func (p *parser) parseFunctionBlock(async, allowAwait, allowYield bool) *ast.Blo
 	p.scope.inAsync = async
 	p.scope.allowAwait = allowAwait
 	p.scope.allowYield = allowYield
-	body := p.parseBlockStatement()
+	body := p.alloc.BlockStatement()
+	body.LeftBrace = p.expect(token.LeftBrace)
+	body.List = p.parseStatementList(true)
+	body.RightBrace = p.expect(token.RightBrace)
 	p.closeScope()
 	return body
 }
@@ -258,6 +303,11 @@ func (p *parser) parseClass(declaration bool) *ast.ClassLiteral {
 
 	node := p.alloc.ClassLiteral(p.expect(token.Class))
 
+	// All parts of a class are strict mode code.
+	strict := p.scope.strict
+	p.scope.strict = true
+	defer func() { p.scope.strict = strict }()
+
 	p.tokenToBindingId()
 	name := p.alloc.Identifier(0, "")
 	if p.currentKind() == token.Identifier {
@@ -435,9 +485,10 @@ func (p *parser) parseThrowStatement() ast.Stmt {
 }
 
 func (p *parser) parseSwitchStatement() ast.Stmt {
-	p.expect(token.Switch)
+	idx := p.expect(token.Switch)
 	p.expect(token.LeftParenthesis)
 	node := p.alloc.SwitchStatement(p.parseExpression())
+	node.Switch = idx
 	p.expect(token.RightParenthesis)
 
 	p.expect(token.LeftBrace)
@@ -445,12 +496,7 @@ func (p *parser) parseSwitchStatement() ast.Stmt {
 	inSwitch := p.scope.inSwitch
 	p.scope.inSwitch = true
 
-	for index := 0; p.currentKind() != token.Eof; index++ {
-		if p.currentKind() == token.RightBrace {
-			p.next()
-			break
-		}
-
+	for index := 0; p.currentKind() != token.RightBrace && p.currentKind() != token.Eof; index++ {
 		clause := p.parseCaseStatement()
 		if clause.Test == nil {
 			if node.Default != -1 {
@@ -460,15 +506,20 @@ func (p *parser) parseSwitchStatement() ast.Stmt {
 		}
 		node.Body = append(node.Body, clause)
 	}
+	node.RightBrace = p.expect(token.RightBrace)
 
 	p.scope.inSwitch = inSwitch
 	return node
 }
 
 func (p *parser) parseWithStatement() ast.Stmt {
-	p.expect(token.With)
+	idx := p.expect(token.With)
+	if p.scope.strict {
+		p.errorf("Strict mode code may not include a with statement")
+	}
 	p.expect(token.LeftParenthesis)
 	node := p.alloc.WithStatement(p.parseExpression())
+	node.With = idx
 	p.expect(token.RightParenthesis)
 	p.scope.allowLet = false
 	node.Body = p.parseStatement()
@@ -486,7 +537,7 @@ func (p *parser) parseCaseStatement() ast.CaseStatement {
 		p.expect(token.Case)
 		node.Test = p.parseExpression()
 	}
-	p.expect(token.Colon)
+	node.Colon = p.expect(token.Colon)
 
 	mark := len(p.stmtBuf)
 	for {
@@ -676,8 +727,9 @@ func (p *parser) parseDoWhileStatement() ast.Stmt {
 	inIteration := p.scope.inIteration
 	p.scope.inIteration = true
 
-	p.expect(token.Do)
+	idx := p.expect(token.Do)
 	node := p.alloc.DoWhileStatement()
+	node.Do = idx
 	if p.currentKind() == token.LeftBrace {
 		node.Body = p.alloc.Statement(p.parseBlockStatement())
 	} else {
@@ -688,7 +740,7 @@ func (p *parser) parseDoWhileStatement() ast.Stmt {
 	p.expect(token.While)
 	p.expect(token.LeftParenthesis)
 	node.Test = p.parseExpression()
-	p.expect(token.RightParenthesis)
+	node.RightParenthesis = p.expect(token.RightParenthesis)
 	if p.currentKind() == token.Semicolon {
 		p.next()
 	}
@@ -698,9 +750,10 @@ func (p *parser) parseDoWhileStatement() ast.Stmt {
 }
 
 func (p *parser) parseWhileStatement() ast.Stmt {
-	p.expect(token.While)
+	idx := p.expect(token.While)
 	p.expect(token.LeftParenthesis)
 	node := p.alloc.WhileStatement(p.parseExpression())
+	node.While = idx
 	p.expect(token.RightParenthesis)
 	node.Body = p.parseIterationStatement()
 
@@ -708,9 +761,10 @@ func (p *parser) parseWhileStatement() ast.Stmt {
 }
 
 func (p *parser) parseIfStatement() ast.Stmt {
-	p.expect(token.If)
+	idx := p.expect(token.If)
 	p.expect(token.LeftParenthesis)
 	node := p.alloc.IfStatement(p.parseExpression())
+	node.If = idx
 	p.expect(token.RightParenthesis)
 
 	if p.currentKind() == token.LeftBrace {
@@ -731,9 +785,9 @@ func (p *parser) parseIfStatement() ast.Stmt {
 
 func (p *parser) parseSourceElements() (body ast.Statements) {
 	mark := len(p.stmtBuf)
+	directives := true
 	for p.currentKind() != token.Eof {
-		p.scope.allowLet = true
-		p.stmtBuf = append(p.stmtBuf, *p.parseStatement())
+		directives = p.parseListStatement(directives)
 	}
 
 	return p.finishStmtBuf(mark)
@@ -0,0 +1,164 @@
+package rename
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/resolver"
+)
+
+// MangleOptions configures MangleWithOptions.
+type MangleOptions struct {
+	// TopLevel mangles the bindings of the root scope too. They are kept by
+	// default, as the top-level bindings of a script are globals other
+	// scripts may use.
+	TopLevel bool
+	// Keep holds names that keep their bindings and that no other binding
+	// is given.
+	Keep []string
+}
+
+// Mangle gives the bindings of the resolved program the shortest names it
+// can, as in a, b, ..., aa, for minification.
+func Mangle(program *ast.Program, table *resolver.SymbolTable) {
+	MangleWithOptions(program, table, MangleOptions{})
+}
+
+// MangleWithOptions is like Mangle with options.
+//
+// Bindings in sibling scopes reuse the same names, and the bindings used
+// most get the shortest ones. Globals and the other bindings left alone
+// keep their names, which no mangled binding is given. A scope calling eval
+// directly or holding a with statement keeps the names of its bindings and
+// those of the scopes around it, which the code may reach by name.
+func MangleWithOptions(program *ast.Program, table *resolver.SymbolTable, opts MangleOptions) {
+	m := &mangler{
+		keep:     make(map[string]bool),
+		reserved: make(map[string]bool),
+		frozen:   make(map[*resolver.Scope]bool),
+		slots:    make(map[*resolver.Symbol]int),
+	}
+	for _, name := range opts.Keep {
+		m.keep[name] = true
+		m.reserved[name] = true
+	}
+	if !opts.TopLevel {
+		m.frozen[table.Root()] = true
+	}
+	for _, sym := range table.Symbols() {
+		if !m.renamable(sym) {
+			m.reserved[sym.Name] = true
+		}
+	}
+	m.assign(table.Root(), 0)
+
+	// The busiest slots get the shortest names.
+	order := make([]int, len(m.uses))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(m.uses[b], m.uses[a])
+	})
+	names := make([]string, len(m.uses))
+	n := 0
+	for _, slot := range order {
+		for {
+			name := shortName(n)
+			n++
+			if !m.reserved[name] && resolver.IsBindingName(name) && !globalConstant(name) {
+				names[slot] = name
+				break
+			}
+		}
+	}
+
+	renames := make(map[*resolver.Symbol]string, len(m.slots))
+	idents := make(map[*ast.Identifier]string)
+	for sym, slot := range m.slots {
+		name := names[slot]
+		if name == sym.Name {
+			continue
+		}
+		renames[sym] = name
+		for _, id := range sym.Decls {
+			idents[id] = name
+		}
+		for _, ref := range sym.References {
+			idents[ref.Ident] = name
+		}
+	}
+
+	ast.Inspect(program, func(n ast.VisitableNode) bool {
+		if p, ok := n.(*ast.Property); ok {
+			if short, ok := p.Prop.(*ast.PropertyShort); ok && idents[short.Name] != "" {
+				expandShorthand(p)
+			}
+		}
+		return true
+	})
+	for id, name := range idents {
+		id.Name = name
+	}
+	resolver.Rename(renames)
+}
+
+// mangler numbers the renamable bindings of a symbol table by slot. A
+// binding takes the next slot after those of the bindings of the scopes
+// around it, so that bindings sharing a slot never see one another.
+type mangler struct {
+	// keep holds the names whose bindings are left alone.
+	keep map[string]bool
+	// reserved holds the names no binding is given.
+	reserved map[string]bool
+	// frozen holds the scopes whose bindings keep their names, besides
+	// those in reach of eval or with.
+	frozen map[*resolver.Scope]bool
+
+	slots map[*resolver.Symbol]int
+	// uses holds the number of identifiers of the bindings of each slot.
+	uses []int
+}
+
+// renamable reports whether sym may be given another name.
+func (m *mangler) renamable(sym *resolver.Symbol) bool {
+	return !m.frozen[sym.Scope] && !sym.Scope.Dynamic() && !m.keep[sym.Name] && checkDecls(sym) == nil
+}
+
+// assign gives slots to the renamable bindings of s and its children,
+// starting at next.
+func (m *mangler) assign(s *resolver.Scope, next int) {
+	for _, sym := range s.Symbols() {
+		if !m.renamable(sym) {
+			continue
+		}
+		if next == len(m.uses) {
+			m.uses = append(m.uses, 0)
+		}
+		m.slots[sym] = next
+		m.uses[next] += len(sym.Decls) + len(sym.References)
+		next++
+	}
+	for _, child := range s.Children() {
+		m.assign(child, next)
+	}
+}
+
+const (
+	nameStart = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ$_"
+	namePart  = nameStart + "0123456789"
+)
+
+// shortName returns the nth name of the sequence a, b, ..., _, aa, ba and
+// so on.
+func shortName(n int) string {
+	name := []byte{nameStart[n%len(nameStart)]}
+	n /= len(nameStart)
+	for n > 0 {
+		n--
+		name = append(name, namePart[n%len(namePart)])
+		n /= len(namePart)
+	}
+	return string(name)
+}
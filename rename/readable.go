@@ -0,0 +1,317 @@
+package rename
+
+import (
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/resolver"
+)
+
+// ReadableOptions configures ReadableWithOptions.
+type ReadableOptions struct {
+	// Match selects the bindings to rename by their current name. By
+	// default those named as by obfuscators, as in _0x3fa2b1, are.
+	Match func(name string) bool
+}
+
+var obfuscatedName = regexp.MustCompile(`^_0x[0-9a-fA-F]+$`)
+
+// Readable renames the obfuscated bindings of the resolved program to
+// names guessed from how they are declared.
+func Readable(program *ast.Program, table *resolver.SymbolTable) {
+	ReadableWithOptions(program, table, ReadableOptions{})
+}
+
+// ReadableWithOptions is like Readable with options.
+//
+// A binding is named after the first of its declarations that tells
+// something:
+//
+//   - the result of require("path/to/some-module") after the module, as
+//     someModule;
+//   - a function declaring or returning an array of strings getStrings;
+//   - a loop counter i, or j, k and so on if taken, the key of a for-in
+//     loop key and the value of a for-of loop item;
+//   - a parameter arg0, arg1 and so on by position, a rest parameter
+//     args, and a catch parameter err;
+//   - a variable holding a literal, function or new object str, num,
+//     flag, arr, obj, re, fn or the class name in lower camel case.
+//
+// Other functions are named fn, classes Class and other bindings v.
+// Bindings are renamed in declaration order, so the names are
+// deterministic, and a name conflicting with another binding gets the
+// smallest number from 2 making it free, as in v2 or arg0_2. Bindings in
+// reach of eval or with are left alone.
+func ReadableWithOptions(program *ast.Program, table *resolver.SymbolTable, opts ReadableOptions) {
+	match := opts.Match
+	if match == nil {
+		match = obfuscatedName.MatchString
+	}
+
+	x := newIndex(program, table.Root())
+	h := &hinter{hints: make(map[*ast.Identifier]hint)}
+	h.V = h
+	program.VisitWith(h)
+
+	for _, sym := range table.Symbols() {
+		if !match(sym.Name) || sym.Scope.Dynamic() || checkDecls(sym) != nil {
+			continue
+		}
+		hint := hintOf(sym, h.hints)
+		for i := 0; ; i++ {
+			name := hint.candidate(i)
+			if name == sym.Name {
+				break
+			}
+			if validName(sym, name) && x.check(sym, name) == nil {
+				x.rename(sym, name)
+				break
+			}
+		}
+	}
+}
+
+// hint is a name suggested for a binding. Alternatives, if any, are tried
+// in order when the name is taken, before numbering it.
+type hint struct {
+	name         string
+	alternatives []string
+}
+
+// loopCounters are the names given to loop counters.
+var loopCounters = hint{name: "i", alternatives: []string{"j", "k", "l", "m", "n"}}
+
+// candidate returns the ith name to try for a binding.
+func (h hint) candidate(i int) string {
+	if i == 0 {
+		return h.name
+	}
+	if i <= len(h.alternatives) {
+		return h.alternatives[i-1]
+	}
+	n := i - len(h.alternatives) + 1
+	if unicode.IsDigit(rune(h.name[len(h.name)-1])) {
+		return h.name + "_" + strconv.Itoa(n)
+	}
+	return h.name + strconv.Itoa(n)
+}
+
+// hintOf returns the name suggested for sym by the first of its
+// declarations with a hint, or by its kind.
+func hintOf(sym *resolver.Symbol, hints map[*ast.Identifier]hint) hint {
+	for _, id := range sym.Decls {
+		if h, ok := hints[id]; ok {
+			return h
+		}
+	}
+	switch sym.Kind {
+	case resolver.DeclKindFunction:
+		return hint{name: "fn"}
+	case resolver.DeclKindClass:
+		return hint{name: "Class"}
+	case resolver.DeclKindParam:
+		return hint{name: "arg"}
+	case resolver.DeclKindCatch:
+		return hint{name: "err"}
+	}
+	return hint{name: "v"}
+}
+
+// hinter suggests names for the identifiers declared in a tree.
+type hinter struct {
+	ast.NoopVisitor
+
+	hints map[*ast.Identifier]hint
+}
+
+func (h *hinter) suggest(id *ast.Identifier, name string) {
+	if _, ok := h.hints[id]; !ok && name != "" {
+		h.hints[id] = hint{name: name}
+	}
+}
+
+func (h *hinter) VisitVariableDeclarator(n *ast.VariableDeclarator) {
+	if id, ok := n.Target.Target.(*ast.Identifier); ok && n.Initializer != nil {
+		h.suggest(id, valueName(n.Initializer.Expr))
+	}
+	n.VisitChildrenWith(h)
+}
+
+func (h *hinter) VisitForStatement(n *ast.ForStatement) {
+	if n.Initializer != nil {
+		if decl, ok := n.Initializer.Initializer.(*ast.VariableDeclaration); ok && len(decl.List) == 1 {
+			if id, ok := decl.List[0].Target.Target.(*ast.Identifier); ok {
+				h.hints[id] = loopCounters
+			}
+		}
+	}
+	n.VisitChildrenWith(h)
+}
+
+func (h *hinter) VisitForInStatement(n *ast.ForInStatement) {
+	h.suggestInto(n.Into, "key")
+	n.VisitChildrenWith(h)
+}
+
+func (h *hinter) VisitForOfStatement(n *ast.ForOfStatement) {
+	h.suggestInto(n.Into, "item")
+	n.VisitChildrenWith(h)
+}
+
+func (h *hinter) suggestInto(into *ast.ForInto, name string) {
+	if decl, ok := into.Into.(*ast.VariableDeclaration); ok && len(decl.List) == 1 {
+		if id, ok := decl.List[0].Target.Target.(*ast.Identifier); ok {
+			h.suggest(id, name)
+		}
+	}
+}
+
+func (h *hinter) VisitFunctionLiteral(n *ast.FunctionLiteral) {
+	if n.Name != nil {
+		h.suggest(n.Name, functionName(n))
+	}
+	h.suggestParams(n.ParameterList)
+	n.VisitChildrenWith(h)
+}
+
+func (h *hinter) VisitArrowFunctionLiteral(n *ast.ArrowFunctionLiteral) {
+	h.suggestParams(n.ParameterList)
+	n.VisitChildrenWith(h)
+}
+
+func (h *hinter) suggestParams(n *ast.ParameterList) {
+	for i, param := range n.List {
+		if id, ok := param.Target.Target.(*ast.Identifier); ok {
+			h.suggest(id, "arg"+strconv.Itoa(i))
+		}
+	}
+	if id, ok := n.Rest.(*ast.Identifier); ok {
+		h.suggest(id, "args")
+	}
+}
+
+func (h *hinter) VisitCatchStatement(n *ast.CatchStatement) {
+	if n.Parameter != nil {
+		if id, ok := n.Parameter.Target.(*ast.Identifier); ok {
+			h.suggest(id, "err")
+		}
+	}
+	n.VisitChildrenWith(h)
+}
+
+func (h *hinter) VisitClassLiteral(n *ast.ClassLiteral) {
+	if n.Name != nil {
+		h.suggest(n.Name, "Class")
+	}
+	n.VisitChildrenWith(h)
+}
+
+// valueName returns the name suggested for a variable initialized to e,
+// or "" if there is none.
+func valueName(e ast.Expr) string {
+	switch e := e.(type) {
+	case *ast.CallExpression:
+		if callee, ok := e.Callee.Expr.(*ast.Identifier); ok && callee.Name == "require" && len(e.ArgumentList) == 1 {
+			if s, ok := e.ArgumentList[0].Expr.(*ast.StringLiteral); ok {
+				return moduleName(s.Value)
+			}
+		}
+	case *ast.NewExpression:
+		if callee, ok := e.Callee.Expr.(*ast.Identifier); ok && !obfuscatedName.MatchString(callee.Name) {
+			return camelCase(callee.Name)
+		}
+	case *ast.FunctionLiteral:
+		return functionName(e)
+	case *ast.ArrowFunctionLiteral:
+		return "fn"
+	case *ast.StringLiteral, *ast.TemplateLiteral:
+		return "str"
+	case *ast.NumberLiteral:
+		return "num"
+	case *ast.BooleanLiteral:
+		return "flag"
+	case *ast.ArrayLiteral:
+		return "arr"
+	case *ast.ObjectLiteral:
+		return "obj"
+	case *ast.RegExpLiteral:
+		return "re"
+	}
+	return ""
+}
+
+// functionName returns the name suggested for the function f: getStrings
+// if its body declares or returns an array of strings, as the string table
+// functions of obfuscators do, and fn otherwise.
+func functionName(f *ast.FunctionLiteral) string {
+	for _, stmt := range f.Body.List {
+		switch s := stmt.Stmt.(type) {
+		case *ast.ReturnStatement:
+			if s.Argument != nil && isStringArray(s.Argument.Expr) {
+				return "getStrings"
+			}
+		case *ast.VariableDeclaration:
+			for _, decl := range s.List {
+				if decl.Initializer != nil && isStringArray(decl.Initializer.Expr) {
+					return "getStrings"
+				}
+			}
+		}
+	}
+	return "fn"
+}
+
+// isStringArray reports whether e is a non-empty array literal of strings.
+func isStringArray(e ast.Expr) bool {
+	arr, ok := e.(*ast.ArrayLiteral)
+	if !ok || len(arr.Value) == 0 {
+		return false
+	}
+	for _, el := range arr.Value {
+		if _, ok := el.Expr.(*ast.StringLiteral); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// moduleName returns the name suggested for the result of requiring the
+// module at p: its last path element, without a script extension, in
+// lower camel case.
+func moduleName(p string) string {
+	base := path.Base(p)
+	switch path.Ext(base) {
+	case ".js", ".cjs", ".mjs", ".json", ".ts", ".node":
+		base = strings.TrimSuffix(base, path.Ext(base))
+	}
+	if name := camelCase(base); name != "" {
+		return name
+	}
+	return "module"
+}
+
+// camelCase joins the words of s, runs of letters and digits, in lower
+// camel case. It returns "" if the result cannot name a binding.
+func camelCase(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for i, w := range words {
+		r := []rune(w)
+		if i == 0 {
+			r[0] = unicode.ToLower(r[0])
+		} else {
+			r[0] = unicode.ToUpper(r[0])
+		}
+		b.WriteString(string(r))
+	}
+	if name := b.String(); resolver.IsBindingName(name) {
+		return name
+	}
+	return ""
+}
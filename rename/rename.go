@@ -0,0 +1,301 @@
+// Package rename renames the bindings of a resolved program:
+//
+//	table := resolver.Resolve(program)
+//	err := rename.Symbol(program, table.Root().Lookup("a"), "b")
+//
+// A rename changes every declaration and reference of one symbol and keeps
+// the symbol table in step. It fails rather than change what the program
+// means: when the new name is declared in the scope of the symbol or
+// between it and one of its uses, and when one of the uses of the symbol
+// would capture a use of the new name from an enclosing scope or the
+// globals.
+//
+// Shorthand properties are expanded, so renaming a in {a} gives {a: b}. The
+// parser reads scripts only, so there are no import or export specifiers to
+// keep.
+//
+// Mangle renames all the bindings it safely can at once, to short names
+// for minification, and Readable renames obfuscated bindings to names
+// guessed from their use.
+package rename
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/resolver"
+)
+
+var (
+	// ErrInvalidName is returned for a new name that is not an identifier
+	// or is reserved, and for undefined, NaN and Infinity as the new name of
+	// a top-level binding.
+	ErrInvalidName = errors.New("rename: invalid name")
+	// ErrUnsafe is wrapped by the errors of symbols that cannot be renamed
+	// whatever the new name: globals, symbols in reach of eval or with, and
+	// catch parameters redeclared by a var.
+	ErrUnsafe = errors.New("rename: symbol cannot be renamed")
+)
+
+// ConflictError is returned when the new name is taken by another symbol.
+type ConflictError struct {
+	Name string
+	// Symbol is the symbol already named Name: a declaration in the way or
+	// one whose uses the renamed symbol would capture.
+	Symbol *resolver.Symbol
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("rename: %s conflicts with the %v %s of a %v scope", e.Name, e.Symbol.Kind, e.Name, e.Symbol.Scope.Kind())
+}
+
+// Options configures SymbolWithOptions.
+type Options struct {
+	// Suffix makes a conflicting name free by appending the smallest number
+	// from 2 that does, as in b2, instead of failing.
+	Suffix bool
+}
+
+// Symbol renames sym, a symbol of the resolved program, to name.
+func Symbol(program *ast.Program, sym *resolver.Symbol, name string) error {
+	_, err := SymbolWithOptions(program, sym, name, Options{})
+	return err
+}
+
+// SymbolWithOptions is like Symbol with options, returning the name sym
+// is given.
+func SymbolWithOptions(program *ast.Program, sym *resolver.Symbol, name string, opts Options) (string, error) {
+	if !validName(sym, name) {
+		return "", fmt.Errorf("%w %q", ErrInvalidName, name)
+	}
+	if name == sym.Name {
+		return name, nil
+	}
+	if err := checkSymbol(sym); err != nil {
+		return "", err
+	}
+
+	root := sym.Scope
+	for root.Parent() != nil {
+		root = root.Parent()
+	}
+	x := newIndex(program, root)
+
+	candidate := name
+	for i := 2; ; i++ {
+		err := x.check(sym, candidate)
+		if err == nil {
+			x.rename(sym, candidate)
+			return candidate, nil
+		}
+		var conflict *ConflictError
+		if !opts.Suffix || !errors.As(err, &conflict) {
+			return "", err
+		}
+		candidate = name + strconv.Itoa(i)
+	}
+}
+
+// validName reports whether sym may be renamed to name. Besides naming a
+// binding, a top-level name must not be one of the read-only properties of
+// the global object, which a script cannot declare.
+func validName(sym *resolver.Symbol, name string) bool {
+	if !resolver.IsBindingName(name) {
+		return false
+	}
+	return sym.Scope.Kind() != resolver.ScopeKindProgram || !globalConstant(name)
+}
+
+// globalConstant reports whether name is a read-only property of the global
+// object.
+func globalConstant(name string) bool {
+	switch name {
+	case "undefined", "NaN", "Infinity":
+		return true
+	}
+	return false
+}
+
+// checkSymbol returns an error if sym cannot be renamed at all.
+func checkSymbol(sym *resolver.Symbol) error {
+	if err := checkDecls(sym); err != nil {
+		return err
+	}
+	if sym.Scope.Dynamic() {
+		return fmt.Errorf("%w: %s is in reach of eval or with", ErrUnsafe, sym.Name)
+	}
+	return nil
+}
+
+// checkDecls returns an error if sym is reached by its name rather than
+// through declarations: a global, or a catch parameter and the var
+// sharing its name.
+func checkDecls(sym *resolver.Symbol) error {
+	if len(sym.Decls) == 0 {
+		return fmt.Errorf("%w: %s is not declared", ErrUnsafe, sym.Name)
+	}
+	if sym.Kind == resolver.DeclKindCatch && len(sym.Decls) > 1 {
+		return fmt.Errorf("%w: catch parameter %s is redeclared by a var", ErrUnsafe, sym.Name)
+	}
+	return nil
+}
+
+// index locates the identifiers of a resolved program, so that renames can
+// be checked against the symbol table without walking the tree again.
+type index struct {
+	// sites holds the scope each resolved identifier appears in. The name
+	// of a function or class declaration appears in the enclosing scope.
+	sites map[*ast.Identifier]*resolver.Scope
+	// shorthands holds the shorthand properties by the identifier they
+	// name.
+	shorthands map[*ast.Identifier]*ast.Property
+	// byName holds the symbols under root by name.
+	byName map[string][]*resolver.Symbol
+}
+
+func newIndex(program *ast.Program, root *resolver.Scope) *index {
+	x := &index{
+		sites:      make(map[*ast.Identifier]*resolver.Scope),
+		shorthands: make(map[*ast.Identifier]*ast.Property),
+		byName:     make(map[string][]*resolver.Symbol),
+	}
+	byNode := make(map[ast.VisitableNode]*resolver.Scope)
+	var walk func(s *resolver.Scope)
+	walk = func(s *resolver.Scope) {
+		byNode[s.Node()] = s
+		for _, sym := range s.Symbols() {
+			x.byName[sym.Name] = append(x.byName[sym.Name], sym)
+		}
+		for _, child := range s.Children() {
+			walk(child)
+		}
+	}
+	walk(root)
+
+	var stack []*resolver.Scope
+	var cur *resolver.Scope
+	ast.Inspect(program, func(n ast.VisitableNode) bool {
+		if n == nil {
+			cur, stack = stack[len(stack)-1], stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, cur)
+		switch n := n.(type) {
+		case *ast.Identifier:
+			if _, ok := x.sites[n]; !ok && n.ScopeContext != resolver.UnresolvedMark {
+				x.sites[n] = cur
+			}
+		case *ast.Property:
+			if short, ok := n.Prop.(*ast.PropertyShort); ok {
+				x.shorthands[short.Name] = n
+			}
+		}
+		if scope := byNode[n]; scope != nil {
+			var id *ast.Identifier
+			switch n := n.(type) {
+			case *ast.FunctionLiteral:
+				id = n.Name
+			case *ast.ClassLiteral:
+				id = n.Name
+			}
+			if id != nil && id.ScopeContext != scope.Context() {
+				x.sites[id] = cur
+			}
+			cur = scope
+		}
+		return true
+	})
+	return x
+}
+
+// idents returns the identifiers declaring and referring to sym.
+func idents(sym *resolver.Symbol) []*ast.Identifier {
+	ids := slices.Clone(sym.Decls)
+	for _, ref := range sym.References {
+		ids = append(ids, ref.Ident)
+	}
+	return ids
+}
+
+// check returns a *ConflictError if renaming sym to name would change what
+// one of its identifiers, or one of those of name, refers to.
+func (x *index) check(sym *resolver.Symbol, name string) error {
+	s := sym.Scope
+	if other := s.Lookup(name); other != nil && other.Scope == s {
+		return &ConflictError{Name: name, Symbol: other}
+	}
+
+	// A use of sym must not be shadowed by a declaration of name.
+	for _, id := range idents(sym) {
+		for scope := x.sites[id]; scope != nil && scope != s; scope = scope.Parent() {
+			if other := scope.Lookup(name); other != nil && other.Scope == scope {
+				return &ConflictError{Name: name, Symbol: other}
+			}
+		}
+	}
+
+	// A use of name from around s must not be captured by sym.
+	for _, other := range x.byName[name] {
+		if other.Scope == s || !within(s, other.Scope) {
+			continue
+		}
+		for _, id := range idents(other) {
+			if site := x.sites[id]; site != nil && within(site, s) {
+				return &ConflictError{Name: name, Symbol: other}
+			}
+		}
+	}
+	return nil
+}
+
+// within reports whether s is scope or nested in it.
+func within(s, scope *resolver.Scope) bool {
+	for ; s != nil; s = s.Parent() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// rename renames sym to name, which check has found safe.
+func (x *index) rename(sym *resolver.Symbol, name string) {
+	ids := idents(sym)
+	// Expand the shorthands before renaming their identifiers.
+	for _, id := range ids {
+		if p := x.shorthands[id]; p != nil {
+			expandShorthand(p)
+			delete(x.shorthands, id)
+		}
+	}
+	for _, id := range ids {
+		id.Name = name
+	}
+
+	x.byName[sym.Name] = slices.DeleteFunc(x.byName[sym.Name], func(s *resolver.Symbol) bool { return s == sym })
+	x.byName[name] = append(x.byName[name], sym)
+	sym.Rename(name)
+}
+
+// expandShorthand turns the shorthand property p into a keyed one keeping
+// the current name as key, so that the value can be renamed.
+func expandShorthand(p *ast.Property) {
+	short := p.Prop.(*ast.PropertyShort)
+	key := short.Name.Name
+	value := ast.Expr(short.Name)
+	if short.Initializer != nil && short.Initializer.Expr != nil {
+		value = &ast.AssignExpression{
+			Left:     &ast.Expression{Expr: short.Name},
+			Right:    short.Initializer,
+			Operator: ast.AssignmentAssign,
+		}
+	}
+	p.Prop = &ast.PropertyKeyed{
+		Key:   &ast.Expression{Expr: &ast.StringLiteral{Value: key, Raw: &key, Idx: short.Name.Idx}},
+		Kind:  ast.PropertyKindValue,
+		Value: &ast.Expression{Expr: value},
+	}
+}
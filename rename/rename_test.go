@@ -0,0 +1,243 @@
+package rename_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/ast/build"
+	"github.com/t14raptor/go-fast/generator"
+	"github.com/t14raptor/go-fast/parser"
+	"github.com/t14raptor/go-fast/parser/scanner/token"
+	"github.com/t14raptor/go-fast/rename"
+	"github.com/t14raptor/go-fast/resolver"
+)
+
+// find returns the first symbol called name in the scopes of table, outer
+// scopes first.
+func find(table *resolver.SymbolTable, name string) *resolver.Symbol {
+	for _, sym := range table.Symbols() {
+		if sym.Name == name {
+			return sym
+		}
+	}
+	return nil
+}
+
+func TestSymbol(t *testing.T) {
+	tests := []struct {
+		src, from, to string
+		want          string
+		conflict      bool
+	}{
+		{`var a = 1; function f(a) { return a; } a++;`, "a", "b", `var b=1;function f(a){return a;}b++;`, false},
+		{`let a = 1; f({a}); var {a: c} = o;`, "a", "b", `let b=1;f({a:b});var {a:c}=o;`, false},
+		{`function f({a = 1}) { return a; }`, "a", "b", `function f({a:b=1}){return b;}`, false},
+		{`{ let a = 1; ({a} = o); }`, "a", "b", `{let b=1;({a:b}=o);}`, false},
+		{`var a; var b;`, "a", "b", ``, true},
+		{`var a; function f() { let b; return a; }`, "a", "b", ``, true},
+		{`var a; function f() { return a + b; }`, "a", "b", ``, true},
+		{`var a; function f() { return a; } var b; b;`, "a", "b", ``, true},
+		{`function f() { var a; return console; }`, "a", "console", ``, true},
+		{`var a; { let b; } a;`, "a", "b", `var b;{let b;}b;`, false},
+		{`function a() { return a; } a();`, "a", "b", `function b(){return b;}b();`, false},
+		{`'use strict'; { function f() {} } f();`, "f", "g", `'use strict';{function g(){}}f();`, false},
+		{`function f(a = () => b, b) { return a(); }`, "b", "c", `function f(a=()=>c,c){return a();}`, false},
+		{`function f() { var a; return a; }`, "a", "undefined", `function f(){var undefined;return undefined;}`, false},
+	}
+	for _, tt := range tests {
+		program, err := parser.ParseFile(tt.src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		table := resolver.Resolve(program)
+		sym := find(table, tt.from)
+		err = rename.Symbol(program, sym, tt.to)
+		var conflict *rename.ConflictError
+		if tt.conflict {
+			if !errors.As(err, &conflict) {
+				t.Errorf("%s: renaming %s to %s gave %v; want a conflict", tt.src, tt.from, tt.to, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: renaming %s to %s: %v", tt.src, tt.from, tt.to, err)
+			continue
+		}
+		if got := generator.GenerateMinified(program); got != tt.want {
+			t.Errorf("%s: got %q; want %q", tt.src, got, tt.want)
+		}
+		if sym.Name != tt.to || table.Root().Lookup(tt.from) == sym || table.Lookup(sym.Decls[0].ToId()) != sym {
+			t.Errorf("%s: symbol table not updated", tt.src)
+		}
+	}
+}
+
+func TestFreshSymbol(t *testing.T) {
+	program, err := parser.ParseFile(`function f() { return 1; }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := resolver.Resolve(program)
+	f := table.Root().Children()[0]
+	sym, err := resolver.Declare(f, resolver.FreshIdent(f, "_tmp"), token.Let, build.Num(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn := program.Body[0].Stmt.(*ast.FunctionDeclaration).Function
+	ret := fn.Body.List[1].Stmt.(*ast.ReturnStatement)
+	ret.Argument.Expr = sym.Use(f, resolver.ReferenceRead)
+
+	if err := rename.Symbol(program, sym, "tmpx"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := generator.GenerateMinified(program), `function f(){let tmpx=2;return tmpx;}`; got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+
+	rename.MangleWithOptions(program, table, rename.MangleOptions{TopLevel: true})
+	if got, want := generator.GenerateMinified(program), `function b(){let a=2;return a;}`; got != want {
+		t.Errorf("mangled %q; want %q", got, want)
+	}
+}
+
+func TestSuffixAndErrors(t *testing.T) {
+	program, err := parser.ParseFile(`var a, b, b2; a;`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := resolver.Resolve(program)
+	name, err := rename.SymbolWithOptions(program, find(table, "a"), "b", rename.Options{Suffix: true})
+	if err != nil || name != "b3" {
+		t.Errorf("suffixed rename = %q, %v; want b3", name, err)
+	}
+	if got, want := generator.GenerateMinified(program), `var b3,b,b2;b3;`; got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+
+	for _, tt := range []struct {
+		src, from, to string
+		// catch picks the catch parameter rather than the var.
+		catch bool
+		err   error
+	}{
+		{`var a;`, "a", "if", false, rename.ErrInvalidName},
+		{`var a;`, "a", "1a", false, rename.ErrInvalidName},
+		{`var a = 1; function f() { return a; }`, "a", "undefined", false, rename.ErrInvalidName},
+		{`let a;`, "a", "NaN", false, rename.ErrInvalidName},
+		{`function a() {}`, "a", "Infinity", false, rename.ErrInvalidName},
+		{`a;`, "a", "b", false, rename.ErrUnsafe},
+		{`function f() { var a; eval(s); }`, "a", "b", false, rename.ErrUnsafe},
+		{`try {} catch (a) { var a = 1; }`, "a", "b", false, rename.ErrUnsafe},
+		{`try {} catch (a) { var a = 1; }`, "a", "b", true, rename.ErrUnsafe},
+	} {
+		program, err := parser.ParseFile(tt.src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		table := resolver.Resolve(program)
+		sym := find(table, tt.from)
+		if tt.catch {
+			sym = sym.Scope.Children()[1].Lookup(tt.from)
+		}
+		if err := rename.Symbol(program, sym, tt.to); !errors.Is(err, tt.err) {
+			t.Errorf("%s: renaming %s to %s gave %v; want %v", tt.src, tt.from, tt.to, err, tt.err)
+		}
+	}
+}
+
+func TestMangle(t *testing.T) {
+	tests := []struct {
+		src  string
+		opts rename.MangleOptions
+		want string
+	}{
+		{
+			`function f(first, second) { var local = first; return local + second + local; } function g(x) { { let y; } return x; }`,
+			rename.MangleOptions{},
+			`function f(a,b){var c=a;return c+b+c;}function g(a){{let b;}return a;}`,
+		},
+		{
+			`var counter = 0; function inc(step) { counter += step; return {counter}; }`,
+			rename.MangleOptions{TopLevel: true},
+			`var a=0;function c(b){a+=b;return {counter:a};}`,
+		},
+		{
+			`function f(long) { return a + long; }`,
+			rename.MangleOptions{},
+			`function f(b){return a+b;}`,
+		},
+		{
+			`function f(x, y) { eval(s); return function(z) { return x + z; }; }`,
+			rename.MangleOptions{},
+			`function f(x,y){eval(s);return function(a){return x+a;};}`,
+		},
+		{
+			`'use strict'; { function f() {} f(); } f();`,
+			rename.MangleOptions{TopLevel: true},
+			`'use strict';{function a(){}a();}f();`,
+		},
+		{
+			`function f(keep, other) { return keep + other; }`,
+			rename.MangleOptions{Keep: []string{"keep", "a"}},
+			`function f(keep,b){return keep+b;}`,
+		},
+	}
+	for _, tt := range tests {
+		program, err := parser.ParseFile(tt.src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		table := resolver.Resolve(program)
+		rename.MangleWithOptions(program, table, tt.opts)
+		if got := generator.GenerateMinified(program); got != tt.want {
+			t.Errorf("%s:\ngot  %q\nwant %q", tt.src, got, tt.want)
+		}
+		for _, sym := range table.Symbols() {
+			if len(sym.Decls) > 0 && table.Lookup(sym.Decls[0].ToId()) != sym {
+				t.Errorf("%s: symbol %s not found by its new name", tt.src, sym.Name)
+			}
+		}
+	}
+}
+
+func TestReadable(t *testing.T) {
+	tests := []struct {
+		src, want string
+	}{
+		{
+			`var _0x1a = require("./lib/string-utils.js"), _0x2b = require("@scope/pkg");`,
+			`var stringUtils=require("./lib/string-utils.js"),pkg=require("@scope/pkg");`,
+		},
+		{
+			`for (var _0x1 = 0; _0x1 < n; _0x1++) for (var _0x2 = 0; _0x2 < m; _0x2++) f(_0x1, _0x2);`,
+			`for(var i=0;i<n;i++)for(var j=0;j<m;j++)f(i,j);`,
+		},
+		{
+			`function _0xa(_0xb, _0xc, ..._0xd) { try {} catch (_0xe) { return _0xb; } }`,
+			`function fn(arg0,arg1,...args){try{}catch(err){return arg0;}}`,
+		},
+		{
+			`function _0xf() { var _0x9 = ["a", "b"]; return _0x9; } var _0x8 = new Map(), v;`,
+			`function getStrings(){var arr=["a","b"];return arr;}var map=new Map(),v;`,
+		},
+		{
+			`function f(_0x1, v) { var _0x2 = 1, _0x3 = 2; return arg0; }`,
+			`function f(arg0_2,v){var num=1,num2=2;return arg0;}`,
+		},
+		{
+			`function f(_0x1) { eval(s); }`,
+			`function f(_0x1){eval(s);}`,
+		},
+	}
+	for _, tt := range tests {
+		program, err := parser.ParseFile(tt.src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rename.Readable(program, resolver.Resolve(program))
+		if got := generator.GenerateMinified(program); got != tt.want {
+			t.Errorf("%s:\ngot  %q\nwant %q", tt.src, got, tt.want)
+		}
+	}
+}
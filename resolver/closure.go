@@ -0,0 +1,211 @@
+package resolver
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/t14raptor/go-fast/ast"
+)
+
+// Closure is what a function uses from outside itself, found by Closures.
+// The uses of the functions nested in it count as its own.
+type Closure struct {
+	// Scope is the scope of the function.
+	Scope *Scope
+
+	// Reads and Writes hold the bindings declared outside the function that
+	// it reads and assigns, in the order they are first used. A compound
+	// assignment or update both reads and writes. Globals are not included.
+	Reads, Writes []*Symbol
+	// Globals holds the globals the function uses, in the order they are
+	// first used.
+	Globals []*Symbol
+
+	// UsesThis, UsesArguments, UsesNewTarget and UsesSuper report whether
+	// the function uses this, arguments, new.target or super. For an arrow
+	// function they are those of the function around it.
+	UsesThis, UsesArguments, UsesNewTarget, UsesSuper bool
+
+	seen map[*Symbol]ReferenceKind
+}
+
+// Closures analyzes the functions of the tree p, resolved into table. It
+// returns the closure of every FunctionLiteral, ArrowFunctionLiteral and
+// ClassStaticBlock, keyed by node.
+func Closures(p ast.VisitableNode, table *SymbolTable) map[ast.VisitableNode]*Closure {
+	closures := make(map[ast.VisitableNode]*Closure)
+	closureOf := func(s *Scope) *Closure {
+		c, ok := closures[s.node]
+		if !ok {
+			c = &Closure{Scope: s, seen: make(map[*Symbol]ReferenceKind)}
+			closures[s.node] = c
+		}
+		return c
+	}
+
+	var walk func(s *Scope)
+	walk = func(s *Scope) {
+		if s.kind == ScopeKindFunction {
+			closureOf(s)
+		}
+		for _, child := range s.children {
+			walk(child)
+		}
+	}
+	if table.root != nil {
+		walk(table.root)
+	}
+
+	type use struct {
+		sym *Symbol
+		ref Reference
+	}
+	var uses []use
+	for _, sym := range table.symbols {
+		for _, ref := range sym.References {
+			uses = append(uses, use{sym, ref})
+		}
+	}
+	slices.SortStableFunc(uses, func(a, b use) int {
+		return cmp.Compare(a.ref.Ident.Idx, b.ref.Ident.Idx)
+	})
+	for _, u := range uses {
+		sym, ref := u.sym, u.ref
+		if sym.Kind == DeclKindGlobal && sym.Name == "arguments" && usesArguments(ref.Scope, closureOf) {
+			continue
+		}
+		// The functions between the reference and the declaration capture
+		// the symbol.
+		for s := ref.Scope; s != nil && s != sym.Scope; s = s.parent {
+			if s.kind == ScopeKindFunction {
+				closureOf(s).use(sym, ref.Kind)
+			}
+		}
+	}
+
+	v := &closureVisitor{closures: closures}
+	v.V = v
+	p.VisitWith(v)
+
+	for _, c := range closures {
+		c.seen = nil
+	}
+	return closures
+}
+
+// usesArguments marks the functions from s out to the closest function
+// that is not an arrow function as using arguments. It reports false if
+// there is none, arguments then being a global.
+func usesArguments(s *Scope, closureOf func(*Scope) *Closure) bool {
+	var arrows []*Scope
+	for ; s != nil; s = s.parent {
+		if s.kind != ScopeKindFunction {
+			continue
+		}
+		if _, ok := s.node.(*ast.ArrowFunctionLiteral); ok {
+			arrows = append(arrows, s)
+			continue
+		}
+		if _, ok := s.node.(*ast.FunctionLiteral); !ok {
+			return false
+		}
+		closureOf(s).UsesArguments = true
+		for _, arrow := range arrows {
+			closureOf(arrow).UsesArguments = true
+		}
+		return true
+	}
+	return false
+}
+
+func (c *Closure) use(sym *Symbol, kind ReferenceKind) {
+	seen, ok := c.seen[sym]
+	if sym.Kind == DeclKindGlobal {
+		if !ok {
+			c.Globals = append(c.Globals, sym)
+			c.seen[sym] = kind
+		}
+		return
+	}
+	reads := kind != ReferenceWrite
+	writes := kind != ReferenceRead
+	if reads && (!ok || seen == ReferenceWrite) {
+		c.Reads = append(c.Reads, sym)
+	}
+	if writes && (!ok || seen == ReferenceRead) {
+		c.Writes = append(c.Writes, sym)
+	}
+	switch {
+	case !ok:
+		c.seen[sym] = kind
+	case seen != kind:
+		c.seen[sym] = ReferenceReadWrite
+	}
+}
+
+// closureVisitor finds the uses of this, new.target and super. A use in an
+// arrow function belongs to the function around it too.
+type closureVisitor struct {
+	ast.NoopVisitor
+
+	closures map[ast.VisitableNode]*Closure
+	// stack holds the closures of the functions being visited, innermost
+	// last. A nil entry stands for a class field, which has its own this.
+	stack []*Closure
+}
+
+func (v *closureVisitor) push(n ast.VisitableNode, visit func()) {
+	v.stack = append(v.stack, v.closures[n])
+	visit()
+	v.stack = v.stack[:len(v.stack)-1]
+}
+
+func (v *closureVisitor) VisitFunctionLiteral(n *ast.FunctionLiteral) {
+	v.push(n, func() { n.VisitChildrenWith(v) })
+}
+
+func (v *closureVisitor) VisitArrowFunctionLiteral(n *ast.ArrowFunctionLiteral) {
+	v.push(n, func() { n.VisitChildrenWith(v) })
+}
+
+func (v *closureVisitor) VisitClassStaticBlock(n *ast.ClassStaticBlock) {
+	v.push(n, func() { n.VisitChildrenWith(v) })
+}
+
+func (v *closureVisitor) VisitFieldDefinition(n *ast.FieldDefinition) {
+	n.Key.VisitWith(v)
+	v.push(n, func() {
+		if n.Initializer != nil {
+			n.Initializer.VisitWith(v)
+		}
+	})
+}
+
+// mark sets a flag of the innermost closure and, through arrow functions,
+// of the closures around it.
+func (v *closureVisitor) mark(flag func(c *Closure) *bool) {
+	for i := len(v.stack) - 1; i >= 0; i-- {
+		c := v.stack[i]
+		if c == nil {
+			return
+		}
+		*flag(c) = true
+		if _, ok := c.Scope.node.(*ast.ArrowFunctionLiteral); !ok {
+			return
+		}
+	}
+}
+
+func (v *closureVisitor) VisitThisExpression(n *ast.ThisExpression) {
+	v.mark(func(c *Closure) *bool { return &c.UsesThis })
+}
+
+func (v *closureVisitor) VisitSuperExpression(n *ast.SuperExpression) {
+	v.mark(func(c *Closure) *bool { return &c.UsesSuper })
+}
+
+func (v *closureVisitor) VisitMetaProperty(n *ast.MetaProperty) {
+	if n.Meta.Name == "new" {
+		v.mark(func(c *Closure) *bool { return &c.UsesNewTarget })
+	}
+}
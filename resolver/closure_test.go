@@ -0,0 +1,68 @@
+package resolver_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/resolver"
+)
+
+func names(syms []*resolver.Symbol) string {
+	var list []string
+	for _, sym := range syms {
+		list = append(list, sym.Name)
+	}
+	return strings.Join(list, ",")
+}
+
+func TestClosures(t *testing.T) {
+	src := `
+var a = 1, b, c;
+function f(p) {
+	b = a + p;
+	c++;
+	console.log(this, arguments);
+	return () => g(arguments, this, x => x + a);
+}
+function g() { return new.target; }
+class C extends D { m() { return super.m(); } x = this; }
+`
+	program, table := resolve(t, src)
+	closures := resolver.Closures(program, table)
+
+	var got []string
+	for _, stmt := range program.Body {
+		var fn ast.VisitableNode
+		switch s := stmt.Stmt.(type) {
+		case *ast.FunctionDeclaration:
+			fn = s.Function
+		default:
+			continue
+		}
+		for n := range ast.Preorder(fn) {
+			c := closures[n]
+			if c == nil {
+				continue
+			}
+			got = append(got, fmt.Sprintf("%T r=%s w=%s g=%s this=%v args=%v new.target=%v",
+				n, names(c.Reads), names(c.Writes), names(c.Globals), c.UsesThis, c.UsesArguments, c.UsesNewTarget))
+		}
+	}
+	want := []string{
+		"*ast.FunctionLiteral r=a,c,g w=b,c g=console this=true args=true new.target=false",
+		"*ast.ArrowFunctionLiteral r=g,a w= g= this=true args=true new.target=false",
+		"*ast.ArrowFunctionLiteral r=a w= g= this=false args=false new.target=false",
+		"*ast.FunctionLiteral r= w= g= this=false args=false new.target=true",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	class := program.Body[3].Stmt.(*ast.ClassDeclaration).Class
+	method := class.Body[0].Element.(*ast.MethodDefinition).Body
+	if c := closures[method]; !c.UsesSuper || c.UsesThis {
+		t.Errorf("method UsesSuper = %v, UsesThis = %v", c.UsesSuper, c.UsesThis)
+	}
+}
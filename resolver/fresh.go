@@ -0,0 +1,160 @@
+package resolver
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"unicode"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/ast/build"
+	"github.com/t14raptor/go-fast/parser/scanner/token"
+)
+
+// FreshIdent returns an identifier for a new binding of scope, such as a
+// temporary introduced by a transform. Its name is hint, or hint followed
+// by the smallest number from 2 that no symbol of the table has had and no
+// earlier call has given out, as in _tmp2, so no use of another binding
+// can clash with it. A hint that cannot name a binding is replaced by _tmp.
+//
+// The identifier is stamped with a scope context of its own, which sets it
+// apart from every resolved identifier and keeps the resolver from
+// resolving it again. Declare it with Declare, and make each use of it with
+// Symbol.Use, so that the symbol table knows of them. The body of a with
+// statement may still read a property of its object under the name.
+func FreshIdent(scope *Scope, hint string) *ast.Identifier {
+	t := scope.table
+	if !IsBindingName(hint) {
+		hint = "_tmp"
+	}
+	name := hint
+	for i := 2; ; i++ {
+		if _, ok := t.names[name]; !ok {
+			break
+		}
+		name = hint + strconv.Itoa(i)
+	}
+	t.names[name] = struct{}{}
+
+	ctx := t.next
+	t.next++
+	t.scopes[ctx] = scope
+	return &ast.Identifier{Name: name, ScopeContext: ctx}
+}
+
+// Declare inserts the declaration tok id = init, where tok is token.Var,
+// token.Let or token.Const and a nil init leaves id uninitialised, at the
+// start of scope, after its directives. A var goes in the function or
+// program scope enclosing scope instead. id is an identifier from
+// FreshIdent for scope. Declare returns the new symbol, or an error if the
+// scope has no statements of its own to insert into, as for a loop or
+// class, if the name is taken, or if a const has no init. The scope is left
+// unchanged on error.
+func Declare(scope *Scope, id *ast.Identifier, tok token.Token, init ast.Expr) (*Symbol, error) {
+	if tok == token.Const && init == nil {
+		return nil, fmt.Errorf("resolver: const %s must be initialized", id.Name)
+	}
+	if tok == token.Var {
+		scope = scope.function()
+	}
+	if _, ok := scope.byName[id.Name]; ok {
+		return nil, fmt.Errorf("resolver: %s is already declared in a %v scope", id.Name, scope.kind)
+	}
+	list, err := scope.statements()
+	if err != nil {
+		return nil, err
+	}
+
+	decl := build.Declare(tok, build.Declarator(id, init))
+	i := 0
+	if scope.kind == ScopeKindFunction || scope.kind == ScopeKindProgram {
+		i = prologueLen(*list)
+	}
+	*list = slices.Insert(*list, i, ast.Statement{Stmt: decl})
+
+	sym := &Symbol{
+		Name:        id.Name,
+		Kind:        declKind(decl),
+		Scope:       scope,
+		Decls:       []*ast.Identifier{id},
+		initialized: true,
+		ctx:         id.ScopeContext,
+	}
+	scope.byName[sym.Name] = sym
+	scope.symbols = append(scope.symbols, sym)
+	scope.table.symbols = append(scope.table.symbols, sym)
+	scope.table.scopes[id.ScopeContext] = scope
+	return sym, nil
+}
+
+// Use returns a new identifier referring to s, to be placed in scope, and
+// records it as a reference of kind. Passes adding uses of a symbol, such
+// as one declared by Declare, make them this way so that renaming the
+// symbol renames them too.
+func (s *Symbol) Use(scope *Scope, kind ReferenceKind) *ast.Identifier {
+	id := &ast.Identifier{Name: s.Name, ScopeContext: s.Id().ScopeContext}
+	// In the body of a with statement, the name may be a property of the
+	// object.
+	if scope.with != nil && within(scope.with, s.Scope) {
+		id.ScopeContext = DynamicMark
+	}
+	s.References = append(s.References, Reference{
+		Ident:   id,
+		Kind:    kind,
+		Scope:   scope,
+		Dynamic: id.ScopeContext == DynamicMark,
+	})
+	return id
+}
+
+// statements returns the statements of the node opening s, turning the
+// expression body of an arrow function into a block.
+func (s *Scope) statements() (*ast.Statements, error) {
+	switch n := s.node.(type) {
+	case *ast.Program:
+		return &n.Body, nil
+	case *ast.FunctionLiteral:
+		return &n.Body.List, nil
+	case *ast.BlockStatement:
+		return &n.List, nil
+	case *ast.ClassStaticBlock:
+		return &n.Block.List, nil
+	case *ast.ArrowFunctionLiteral:
+		if e, ok := n.Body.Body.(*ast.Expression); ok {
+			body := build.Block(build.Return(e.Expr))
+			body.ScopeContext = s.ctx
+			n.Body.Body = body
+		}
+		return &n.Body.Body.(*ast.BlockStatement).List, nil
+	}
+	return nil, fmt.Errorf("resolver: cannot declare in a %v scope", s.kind)
+}
+
+// prologueLen returns the number of directives stmts, a function body or
+// program, begins with.
+func prologueLen(stmts ast.Statements) int {
+	for i, stmt := range stmts {
+		expr, ok := stmt.Stmt.(*ast.ExpressionStatement)
+		if !ok {
+			return i
+		}
+		if _, ok := expr.Expression.Expr.(*ast.StringLiteral); !ok {
+			return i
+		}
+	}
+	return len(stmts)
+}
+
+// IsBindingName reports whether s can name a binding: an identifier that
+// is not a keyword, reserved word, eval or arguments.
+func IsBindingName(s string) bool {
+	if s == "" || s == "eval" || s == "arguments" || token.MatchKeyword(s) != token.Identifier {
+		return false
+	}
+	for i, c := range s {
+		if c != '$' && c != '_' && !unicode.IsLetter(c) && (i == 0 || !unicode.IsDigit(c)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package resolver_test
+
+import (
+	"testing"
+
+	"github.com/t14raptor/go-fast/ast/build"
+	"github.com/t14raptor/go-fast/generator"
+	"github.com/t14raptor/go-fast/parser/scanner/token"
+	"github.com/t14raptor/go-fast/resolver"
+)
+
+func TestFreshIdent(t *testing.T) {
+	program, table := resolve(t, "'use strict'; var _tmp; function f() { { _ref; } } x => x; y => y;")
+	root := table.Root()
+	f := root.Children()[0]
+	block := f.Children()[0]
+	arrow := root.Children()[1]
+
+	a := resolver.FreshIdent(block, "_tmp")
+	b := resolver.FreshIdent(root, "_ref")
+	c := resolver.FreshIdent(arrow, "if")
+	if a.Name != "_tmp2" || b.Name != "_ref2" || c.Name != "_tmp3" {
+		t.Errorf("names = %s, %s, %s, want _tmp2, _ref2, _tmp3", a.Name, b.Name, c.Name)
+	}
+	if a.ScopeContext == b.ScopeContext || table.Scope(a.ScopeContext) != block {
+		t.Errorf("fresh contexts = %d, %d", a.ScopeContext, b.ScopeContext)
+	}
+
+	sym, err := resolver.Declare(block, a, token.Var, build.Num(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sym.Scope != f || sym.Kind != resolver.DeclKindVar || table.SymbolOf(a) != sym || sym.Id() != a.ToId() {
+		t.Errorf("var %s declared as %v in %v scope", sym.Name, sym.Kind, sym.Scope.Kind())
+	}
+	ref, err := resolver.Declare(root, b, token.Let, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	use := ref.Use(arrow, resolver.ReferenceRead)
+	if _, err := resolver.Declare(arrow, c, token.Const, use); err != nil {
+		t.Fatal(err)
+	}
+	if table.SymbolOf(use) != ref || len(ref.References) != 1 || ref.References[0].Ident != use || ref.References[0].Scope != arrow {
+		t.Errorf("use of _ref2 is not recorded")
+	}
+	if _, err := resolver.Declare(root, b, token.Let, nil); err == nil {
+		t.Errorf("declaring _ref2 twice succeeded")
+	}
+	if _, err := resolver.Declare(root, resolver.FreshIdent(root, "k"), token.Const, nil); err == nil {
+		t.Errorf("declaring a const without an initializer succeeded")
+	}
+	// A failed declaration leaves the expression body of an arrow alone.
+	if _, err := resolver.Declare(root.Children()[2], build.Ident("y"), token.Let, nil); err == nil {
+		t.Errorf("declaring y twice succeeded")
+	}
+
+	got := generator.GenerateMinified(program)
+	want := `'use strict';let _ref2;var _tmp;function f(){var _tmp2=1;{_ref;}}(x)=>{const _tmp3=_ref2;return x;};(y)=>y;`
+	if got != want {
+		t.Errorf("got %s\nwant %s", got, want)
+	}
+}
@@ -1,166 +1,184 @@
 package resolver
 
 import (
-	"maps"
-
 	"github.com/t14raptor/go-fast/ast"
 	"github.com/t14raptor/go-fast/parser/scanner/token"
 )
 
+// hoister declares the var and nested function declarations of a function
+// body in the function's scope before the body is resolved.
 type hoister struct {
 	ast.NoopVisitor
 
 	resolver *Resolver
-	kind     DeclKind
-	inBlock  bool
-
-	inCatchBody bool
 
-	excludedFromCatch map[string]struct{}
-	catchParamDecls   map[string]struct{}
+	// lexical holds the names declared with let, const or class by the
+	// function body and the blocks being visited, innermost last.
+	lexical []map[string]struct{}
+	// catchParams counts the catch clauses being visited whose parameter is
+	// a plain identifier, by name.
+	catchParams map[string]int
 }
 
-func newHoister(resolver *Resolver) *hoister {
-	return &hoister{
-		resolver:          resolver,
-		kind:              DeclKindVar,
-		excludedFromCatch: make(map[string]struct{}),
-		catchParamDecls:   make(map[string]struct{}),
+// hoistFunction declares the declarations of stmts, the body of the
+// function or program of the current scope. Declarations nested in blocks
+// are only hoisted for var, and for functions where nothing lexical
+// shadows them.
+func (r *Resolver) hoistFunction(stmts ast.Statements) {
+	h := &hoister{
+		resolver:    r,
+		lexical:     []map[string]struct{}{lexicalNames(stmts)},
+		catchParams: make(map[string]int),
 	}
-}
+	h.V = h
 
-func (h *hoister) addIdent(id *ast.Identifier) {
-	if h.inCatchBody {
-		if _, ok := h.catchParamDecls[id.Name]; ok {
-			if r, _ := h.resolver.lookupContext(id.Name); r != UnresolvedMark {
-				return
+	for i := range stmts {
+		switch it := stmts[i].Stmt.(type) {
+		case *ast.VariableDeclaration:
+			if it.Token == token.Var {
+				it.VisitWith(h)
+			} else {
+				r.declareLexical(it)
 			}
-		}
-
-		h.excludedFromCatch[id.Name] = struct{}{}
-	} else if _, ok := h.catchParamDecls[id.Name]; ok {
-		if _, excluded := h.excludedFromCatch[id.Name]; !excluded {
-			return
+		case *ast.FunctionDeclaration:
+			r.declare(r.current, it.Function.Name, DeclKindFunction)
+		case *ast.ClassDeclaration:
+			r.declare(r.current, it.Class.Name, DeclKindClass)
+		default:
+			stmts[i].VisitWith(h)
 		}
 	}
-
-	h.resolver.modify(id, h.kind)
 }
 
-func (h *hoister) VisitBlockStatement(n *ast.BlockStatement) {
-	old := h.inBlock
-	h.inBlock = true
-	n.VisitChildrenWith(h)
-	h.inBlock = old
-}
-
-func (h *hoister) VisitCatchStatement(n *ast.CatchStatement) {
-	oldExclude := h.excludedFromCatch
-	h.excludedFromCatch = make(map[string]struct{})
-	oldInCatchBody := h.inCatchBody
-
-	if n.Parameter != nil {
-		if params := findIds(n.Parameter); len(params) == 1 {
-			h.catchParamDecls[params[0].Name] = struct{}{}
+// hoistBlock declares the let, const, class and function declarations
+// directly in stmts, the body of a block, in the current scope.
+func (r *Resolver) hoistBlock(stmts ast.Statements) {
+	for i := range stmts {
+		switch it := stmts[i].Stmt.(type) {
+		case *ast.VariableDeclaration:
+			r.declareLexical(it)
+		case *ast.FunctionDeclaration:
+			// Already declared in the function scope if hoisted there.
+			r.declare(r.current, it.Function.Name, DeclKindFunction)
+		case *ast.ClassDeclaration:
+			r.declare(r.current, it.Class.Name, DeclKindClass)
 		}
 	}
+}
 
-	old := maps.Clone(h.catchParamDecls)
-
-	h.inCatchBody = true
-	n.Body.VisitWith(h)
-	h.inCatchBody = false
-	if n.Parameter != nil {
-		n.Parameter.VisitWith(h)
+// declareLexical declares the names of n in the current scope if it is a
+// let or const declaration.
+func (r *Resolver) declareLexical(n *ast.VariableDeclaration) {
+	kind := DeclKindLet
+	switch n.Token {
+	case token.Var:
+		return
+	case token.Const:
+		kind = DeclKindConst
+	}
+	for _, decl := range n.List {
+		bindingIdents(decl.Target.Target, func(id *ast.Identifier) {
+			r.declare(r.current, id, kind)
+		}, nil)
 	}
-
-	h.catchParamDecls = old
-	h.inCatchBody = oldInCatchBody
-	h.excludedFromCatch = oldExclude
 }
 
-func (h *hoister) VisitStatements(n *ast.Statements) {
-	others := make(ast.Statements, 0, len(*n))
-	for i := range *n {
-		switch it := (*n)[i].Stmt.(type) {
+// lexicalNames returns the names declared with let, const or class directly
+// in stmts.
+func lexicalNames(stmts ast.Statements) map[string]struct{} {
+	names := make(map[string]struct{})
+	for i := range stmts {
+		switch it := stmts[i].Stmt.(type) {
 		case *ast.VariableDeclaration:
-			it.VisitWith(h)
-		case *ast.FunctionDeclaration:
-			it.VisitWith(h)
-		default:
-			others = append(others, (*n)[i])
+			if it.Token == token.Var {
+				continue
+			}
+			for _, decl := range it.List {
+				bindingIdents(decl.Target.Target, func(id *ast.Identifier) {
+					names[id.Name] = struct{}{}
+				}, nil)
+			}
+		case *ast.ClassDeclaration:
+			names[it.Class.Name.Name] = struct{}{}
 		}
 	}
-
-	for i := range others {
-		others[i].VisitWith(h)
-	}
+	return names
 }
 
 func (h *hoister) VisitVariableDeclaration(n *ast.VariableDeclaration) {
-	if h.inBlock && n.Token != token.Var {
+	if n.Token != token.Var {
 		return
 	}
 
-	oldKind := h.kind
-	h.kind = DeclKindVar
-	n.VisitChildrenWith(h)
-	h.kind = oldKind
-}
-
-func (h *hoister) VisitBindingTarget(n *ast.BindingTarget) {
-	if ident, ok := n.Target.(*ast.Identifier); ok {
-		h.addIdent(ident)
-		return
+	r := h.resolver
+	for _, decl := range n.List {
+		bindingIdents(decl.Target.Target, func(id *ast.Identifier) {
+			if h.catchParams[id.Name] > 0 {
+				// The identifier refers to the catch parameter, but the var
+				// is still declared in the function.
+				r.symbol(r.current, id.Name, DeclKindVar)
+				return
+			}
+			r.declare(r.current, id, DeclKindVar)
+		}, nil)
 	}
-	n.VisitChildrenWith(h)
 }
 
+// VisitFunctionDeclaration hoists a function declared in a block to the
+// function scope, as sloppy mode code expects, unless a let, const, class or
+// parameter of the same name is in scope of it. It is otherwise declared in
+// its block, as it always is in strict mode code.
 func (h *hoister) VisitFunctionDeclaration(n *ast.FunctionDeclaration) {
-	if _, ok := h.catchParamDecls[n.Function.Name.Name]; ok {
+	if h.resolver.current.strict {
 		return
 	}
-
-	if h.inBlock {
-		if kind, declared := h.resolver.current.isDeclared(n.Function.Name.Name); declared {
-			if kind != DeclKindVar && kind != DeclKindFunction {
-				return
-			}
+	name := n.Function.Name.Name
+	for _, names := range h.lexical {
+		if _, ok := names[name]; ok {
+			return
 		}
 	}
+	if sym := h.resolver.current.byName[name]; sym != nil && (sym.Kind == DeclKindParam || sym.Kind.IsLexical()) {
+		return
+	}
+	h.resolver.declare(h.resolver.current, n.Function.Name, DeclKindFunction)
+}
 
-	h.resolver.modify(n.Function.Name, DeclKindFunction)
+func (h *hoister) VisitBlockStatement(n *ast.BlockStatement) {
+	h.lexical = append(h.lexical, lexicalNames(n.List))
+	n.VisitChildrenWith(h)
+	h.lexical = h.lexical[:len(h.lexical)-1]
 }
 
 func (h *hoister) VisitSwitchStatement(n *ast.SwitchStatement) {
-	n.Discriminant.VisitWith(h)
+	names := make(map[string]struct{})
+	for _, c := range n.Body {
+		for name := range lexicalNames(c.Consequent) {
+			names[name] = struct{}{}
+		}
+	}
 
-	old := h.inBlock
-	h.inBlock = true
+	h.lexical = append(h.lexical, names)
 	n.Body.VisitWith(h)
-	h.inBlock = old
+	h.lexical = h.lexical[:len(h.lexical)-1]
 }
 
-func (h *hoister) VisitArrowFunctionLiteral(*ast.ArrowFunctionLiteral) {}
-func (h *hoister) VisitExpression(*ast.Expression)                     {}
-func (h *hoister) VisitFunctionLiteral(*ast.FunctionLiteral)           {}
-
-type idsFinder struct {
-	ast.NoopVisitor
-
-	found []ast.Id
-}
-
-func findIds(n ast.VisitableNode) []ast.Id {
-	v := &idsFinder{}
-	v.V = v
-	n.VisitWith(v)
+func (h *hoister) VisitCatchStatement(n *ast.CatchStatement) {
+	var param *ast.Identifier
+	if n.Parameter != nil {
+		param, _ = n.Parameter.Target.(*ast.Identifier)
+	}
+	if param == nil {
+		n.Body.VisitWith(h)
+		return
+	}
 
-	return v.found
+	h.catchParams[param.Name]++
+	n.Body.VisitWith(h)
+	h.catchParams[param.Name]--
 }
 
-func (v *idsFinder) VisitExpression(*ast.Expression) {}
-
-func (v *idsFinder) VisitIdentifier(n *ast.Identifier) {
-	v.found = append(v.found, n.ToId())
-}
+func (h *hoister) VisitArrowFunctionLiteral(*ast.ArrowFunctionLiteral) {}
+func (h *hoister) VisitClassLiteral(*ast.ClassLiteral)                 {}
+func (h *hoister) VisitExpression(*ast.Expression)                     {}
+func (h *hoister) VisitFunctionLiteral(*ast.FunctionLiteral)           {}
@@ -0,0 +1,89 @@
+package resolver
+
+import "github.com/t14raptor/go-fast/ast"
+
+// bindingIdents calls bind for each identifier the binding pattern e
+// declares, in source order. Default values and computed keys found along
+// the way are passed to value, unless it is nil.
+func bindingIdents(e ast.Expr, bind func(*ast.Identifier), value func(*ast.Expression)) {
+	switch e := e.(type) {
+	case *ast.Identifier:
+		bind(e)
+	case *ast.ArrayPattern:
+		for _, el := range e.Elements {
+			bindingIdents(el.Expr, bind, value)
+		}
+		if e.Rest != nil {
+			bindingIdents(e.Rest.Expr, bind, value)
+		}
+	case *ast.ObjectPattern:
+		for _, p := range e.Properties {
+			switch p := p.Prop.(type) {
+			case *ast.PropertyShort:
+				bind(p.Name)
+				if p.Initializer != nil && value != nil {
+					value(p.Initializer)
+				}
+			case *ast.PropertyKeyed:
+				if p.Computed && value != nil {
+					value(p.Key)
+				}
+				bindingIdents(p.Value.Expr, bind, value)
+			case *ast.SpreadElement:
+				bindingIdents(p.Expression.Expr, bind, value)
+			}
+		}
+		bindingIdents(e.Rest, bind, value)
+	case *ast.AssignExpression:
+		// A default value, as in [a = 1].
+		bindingIdents(e.Left.Expr, bind, value)
+		if value != nil {
+			value(e.Right)
+		}
+	}
+}
+
+// writeTargets records the identifiers assigned by the assignment target e,
+// which may be a destructuring pattern, as referenced with kind.
+func writeTargets(e ast.Expr, kind ReferenceKind, targets map[*ast.Identifier]ReferenceKind) {
+	switch e := e.(type) {
+	case *ast.Identifier:
+		targets[e] = kind
+	case *ast.ArrayLiteral:
+		for _, el := range e.Value {
+			writeTargets(el.Expr, kind, targets)
+		}
+	case *ast.ArrayPattern:
+		for _, el := range e.Elements {
+			writeTargets(el.Expr, kind, targets)
+		}
+		if e.Rest != nil {
+			writeTargets(e.Rest.Expr, kind, targets)
+		}
+	case *ast.ObjectLiteral:
+		for _, p := range e.Value {
+			writePropertyTargets(p.Prop, kind, targets)
+		}
+	case *ast.ObjectPattern:
+		for _, p := range e.Properties {
+			writePropertyTargets(p.Prop, kind, targets)
+		}
+		writeTargets(e.Rest, kind, targets)
+	case *ast.SpreadElement:
+		writeTargets(e.Expression.Expr, kind, targets)
+	case *ast.AssignExpression:
+		// A default value, as in [a = 1] = list.
+		writeTargets(e.Left.Expr, kind, targets)
+	}
+}
+
+func writePropertyTargets(p ast.Prop, kind ReferenceKind, targets map[*ast.Identifier]ReferenceKind) {
+	switch p := p.(type) {
+	case *ast.PropertyShort:
+		targets[p.Name] = kind
+	case *ast.PropertyKeyed:
+		writeTargets(p.Value.Expr, kind, targets)
+	case *ast.SpreadElement:
+		writeTargets(p.Expression.Expr, kind, targets)
+	}
+}
@@ -0,0 +1,101 @@
+package resolver
+
+import (
+	"slices"
+
+	"github.com/t14raptor/go-fast/ast"
+)
+
+// Clear removes the scope contexts stamped onto the tree p, so that Resolve
+// can resolve it again from scratch. Resolve leaves resolved identifiers
+// alone, so resolving a rewritten tree without clearing it first keeps the
+// stale contexts.
+func Clear(p ast.VisitableNode) {
+	clearContexts(p, nil)
+}
+
+// clearContexts clears the scope contexts of p but for keep's.
+func clearContexts(p ast.VisitableNode, keep *ast.Identifier) {
+	ast.Inspect(p, func(n ast.VisitableNode) bool {
+		switch n := n.(type) {
+		case *ast.Identifier:
+			if n != keep {
+				n.ScopeContext = UnresolvedMark
+			}
+		case *ast.BlockStatement:
+			n.ScopeContext = UnresolvedMark
+		case *ast.FunctionLiteral:
+			n.ScopeContext = UnresolvedMark
+		case *ast.ArrowFunctionLiteral:
+			n.ScopeContext = UnresolvedMark
+		}
+		return true
+	})
+}
+
+// Reresolve resolves the function or program scope is in again, after a
+// pass rewrote its tree, and updates t in step. The rest of the tree is left
+// alone, so a pass need only re-resolve the functions it changed rather
+// than the whole program. The function scope is in keeps its Scope and
+// context; the scopes and symbols nested in it are replaced, coming last in
+// t.Symbols(), and the references it made to the bindings around it are
+// found again.
+//
+// The node opening the function must still be in the tree, and the changes
+// must not reach out of it: a function declaration keeps the name it was
+// declared with, and a var declared in it stays its own. Re-resolve the
+// program, t.Root(), otherwise. The flags of the scopes around the function
+// telling it uses arguments are kept.
+func (t *SymbolTable) Reresolve(scope *Scope) {
+	scope = scope.function()
+
+	// Forget the symbols and scopes of the function, and its uses of the
+	// bindings around it.
+	t.symbols = slices.DeleteFunc(t.symbols, func(sym *Symbol) bool {
+		if within(sym.Scope, scope) {
+			return true
+		}
+		sym.References = slices.DeleteFunc(sym.References, func(ref Reference) bool {
+			return within(ref.Scope, scope)
+		})
+		if sym.Kind == DeclKindGlobal && len(sym.References) == 0 {
+			delete(sym.Scope.byName, sym.Name)
+			sym.Scope.symbols = slices.DeleteFunc(sym.Scope.symbols, func(s *Symbol) bool { return s == sym })
+			return true
+		}
+		return false
+	})
+	for ctx, s := range t.scopes {
+		if s != scope && within(s, scope) {
+			delete(t.scopes, ctx)
+		}
+	}
+	scope.children, scope.symbols = nil, nil
+	scope.byName = make(map[string]*Symbol)
+	scope.usesEval, scope.usesWith, scope.usesArguments = false, false, false
+	scope.strict = scope.parent != nil && scope.parent.strict
+
+	var keep *ast.Identifier
+	if f, ok := scope.node.(*ast.FunctionLiteral); ok && f.Name != nil && f.Name.ScopeContext != scope.ctx {
+		keep = f.Name
+	}
+	clearContexts(scope.node, keep)
+
+	r := newResolver(nil)
+	r.table = t
+	r.current = scope
+	if scope.with != nil {
+		r.with = []*Scope{scope.with}
+	}
+	switch n := scope.node.(type) {
+	case *ast.Program:
+		r.resolveProgram(n)
+	case *ast.FunctionLiteral:
+		r.resolveFunction(n)
+	case *ast.ArrowFunctionLiteral:
+		r.resolveArrow(n)
+	case *ast.ClassStaticBlock:
+		r.resolveStaticBlock(n)
+	}
+	r.markDynamic()
+}
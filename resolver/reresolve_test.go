@@ -0,0 +1,80 @@
+package resolver_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/generator"
+	"github.com/t14raptor/go-fast/parser"
+	"github.com/t14raptor/go-fast/resolver"
+)
+
+// summarize describes the symbols of table by name and kind rather than by
+// scope context, sorted, so that tables resolved apart can be compared.
+func summarize(table *resolver.SymbolTable) []string {
+	var list []string
+	for _, sym := range table.Symbols() {
+		var dynamic int
+		for _, ref := range sym.References {
+			if ref.Dynamic {
+				dynamic++
+			}
+		}
+		list = append(list, fmt.Sprintf("%s %v in %v d%d r%d dyn%d",
+			sym.Name, sym.Kind, sym.Scope.Kind(), len(sym.Decls), len(sym.References), dynamic))
+	}
+	slices.Sort(list)
+	return list
+}
+
+func TestReresolve(t *testing.T) {
+	tests := []struct {
+		src, body string
+	}{
+		{"var a = 1; function f(b) { return a + b; } f(2);", "let a = b; return a + c;"},
+		{"var a; function f(b) { { let a; a; } a; }", "eval(s); a; function g() { return b; }"},
+		{"var a; with (o) { (function f() { a; }); }", "a; f; b;"},
+	}
+	for _, tt := range tests {
+		program, table := resolve(t, tt.src)
+		var fn *ast.FunctionLiteral
+		ast.Inspect(program, func(n ast.VisitableNode) bool {
+			if f, ok := n.(*ast.FunctionLiteral); ok && fn == nil {
+				fn = f
+			}
+			return true
+		})
+		scope := table.Scope(fn.ScopeContext)
+
+		parsed, err := parser.ParseFile("(function () {" + tt.body + "})")
+		if err != nil {
+			t.Fatal(err)
+		}
+		body := parsed.Body[0].Stmt.(*ast.ExpressionStatement).Expression.Expr.(*ast.FunctionLiteral).Body
+		fn.Body.List = body.List
+		table.Reresolve(scope)
+
+		if table.Scope(fn.ScopeContext) != scope || scope.Node() != fn {
+			t.Errorf("%s: the scope of the function changed", tt.src)
+		}
+		ast.Inspect(program, func(n ast.VisitableNode) bool {
+			if id, ok := n.(*ast.Identifier); ok && id.ScopeContext != resolver.DynamicMark && table.SymbolOf(id) == nil {
+				t.Errorf("%s: %s is left unresolved", tt.src, id.Name)
+			}
+			return true
+		})
+
+		code := generator.Generate(program)
+		_, want := resolve(t, code)
+		if got, want := summarize(table), summarize(want); !slices.Equal(got, want) {
+			t.Errorf("%s:\ngot  %q\nwant %q", code, got, want)
+		}
+
+		resolver.Clear(program)
+		if got := summarize(resolver.Resolve(program)); !slices.Equal(got, summarize(want)) {
+			t.Errorf("%s: cleared and resolved again:\ngot  %q\nwant %q", code, got, summarize(want))
+		}
+	}
+}
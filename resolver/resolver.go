@@ -1,21 +1,32 @@
 package resolver
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/parser/scanner/token"
 )
 
+// IdentType tells references from bindings.
+//
+// Deprecated: The resolver no longer uses it. Use the Decls and References
+// of a Symbol instead.
 type IdentType int
 
 const (
-	IdentTypeRef     IdentType = iota // Reference (read)
-	IdentTypeBinding                  // Binding (declaration)
+	// Deprecated: See IdentType.
+	IdentTypeRef IdentType = iota // Reference (read)
+	// Deprecated: See IdentType.
+	IdentTypeBinding // Binding (declaration)
 )
 
 const (
 	UnresolvedMark ast.ScopeContext = 0
 	TopLevelMark   ast.ScopeContext = 1
+	// DynamicMark is stamped onto the references that eval or with may
+	// redirect at run time, which no scope context describes. Passes must
+	// treat them like unknown globals.
+	DynamicMark ast.ScopeContext = -1
 )
 
 type Resolver struct {
@@ -23,33 +34,132 @@ type Resolver struct {
 
 	current *Scope
 
-	identType IdentType
-	declKind  DeclKind
+	table *SymbolTable
+	// writes holds the identifiers about to be visited that are assigned
+	// rather than only read.
+	writes map[*ast.Identifier]ReferenceKind
+	// with holds the scopes of the with statements whose body is being
+	// visited, innermost last.
+	with []*Scope
 
-	nextCtxt ast.ScopeContext
+	ctx   context.Context
+	steps int
 }
 
-func Resolve(p ast.VisitableNode) *Resolver {
+func newResolver(ctx context.Context) *Resolver {
 	r := &Resolver{
-		identType: IdentTypeRef,
-		nextCtxt:  TopLevelMark,
+		table: &SymbolTable{
+			scopes: make(map[ast.ScopeContext]*Scope),
+			next:   TopLevelMark,
+			names:  make(map[string]struct{}),
+		},
+		writes: make(map[*ast.Identifier]ReferenceKind),
+		ctx:    ctx,
 	}
 	r.V = r
-
-	p.VisitWith(r)
 	return r
 }
 
-func (r *Resolver) pushScope(kind ScopeKind) {
-	ctx := r.nextCtxt
-	r.nextCtxt++
+// Resolve stamps a scope context onto the identifiers of the tree rooted at
+// p, so that identifiers referring to the same binding share an ast.Id, and
+// returns the scopes and symbols it found. The identifiers must not be
+// resolved yet; see Clear and SymbolTable.Reresolve for rewritten trees.
+//
+// A root other than a Program is given a root scope of its own, holding the
+// globals and what a root statement declares.
+func Resolve(p ast.VisitableNode) *SymbolTable {
+	r := newResolver(nil)
+	r.resolve(p)
+	return r.table
+}
+
+// resolve resolves the tree rooted at p and flags its dynamic scopes.
+func (r *Resolver) resolve(p ast.VisitableNode) {
+	if _, ok := p.(*ast.Program); ok {
+		p.VisitWith(r)
+	} else {
+		r.pushScope(ScopeKindProgram, p)
+		if stmt, ok := p.(ast.Stmt); ok {
+			r.hoistFunction(ast.Statements{{Stmt: stmt}})
+		}
+		p.VisitWith(r)
+		r.popScope()
+	}
+	r.markDynamic()
+}
+
+// ctxCheckInterval is the number of identifiers resolved between two checks
+// of the resolver's context.
+const ctxCheckInterval = 1024
+
+// cancelled is the panic value used to unwind the resolver once its context
+// is done.
+type cancelled struct {
+	err error
+}
+
+// ResolveContext is like Resolve but stops as soon as ctx is done, returning
+// ctx's error. The scope contexts of a cancelled resolution are only
+// partially assigned.
+func ResolveContext(ctx context.Context, p ast.VisitableNode) (res *SymbolTable, err error) {
+	r := newResolver(ctx)
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			c, ok := rec.(cancelled)
+			if !ok {
+				panic(rec)
+			}
+			res, err = nil, c.err
+		}
+	}()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.resolve(p)
+	return r.table, nil
+}
+
+// checkContext periodically aborts the resolution if r's context is done.
+func (r *Resolver) checkContext() {
+	if r.ctx == nil {
+		return
+	}
+	r.steps++
+	if r.steps%ctxCheckInterval != 0 {
+		return
+	}
+	if err := r.ctx.Err(); err != nil {
+		panic(cancelled{err: err})
+	}
+}
+
+func (r *Resolver) pushScope(kind ScopeKind, node ast.VisitableNode) {
+	r.checkContext()
+
+	ctx := r.table.next
+	r.table.next++
 
-	r.current = &Scope{
-		parent:          r.current,
-		kind:            kind,
-		declaredSymbols: make(map[string]DeclKind),
-		ctx:             ctx,
+	scope := &Scope{
+		parent: r.current,
+		table:  r.table,
+		kind:   kind,
+		node:   node,
+		byName: make(map[string]*Symbol),
+		ctx:    ctx,
+		strict: kind == ScopeKindClass || r.current != nil && r.current.strict,
+	}
+	if len(r.with) > 0 {
+		scope.with = r.with[len(r.with)-1]
+	}
+	if r.current != nil {
+		r.current.children = append(r.current.children, scope)
+	} else if r.table.root == nil {
+		r.table.root = scope
 	}
+	r.table.scopes[ctx] = scope
+	r.current = scope
 }
 
 func (r *Resolver) popScope() {
@@ -58,205 +168,459 @@ func (r *Resolver) popScope() {
 	}
 }
 
-func (r *Resolver) modify(id *ast.Identifier, kind DeclKind) {
+// declare declares id in scope with kind, unless id is already resolved.
+func (r *Resolver) declare(scope *Scope, id *ast.Identifier, kind DeclKind) {
+	if id.ScopeContext != UnresolvedMark {
+		return
+	}
+
+	sym := r.symbol(scope, id.Name, kind)
+	id.ScopeContext = scope.ctx
+	sym.Decls = append(sym.Decls, id)
+}
+
+// symbol returns the symbol of name in scope, creating it with kind if
+// there is none. A function declaration takes over a var of the same name.
+func (r *Resolver) symbol(scope *Scope, name string, kind DeclKind) *Symbol {
+	sym, ok := scope.byName[name]
+	if !ok {
+		sym = &Symbol{Name: name, Kind: kind, Scope: scope}
+		scope.byName[name] = sym
+		scope.symbols = append(scope.symbols, sym)
+		r.table.symbols = append(r.table.symbols, sym)
+		r.table.names[name] = struct{}{}
+	} else if kind == DeclKindFunction && sym.Kind == DeclKindVar {
+		sym.Kind = kind
+	}
+	return sym
+}
+
+// bind declares the binding identifier id with kind in the current scope.
+// A var is hoisted before its scope is resolved, except when it shares the
+// name of a catch parameter in scope, which it then declares again.
+func (r *Resolver) bind(id *ast.Identifier, kind DeclKind) {
 	if id.ScopeContext != UnresolvedMark {
 		return
 	}
+	r.checkContext()
+
+	if kind == DeclKindVar {
+		if sym := r.current.Lookup(id.Name); sym != nil {
+			id.ScopeContext = sym.Scope.ctx
+			sym.Decls = append(sym.Decls, id)
+			return
+		}
+		r.declare(r.current.function(), id, kind)
+		return
+	}
+	r.declare(r.current, id, kind)
+}
+
+// bindPattern binds the identifiers of the binding pattern e with kind,
+// resolving its default values and computed keys.
+func (r *Resolver) bindPattern(e ast.Expr, kind DeclKind) {
+	bindingIdents(e, func(id *ast.Identifier) {
+		r.bind(id, kind)
+	}, func(value *ast.Expression) {
+		value.VisitWith(r)
+	})
+}
 
-	r.current.declaredSymbols[id.Name] = kind
+// initialize ends the temporal dead zone of the lexical bindings of the
+// binding pattern e.
+func (r *Resolver) initialize(e ast.Expr) {
+	bindingIdents(e, func(id *ast.Identifier) {
+		if sym := r.table.SymbolOf(id); sym != nil {
+			sym.initialized = true
+		}
+	}, nil)
+}
 
-	id.ScopeContext = r.current.ctx
+// reference records id as a reference to sym.
+func (r *Resolver) reference(id *ast.Identifier, sym *Symbol) {
+	kind := r.writes[id]
+	delete(r.writes, id)
+	tdz := (sym.Kind.IsLexical() || sym.Kind == DeclKindParam) && !sym.initialized &&
+		r.current.function() == sym.Scope.function()
+	sym.References = append(sym.References, Reference{
+		Ident:   id,
+		Kind:    kind,
+		Scope:   r.current,
+		TDZ:     tdz,
+		Dynamic: id.ScopeContext == DynamicMark,
+	})
 }
 
-func (r *Resolver) lookupContext(sym string) (ast.ScopeContext, *Scope) {
-	for scope := r.current; scope != nil; scope = scope.parent {
-		if _, exists := scope.declaredSymbols[sym]; exists {
-			return scope.ctx, scope
+// bindParams binds the parameters of a function in the current scope. All
+// of them are in scope of the default values, which run left to right with
+// each parameter in its temporal dead zone until it is reached.
+func (r *Resolver) bindParams(n *ast.ParameterList) {
+	bind := func(id *ast.Identifier) {
+		r.bind(id, DeclKindParam)
+	}
+	for _, param := range n.List {
+		bindingIdents(param.Target.Target, bind, nil)
+	}
+	if n.Rest != nil {
+		bindingIdents(n.Rest, bind, nil)
+	}
+
+	for _, param := range n.List {
+		if param.Initializer != nil {
+			param.Initializer.VisitWith(r)
 		}
+		r.initializeParam(param.Target.Target)
+	}
+	if n.Rest != nil {
+		r.initializeParam(n.Rest)
 	}
-	return UnresolvedMark, nil
+}
+
+// initializeParam resolves the default values and computed keys of the
+// parameter pattern e, ending the temporal dead zone of its bindings in
+// turn.
+func (r *Resolver) initializeParam(e ast.Expr) {
+	bindingIdents(e, func(id *ast.Identifier) {
+		if sym := r.table.SymbolOf(id); sym != nil {
+			sym.initialized = true
+		}
+	}, func(value *ast.Expression) {
+		value.VisitWith(r)
+	})
 }
 
 func (r *Resolver) VisitArrowFunctionLiteral(n *ast.ArrowFunctionLiteral) {
-	r.pushScope(ScopeKindFunction)
+	r.pushScope(ScopeKindFunction, n)
+	r.resolveArrow(n)
+	r.popScope()
+}
 
+// resolveArrow resolves the arrow function n in its scope, the current one.
+func (r *Resolver) resolveArrow(n *ast.ArrowFunctionLiteral) {
 	n.ScopeContext = r.current.ctx
 
-	oldIdentType := r.identType
-	r.identType = IdentTypeBinding
-	n.ParameterList.VisitWith(r)
+	if body, ok := n.Body.Body.(*ast.BlockStatement); ok && hasUseStrict(body.List) {
+		r.current.strict = true
+	}
+	r.bindParams(n.ParameterList)
 
-	r.identType = IdentTypeRef
 	switch body := n.Body.Body.(type) {
 	case *ast.BlockStatement:
 		body.ScopeContext = r.current.ctx
 		// Prevent creating a new scope.
+		r.hoistFunction(body.List)
 		body.VisitChildrenWith(r)
 	case *ast.Expression:
 		body.VisitWith(r)
 	}
-	r.identType = oldIdentType
-
-	r.popScope()
 }
 
 func (r *Resolver) VisitBlockStatement(n *ast.BlockStatement) {
-	r.pushScope(ScopeKindBlock)
+	r.pushScope(ScopeKindBlock, n)
 	n.ScopeContext = r.current.ctx
+	r.hoistBlock(n.List)
 	n.VisitChildrenWith(r)
 	r.popScope()
 }
 
-func (r *Resolver) VisitForOfStatement(n *ast.ForOfStatement) {
-	r.pushScope(ScopeKindBlock)
-
-	oldIdentType := r.identType
-	r.identType = IdentTypeRef
+func (r *Resolver) VisitSwitchStatement(n *ast.SwitchStatement) {
+	n.Discriminant.VisitWith(r)
 
-	n.Into.VisitWith(r)
-	n.Source.VisitWith(r)
+	// The cases share a block scope.
+	r.pushScope(ScopeKindBlock, n)
+	for _, c := range n.Body {
+		r.hoistBlock(c.Consequent)
+	}
+	n.Body.VisitWith(r)
+	r.popScope()
+}
 
-	if blockStmt, ok := n.Body.Stmt.(*ast.BlockStatement); ok {
-		blockStmt.ScopeContext = r.current.ctx
+func (r *Resolver) VisitCatchStatement(n *ast.CatchStatement) {
+	r.pushScope(ScopeKindCatch, n)
+	if n.Parameter != nil {
+		r.bindPattern(n.Parameter.Target, DeclKindCatch)
 	}
 	n.Body.VisitWith(r)
+	r.popScope()
+}
 
-	r.identType = oldIdentType
+func (r *Resolver) VisitForOfStatement(n *ast.ForOfStatement) {
+	r.pushScope(ScopeKindLoop, n)
+	r.visitForInto(n.Into, n.Source)
+	n.Body.VisitWith(r)
 	r.popScope()
 }
 
 func (r *Resolver) VisitForInStatement(n *ast.ForInStatement) {
-	r.pushScope(ScopeKindBlock)
-
-	oldIdentType := r.identType
-	r.identType = IdentTypeRef
-
-	n.Into.VisitWith(r)
-	n.Source.VisitWith(r)
+	r.pushScope(ScopeKindLoop, n)
+	r.visitForInto(n.Into, n.Source)
+	n.Body.VisitWith(r)
+	r.popScope()
+}
 
-	if blockStmt, ok := n.Body.Stmt.(*ast.BlockStatement); ok {
-		blockStmt.ScopeContext = r.current.ctx
+// visitForInto resolves the head of a for-in or for-of statement. The
+// source is resolved in scope of the let and const bindings of the head,
+// which are in their temporal dead zone until it has run.
+func (r *Resolver) visitForInto(into *ast.ForInto, source *ast.Expression) {
+	decl, ok := into.Into.(*ast.VariableDeclaration)
+	if !ok {
+		into.VisitWith(r)
+		source.VisitWith(r)
+		return
 	}
-	n.Body.VisitWith(r)
 
-	r.identType = oldIdentType
-	r.popScope()
+	r.declareLexical(decl)
+	kind := declKind(decl)
+	for _, d := range decl.List {
+		r.bindPattern(d.Target.Target, kind)
+		if d.Initializer != nil {
+			d.Initializer.VisitWith(r)
+		}
+	}
+	source.VisitWith(r)
+	for _, d := range decl.List {
+		r.initialize(d.Target.Target)
+	}
 }
 
 func (r *Resolver) VisitForStatement(n *ast.ForStatement) {
-	r.pushScope(ScopeKindBlock)
-
-	oldIdentType := r.identType
-	r.identType = IdentTypeBinding
+	r.pushScope(ScopeKindLoop, n)
 
-	// Handle initializer
 	if n.Initializer != nil {
+		if decl, ok := n.Initializer.Initializer.(*ast.VariableDeclaration); ok {
+			r.declareLexical(decl)
+		}
 		n.Initializer.VisitWith(r)
 	}
-
-	// Handle test expression
-	r.identType = IdentTypeRef
 	n.Test.VisitWith(r)
-
-	// Handle update expression
 	n.Update.VisitWith(r)
-
-	// Handle body
-	r.identType = oldIdentType
 	n.Body.VisitWith(r)
 
 	r.popScope()
 }
 
+// VisitFunctionDeclaration resolves a function whose name has been declared
+// in the enclosing scope by hoisting.
+func (r *Resolver) VisitFunctionDeclaration(n *ast.FunctionDeclaration) {
+	r.declare(r.current, n.Function.Name, DeclKindFunction)
+	r.visitFunction(n.Function)
+}
+
+// VisitFunctionLiteral resolves a function expression or method, whose name
+// is only in scope of its own body.
 func (r *Resolver) VisitFunctionLiteral(n *ast.FunctionLiteral) {
-	if n.Name != nil {
-		r.modify(n.Name, DeclKindFunction)
-	}
+	r.visitFunction(n)
+}
 
-	r.pushScope(ScopeKindFunction)
+func (r *Resolver) visitFunction(n *ast.FunctionLiteral) {
+	r.pushScope(ScopeKindFunction, n)
+	r.resolveFunction(n)
+	r.popScope()
+}
 
+// resolveFunction resolves the function n in its scope, the current one.
+// The name of a function declaration must already be resolved.
+func (r *Resolver) resolveFunction(n *ast.FunctionLiteral) {
 	n.ScopeContext = r.current.ctx
+	if hasUseStrict(n.Body.List) {
+		r.current.strict = true
+	}
 
-	oldIdentType := r.identType
-	r.identType = IdentTypeBinding
-	n.ParameterList.VisitWith(r)
-
-	if rest, ok := n.ParameterList.Rest.(*ast.Identifier); ok {
-		rest.VisitWith(r)
-	} else if n.ParameterList.Rest != nil {
-		panic(fmt.Sprintf("Unexpected rest type: %T\n", n.ParameterList.Rest))
+	if n.Name != nil {
+		r.declare(r.current, n.Name, DeclKindFunction)
 	}
+	r.bindParams(n.ParameterList)
 
-	r.identType = IdentTypeRef
 	// Prevent creating new scope.
 	n.Body.ScopeContext = r.current.ctx
+	r.hoistFunction(n.Body.List)
 	n.Body.VisitChildrenWith(r)
+}
+
+// VisitClassDeclaration resolves a class whose name has been declared in
+// the enclosing scope by hoisting.
+func (r *Resolver) VisitClassDeclaration(n *ast.ClassDeclaration) {
+	r.declare(r.current, n.Class.Name, DeclKindClass)
+	sym := r.table.SymbolOf(n.Class.Name)
+	r.visitClass(n.Class, sym)
+}
+
+// VisitClassLiteral resolves a class expression, whose name is only in
+// scope of the class itself.
+func (r *Resolver) VisitClassLiteral(n *ast.ClassLiteral) {
+	r.visitClass(n, nil)
+}
+
+// visitClass resolves the class n. The binding of its name, sym for a
+// class declaration, is in its temporal dead zone until the heritage has
+// run.
+func (r *Resolver) visitClass(n *ast.ClassLiteral, sym *Symbol) {
+	r.pushScope(ScopeKindClass, n)
+
+	if sym == nil && n.Name != nil {
+		r.declare(r.current, n.Name, DeclKindClass)
+		sym = r.table.SymbolOf(n.Name)
+	}
+	if n.SuperClass != nil {
+		n.SuperClass.VisitWith(r)
+	}
+	if sym != nil {
+		sym.initialized = true
+	}
+	n.Body.VisitWith(r)
 
-	r.identType = oldIdentType
+	r.popScope()
+}
 
+// VisitClassStaticBlock resolves a static block, which holds its own var
+// declarations like a function body.
+func (r *Resolver) VisitClassStaticBlock(n *ast.ClassStaticBlock) {
+	r.pushScope(ScopeKindFunction, n)
+	r.resolveStaticBlock(n)
 	r.popScope()
 }
 
+// resolveStaticBlock resolves the static block n in its scope, the current
+// one.
+func (r *Resolver) resolveStaticBlock(n *ast.ClassStaticBlock) {
+	n.Block.ScopeContext = r.current.ctx
+	r.hoistFunction(n.Block.List)
+	n.Block.VisitChildrenWith(r)
+}
+
 func (r *Resolver) VisitProgram(n *ast.Program) {
-	r.pushScope(ScopeKindBlock)
-	n.VisitChildrenWith(r)
+	r.pushScope(ScopeKindProgram, n)
+	r.resolveProgram(n)
 	r.popScope()
 }
 
-func (r *Resolver) VisitStatements(n *ast.Statements) {
-	// Handle hoisting
-	h := newHoister(r)
-	h.V = h
-	n.VisitWith(h)
-
-	// Resolve
+// resolveProgram resolves the program n in its scope, the current one.
+func (r *Resolver) resolveProgram(n *ast.Program) {
+	r.current.strict = hasUseStrict(n.Body)
+	r.hoistFunction(n.Body)
 	n.VisitChildrenWith(r)
 }
 
-func (r *Resolver) VisitVariableDeclaration(n *ast.VariableDeclaration) {
-	oldDeclKind := r.declKind
-	r.declKind = DeclKindVar
+// hasUseStrict reports whether the directive prologue of stmts, a function
+// body or program, holds a "use strict" directive.
+func hasUseStrict(stmts ast.Statements) bool {
+	for _, stmt := range stmts {
+		expr, ok := stmt.Stmt.(*ast.ExpressionStatement)
+		if !ok {
+			return false
+		}
+		lit, ok := expr.Expression.Expr.(*ast.StringLiteral)
+		if !ok {
+			return false
+		}
+		// The directive must be written without escapes.
+		if lit.Raw != nil && (*lit.Raw == `"use strict"` || *lit.Raw == `'use strict'`) {
+			return true
+		}
+	}
+	return false
+}
 
-	for _, decl := range n.List {
-		oldIdentType := r.identType
-		r.identType = IdentTypeBinding
-		decl.Target.VisitWith(r)
-		r.identType = oldIdentType
+// markDynamic flags the scopes in reach of eval or with, and the functions
+// whose arguments object a direct eval may use, clearing the flags of the
+// others. It then stamps DynamicMark
+// onto the references a var declared by a direct eval in sloppy mode code
+// may shadow: those reaching out of the function of the eval.
+func (r *Resolver) markDynamic() {
+	evalVars := make(map[*Scope]bool)
+	var walk func(s *Scope) bool
+	walk = func(s *Scope) bool {
+		if s.usesEval {
+			if !s.strict {
+				evalVars[s.function()] = true
+			}
+			if f := s.arguments(); f != nil {
+				f.usesArguments = true
+			}
+		}
+		s.dynamic = s.usesEval || s.usesWith
+		for _, child := range s.children {
+			if walk(child) {
+				s.dynamic = true
+			}
+		}
+		return s.dynamic
+	}
+	walk(r.table.root)
+	if len(evalVars) == 0 {
+		return
+	}
+
+	for _, sym := range r.table.symbols {
+		for i := range sym.References {
+			ref := &sym.References[i]
+			for s := ref.Scope; s != nil && s != sym.Scope; s = s.parent {
+				if evalVars[s] {
+					ref.Dynamic = true
+					ref.Ident.ScopeContext = DynamicMark
+					break
+				}
+			}
+		}
+	}
+}
 
+// declKind returns the kind of the bindings of n.
+func declKind(n *ast.VariableDeclaration) DeclKind {
+	switch n.Token {
+	case token.Let:
+		return DeclKindLet
+	case token.Const:
+		return DeclKindConst
+	}
+	return DeclKindVar
+}
+
+func (r *Resolver) VisitVariableDeclaration(n *ast.VariableDeclaration) {
+	kind := declKind(n)
+	for _, decl := range n.List {
+		r.bindPattern(decl.Target.Target, kind)
 		if decl.Initializer != nil {
 			decl.Initializer.VisitWith(r)
 		}
+		r.initialize(decl.Target.Target)
 	}
-
-	r.declKind = oldDeclKind
 }
 
 func (r *Resolver) VisitExpression(expr *ast.Expression) {
 	if expr == nil || expr.Expr == nil {
 		return
 	}
-
-	oldIdentType := r.identType
-	r.identType = IdentTypeRef
 	expr.VisitChildrenWith(r)
-	r.identType = oldIdentType
 }
 
+// VisitIdentifier resolves a reference to the closest declaration of its
+// name in scope. A name declared nowhere is a global of the root scope.
 func (r *Resolver) VisitIdentifier(n *ast.Identifier) {
 	if n == nil || n.ScopeContext != UnresolvedMark {
 		return
 	}
+	r.checkContext()
 
-	switch r.identType {
-	case IdentTypeBinding:
-		r.modify(n, r.declKind)
-	case IdentTypeRef:
-		if mark, _ := r.lookupContext(n.Name); mark != UnresolvedMark {
-			n.ScopeContext = mark
-		} else {
-			r.modify(n, r.declKind)
+	sym := r.current.Lookup(n.Name)
+	if sym == nil {
+		sym = r.symbol(r.table.root, n.Name, DeclKindGlobal)
+	}
+	if sym.Kind == DeclKindGlobal && n.Name == "arguments" {
+		if f := r.current.arguments(); f != nil {
+			f.usesArguments = true
 		}
 	}
+
+	// In the body of a with statement, a name not declared in the body may
+	// be a property of the object.
+	if len(r.with) > 0 && within(r.with[len(r.with)-1], sym.Scope) {
+		n.ScopeContext = DynamicMark
+		r.reference(n, sym)
+		return
+	}
+	n.ScopeContext = sym.Scope.ctx
+	r.reference(n, sym)
 }
 
 func (r *Resolver) VisitMemberProperty(n *ast.MemberProperty) {
@@ -264,3 +628,61 @@ func (r *Resolver) VisitMemberProperty(n *ast.MemberProperty) {
 		computed.VisitWith(r)
 	}
 }
+
+// VisitMetaProperty leaves the "new" and "target" names of new.target alone;
+// they are keywords, not references.
+func (r *Resolver) VisitMetaProperty(n *ast.MetaProperty) {}
+
+// VisitPrivateIdentifier leaves private names alone; they are properties of
+// a class, not bindings.
+func (r *Resolver) VisitPrivateIdentifier(n *ast.PrivateIdentifier) {}
+
+// VisitLabelledStatement leaves the label alone; labels live apart from
+// bindings.
+func (r *Resolver) VisitLabelledStatement(n *ast.LabelledStatement) {
+	n.Statement.VisitWith(r)
+}
+
+func (r *Resolver) VisitBreakStatement(n *ast.BreakStatement)       {}
+func (r *Resolver) VisitContinueStatement(n *ast.ContinueStatement) {}
+
+func (r *Resolver) VisitAssignExpression(n *ast.AssignExpression) {
+	kind := ReferenceWrite
+	if n.Operator != ast.AssignmentAssign {
+		kind = ReferenceReadWrite
+	}
+	writeTargets(n.Left.Expr, kind, r.writes)
+	n.VisitChildrenWith(r)
+}
+
+func (r *Resolver) VisitUpdateExpression(n *ast.UpdateExpression) {
+	writeTargets(n.Operand.Expr, ReferenceReadWrite, r.writes)
+	n.VisitChildrenWith(r)
+}
+
+// VisitForInto records the expression a for-in or for-of statement assigns
+// each value to as written.
+func (r *Resolver) VisitForInto(n *ast.ForInto) {
+	if e, ok := n.Into.(*ast.Expression); ok {
+		writeTargets(e.Expr, ReferenceWrite, r.writes)
+	}
+	n.VisitChildrenWith(r)
+}
+
+// VisitCallExpression flags the scopes calling eval directly.
+func (r *Resolver) VisitCallExpression(n *ast.CallExpression) {
+	if id, ok := n.Callee.Expr.(*ast.Identifier); ok && id.Name == "eval" {
+		if sym := r.current.Lookup(id.Name); sym == nil || sym.Kind == DeclKindGlobal {
+			r.current.usesEval = true
+		}
+	}
+	n.VisitChildrenWith(r)
+}
+
+func (r *Resolver) VisitWithStatement(n *ast.WithStatement) {
+	r.current.usesWith = true
+	n.Object.VisitWith(r)
+	r.with = append(r.with, r.current)
+	n.Body.VisitWith(r)
+	r.with = r.with[:len(r.with)-1]
+}
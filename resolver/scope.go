@@ -2,35 +2,231 @@ package resolver
 
 import "github.com/t14raptor/go-fast/ast"
 
+// DeclKind is the way a symbol is declared.
 type DeclKind int
 
 const (
 	DeclKindVar DeclKind = iota
 	DeclKindFunction
+	DeclKindLet
+	DeclKindConst
+	DeclKindClass
+	DeclKindParam
+	// DeclKindCatch is the parameter of a catch clause.
+	DeclKindCatch
+	// DeclKindImport is an import binding. The parser does not read modules
+	// yet, so no symbol has this kind so far.
+	DeclKindImport
+	// DeclKindGlobal is a name used without being declared, which lives in
+	// the root scope.
+	DeclKindGlobal
 )
 
+func (k DeclKind) String() string {
+	switch k {
+	case DeclKindVar:
+		return "var"
+	case DeclKindFunction:
+		return "function"
+	case DeclKindLet:
+		return "let"
+	case DeclKindConst:
+		return "const"
+	case DeclKindClass:
+		return "class"
+	case DeclKindParam:
+		return "param"
+	case DeclKindCatch:
+		return "catch"
+	case DeclKindImport:
+		return "import"
+	case DeclKindGlobal:
+		return "global"
+	}
+	return "unknown"
+}
+
+// IsLexical reports whether symbols of kind k are block scoped and
+// unreadable before their declaration runs: let, const and class.
+func (k DeclKind) IsLexical() bool {
+	return k == DeclKindLet || k == DeclKindConst || k == DeclKindClass
+}
+
 type ScopeKind int
 
 const (
 	ScopeKindBlock ScopeKind = iota
+	// ScopeKindFunction holds the name of a function expression, the
+	// parameters and the var, function and top-level lexical declarations
+	// of a function.
 	ScopeKindFunction
+	// ScopeKindProgram is the top-level scope of a program, holding its
+	// var, function and lexical declarations and the globals.
+	ScopeKindProgram
+	// ScopeKindCatch holds the parameter of a catch clause; the body is a
+	// block scope inside it.
+	ScopeKindCatch
+	// ScopeKindLoop holds the let and const declarations of the head of a
+	// for, for-in or for-of statement, which are copied for each iteration.
+	// The body is a scope inside it.
+	ScopeKindLoop
+	// ScopeKindClass spans a class, holding the name of a class expression.
+	ScopeKindClass
 )
 
+func (k ScopeKind) String() string {
+	switch k {
+	case ScopeKindBlock:
+		return "block"
+	case ScopeKindFunction:
+		return "function"
+	case ScopeKindProgram:
+		return "program"
+	case ScopeKindCatch:
+		return "catch"
+	case ScopeKindLoop:
+		return "loop"
+	case ScopeKindClass:
+		return "class"
+	}
+	return "unknown"
+}
+
+// Scope is a scope of a resolved tree, found in the SymbolTable returned by
+// Resolve.
 type Scope struct {
-	parent *Scope
+	table    *SymbolTable
+	parent   *Scope
+	children []*Scope
 
 	kind ScopeKind
 
 	ctx ast.ScopeContext
 
-	declaredSymbols map[string]DeclKind
+	// node is the node opening the scope.
+	node ast.VisitableNode
+
+	// symbols holds the symbols of the scope in the order they are
+	// declared.
+	symbols []*Symbol
+	byName  map[string]*Symbol
+
+	usesEval      bool
+	usesWith      bool
+	usesArguments bool
+	strict        bool
+	dynamic       bool
+
+	// with is the scope of the innermost with statement whose body s is
+	// in, if any.
+	with *Scope
 }
 
-func (s *Scope) isDeclared(id string) (DeclKind, bool) {
+// Parent returns the scope enclosing s, or nil for the root scope.
+func (s *Scope) Parent() *Scope { return s.parent }
+
+// Children returns the scopes directly nested in s, in source order.
+func (s *Scope) Children() []*Scope { return s.children }
+
+// Kind returns the kind of s.
+func (s *Scope) Kind() ScopeKind { return s.kind }
+
+// Context returns the scope context stamped onto the identifiers declared
+// in s.
+func (s *Scope) Context() ast.ScopeContext { return s.ctx }
+
+// Node returns the node opening s, such as a Program, FunctionLiteral,
+// BlockStatement, CatchStatement, ForStatement, SwitchStatement or
+// ClassLiteral.
+func (s *Scope) Node() ast.VisitableNode { return s.node }
+
+// Symbols returns the symbols declared in s, in the order they are
+// declared. Declarations are hoisted, so those of a scope come before the
+// globals and the symbols of its children.
+func (s *Scope) Symbols() []*Symbol { return s.symbols }
+
+// Lookup returns the symbol name refers to in s, searching the enclosing
+// scopes, or nil if there is none.
+func (s *Scope) Lookup(name string) *Symbol {
 	for scope := s; scope != nil; scope = scope.parent {
-		if declKind, exists := scope.declaredSymbols[id]; exists {
-			return declKind, true
+		if sym, ok := scope.byName[name]; ok {
+			return sym
+		}
+	}
+	return nil
+}
+
+// UsesEval reports whether s directly holds a call to eval, which may
+// declare or reach any name in scope.
+func (s *Scope) UsesEval() bool { return s.usesEval }
+
+// UsesWith reports whether s directly holds a with statement, whose body
+// may read any name as a property of its object.
+func (s *Scope) UsesWith() bool { return s.usesWith }
+
+// Dynamic reports whether code may reach the bindings of s by name at run
+// time, as s or a scope nested in it calls eval directly or holds a with
+// statement. Renaming them is unsafe.
+func (s *Scope) Dynamic() bool { return s.dynamic }
+
+// Strict reports whether the code of s is strict mode code: it is in a
+// class or follows a "use strict" directive of its function or program.
+func (s *Scope) Strict() bool { return s.strict }
+
+// UsesArguments reports whether s is the scope of a function, not an arrow
+// function, using its arguments object, directly or through its arrow
+// functions and direct calls to eval.
+func (s *Scope) UsesArguments() bool { return s.usesArguments }
+
+// AliasesArguments reports whether s is the scope of a sloppy mode function
+// with simple parameters that uses its arguments object. Its parameters are
+// then aliased to the elements of arguments, and assigning one changes the
+// other, so passes dropping or inlining the parameters must leave them be.
+func (s *Scope) AliasesArguments() bool {
+	f, ok := s.node.(*ast.FunctionLiteral)
+	if !ok || !s.usesArguments || s.strict || f.ParameterList.Rest != nil {
+		return false
+	}
+	for _, param := range f.ParameterList.List {
+		if _, ok := param.Target.Target.(*ast.Identifier); !ok || param.Initializer != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// arguments returns the scope of the function whose arguments object the
+// name arguments refers to in s, or nil if there is none.
+func (s *Scope) arguments() *Scope {
+	for ; s != nil; s = s.parent {
+		if s.kind != ScopeKindFunction {
+			continue
+		}
+		if _, ok := s.node.(*ast.ArrowFunctionLiteral); ok {
+			continue
+		}
+		if _, ok := s.node.(*ast.FunctionLiteral); ok {
+			return s
+		}
+		return nil
+	}
+	return nil
+}
+
+// within reports whether s is scope or nested in it.
+func within(s, scope *Scope) bool {
+	for ; s != nil; s = s.parent {
+		if s == scope {
+			return true
 		}
 	}
-	return 0, false
+	return false
+}
+
+// function returns the scope of the function or program s is in.
+func (s *Scope) function() *Scope {
+	for s.parent != nil && s.kind != ScopeKindFunction && s.kind != ScopeKindProgram {
+		s = s.parent
+	}
+	return s
 }
@@ -0,0 +1,145 @@
+package resolver
+
+import "github.com/t14raptor/go-fast/ast"
+
+// SymbolTable holds the scopes and symbols of a resolved tree.
+type SymbolTable struct {
+	root    *Scope
+	scopes  map[ast.ScopeContext]*Scope
+	symbols []*Symbol
+
+	// next is the scope context to give out next.
+	next ast.ScopeContext
+	// names holds every name a symbol has had and those given out by
+	// FreshIdent.
+	names map[string]struct{}
+}
+
+// Root returns the outermost scope, the one of the node passed to Resolve.
+func (t *SymbolTable) Root() *Scope { return t.root }
+
+// Scope returns the scope of the context ctx, or nil if there is none.
+func (t *SymbolTable) Scope(ctx ast.ScopeContext) *Scope { return t.scopes[ctx] }
+
+// Symbols returns all symbols, in the order they are declared.
+func (t *SymbolTable) Symbols() []*Symbol { return t.symbols }
+
+// Lookup returns the symbol of id, or nil if there is none.
+func (t *SymbolTable) Lookup(id ast.Id) *Symbol {
+	if s := t.scopes[id.ScopeContext]; s != nil {
+		return s.byName[id.Name]
+	}
+	return nil
+}
+
+// SymbolOf returns the symbol the resolved identifier n declares or refers
+// to, or nil if there is none.
+func (t *SymbolTable) SymbolOf(n *ast.Identifier) *Symbol {
+	return t.Lookup(n.ToId())
+}
+
+// Symbol is a name declared in a scope, with the identifiers declaring it
+// and those referring to it.
+type Symbol struct {
+	Name string
+	Kind DeclKind
+	// Scope is the scope declaring the symbol.
+	Scope *Scope
+
+	// Decls holds the identifiers declaring the symbol, in source order;
+	// var and function declarations may declare a name more than once. A
+	// global has none, and neither has a var sharing the name of the catch
+	// parameter it is declared in scope of, whose identifiers refer to the
+	// parameter.
+	//
+	// Decls holds identifiers only. To reach the node declaring one, such
+	// as a VariableDeclarator, FunctionLiteral, ClassLiteral or
+	// ParameterList, find the identifier with ast.Traverse and walk the
+	// Ancestors of its path.
+	Decls []*ast.Identifier
+	// References holds the identifiers referring to the symbol, in source
+	// order. Declarations are not references, even with an initializer.
+	References []Reference
+
+	// initialized is set once a lexical declaration or parameter has run,
+	// ending its temporal dead zone.
+	initialized bool
+	// ctx is the scope context of the identifiers of a symbol declared by
+	// Declare, which have their own.
+	ctx ast.ScopeContext
+}
+
+// Rename changes the name of s in its scope, which must not declare name
+// already. The identifiers of s are left alone; package rename renames
+// them too.
+func (s *Symbol) Rename(name string) {
+	Rename(map[*Symbol]string{s: name})
+}
+
+// Rename changes the names of several symbols at once, as Symbol.Rename
+// does, so that they may swap names.
+func Rename(names map[*Symbol]string) {
+	for sym := range names {
+		if sym.Scope.byName[sym.Name] == sym {
+			delete(sym.Scope.byName, sym.Name)
+		}
+	}
+	for sym, name := range names {
+		if other, ok := sym.Scope.byName[name]; ok && other != sym {
+			panic("resolver: " + name + " is already declared in the scope of " + sym.Name)
+		}
+		sym.Name = name
+		sym.Scope.byName[name] = sym
+		sym.Scope.table.names[name] = struct{}{}
+	}
+}
+
+// Id returns the identity shared by the identifiers of the symbol.
+func (s *Symbol) Id() ast.Id {
+	if s.ctx != UnresolvedMark {
+		return ast.Id{Name: s.Name, ScopeContext: s.ctx}
+	}
+	return ast.Id{Name: s.Name, ScopeContext: s.Scope.ctx}
+}
+
+// ReferenceKind tells whether a reference reads or writes its symbol.
+type ReferenceKind int
+
+const (
+	ReferenceRead ReferenceKind = iota
+	ReferenceWrite
+	// ReferenceReadWrite is the target of an update or compound
+	// assignment, as in x++ or x += 1.
+	ReferenceReadWrite
+)
+
+// IsRead reports whether the reference reads its symbol.
+func (k ReferenceKind) IsRead() bool { return k != ReferenceWrite }
+
+// IsWrite reports whether the reference writes its symbol.
+func (k ReferenceKind) IsWrite() bool { return k != ReferenceRead }
+
+// Reference is a use of a symbol.
+type Reference struct {
+	Ident *ast.Identifier
+	Kind  ReferenceKind
+	// Scope is the scope the reference is found in.
+	Scope *Scope
+	// TDZ is set when the reference is to a let, const or class binding
+	// and runs before its declaration in the same function, or to a
+	// parameter from the default value of an earlier one, in the temporal
+	// dead zone, where using it throws. References from nested
+	// functions are not flagged, as they may run later.
+	TDZ bool
+	// Dynamic is set when the reference may not be to the symbol at run
+	// time: in the body of a with statement, it may read a property of the
+	// object instead, and under a direct call to eval, a var declared by
+	// the evaluated code. Its identifier is stamped with DynamicMark.
+	Dynamic bool
+}
+
+// Span returns the span of the referring identifier: the index of its first
+// character and of the one after it.
+func (r Reference) Span() (start, end ast.Idx) {
+	return r.Ident.Idx0(), r.Ident.Idx1()
+}
@@ -0,0 +1,298 @@
+package resolver_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/t14raptor/go-fast/ast"
+	"github.com/t14raptor/go-fast/parser"
+	"github.com/t14raptor/go-fast/resolver"
+)
+
+func resolve(t *testing.T, src string) (*ast.Program, *resolver.SymbolTable) {
+	t.Helper()
+	program, err := parser.ParseFile(src)
+	if err != nil {
+		t.Fatalf("ParseFile(%q): %v", src, err)
+	}
+	return program, resolver.Resolve(program)
+}
+
+// describe formats a symbol as its id, number of declarations and the
+// kinds of its references, as in x#1 d1 rwR.
+func describe(sym *resolver.Symbol) string {
+	var refs strings.Builder
+	for _, ref := range sym.References {
+		switch ref.Kind {
+		case resolver.ReferenceRead:
+			refs.WriteByte('r')
+		case resolver.ReferenceWrite:
+			refs.WriteByte('w')
+		case resolver.ReferenceReadWrite:
+			refs.WriteByte('R')
+		}
+	}
+	return fmt.Sprintf("%s#%d d%d %s", sym.Name, sym.Scope.Context(), len(sym.Decls), refs.String())
+}
+
+func TestSymbols(t *testing.T) {
+	tests := []struct {
+		src  string
+		want []string
+	}{
+		{"var a = 1; a; a = 2; a += 3; a++;", []string{"a#1 d1 rwRR"}},
+		{"var a, b; [a, {k: b, c}] = o; ({a = d} = o);", []string{"a#1 d1 ww", "b#1 d1 w", "c#1 d0 w", "o#1 d0 rr", "d#1 d0 r"}},
+		{"var a, o; for (a in o); for (a of o);", []string{"a#1 d1 ww", "o#1 d1 rr"}},
+		{"x.y = 1; x[y] = 2;", []string{"x#1 d0 rr", "y#1 d0 r"}},
+		{"function f(a) { return a + g(); } function g() {} f(1);", []string{"f#1 d1 r", "g#1 d1 r", "a#2 d1 r"}},
+		{"var a; var a; a;", []string{"a#1 d2 r"}},
+	}
+	for _, tt := range tests {
+		_, table := resolve(t, tt.src)
+		var got []string
+		for _, sym := range table.Symbols() {
+			got = append(got, describe(sym))
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s\ngot  %q\nwant %q", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestSymbolOf(t *testing.T) {
+	src := "let x = 1; function f() { return x; }"
+	program, table := resolve(t, src)
+	decl := program.Body[0].Stmt.(*ast.VariableDeclaration).List[0].Target.Target.(*ast.Identifier)
+	sym := table.SymbolOf(decl)
+	if sym == nil || sym.Decls[0] != decl {
+		t.Fatalf("SymbolOf(x) = %v", sym)
+	}
+	if table.Lookup(sym.Id()) != sym {
+		t.Errorf("Lookup(%v) is not the symbol", sym.Id())
+	}
+	if len(sym.References) != 1 {
+		t.Fatalf("x has %d references, want 1", len(sym.References))
+	}
+	ref := sym.References[0]
+	if start, end := ref.Span(); src[start:end] != "x" || int(start) != strings.LastIndex(src, "x") {
+		t.Errorf("reference span = %d-%d", start, end)
+	}
+	if ref.Scope == sym.Scope || ref.Scope.Kind() != resolver.ScopeKindFunction || ref.Scope.Parent() != sym.Scope {
+		t.Errorf("reference scope = %v, want the scope of f", ref.Scope)
+	}
+}
+
+func TestScopes(t *testing.T) {
+	program, table := resolve(t, "function f() { { let a; } eval(s); } with (o) { g(); } (() => 1);")
+	root := table.Root()
+	if root.Node() != program || root.Parent() != nil || table.Scope(resolver.TopLevelMark) != root {
+		t.Fatalf("root scope is not the scope of the program")
+	}
+	if !root.UsesWith() || root.UsesEval() {
+		t.Errorf("root UsesWith = %v, UsesEval = %v", root.UsesWith(), root.UsesEval())
+	}
+	children := root.Children()
+	if len(children) != 3 {
+		t.Fatalf("root has %d children, want 3", len(children))
+	}
+	f := children[0]
+	if _, ok := f.Node().(*ast.FunctionLiteral); !ok || f.Kind() != resolver.ScopeKindFunction {
+		t.Errorf("first child is a %v scope of %T", f.Kind(), f.Node())
+	}
+	if !f.UsesEval() {
+		t.Errorf("scope of f does not use eval")
+	}
+	if len(f.Children()) != 1 || f.Children()[0].Lookup("a") == nil || f.Lookup("a") != nil {
+		t.Errorf("a is not declared in the block of f only")
+	}
+	if f.Lookup("f") != root.Lookup("f") || root.Lookup("f") == nil {
+		t.Errorf("f is not found from its own scope")
+	}
+	if _, ok := children[2].Node().(*ast.ArrowFunctionLiteral); !ok {
+		t.Errorf("last child is the scope of a %T", children[2].Node())
+	}
+
+	_, table = resolve(t, "function eval() {} eval(s);")
+	if table.Root().UsesEval() {
+		t.Errorf("a call to a declared eval is flagged as eval")
+	}
+}
+
+func TestDeclKinds(t *testing.T) {
+	tests := []struct {
+		src  string
+		want []string
+	}{
+		{"{ let a; } a;", []string{"a#2 let d1 ", "a#1 global d0 r"}},
+		{"switch (x) { case 1: const a = 1; } a;", []string{"x#1 global d0 r", "a#2 const d1 ", "a#1 global d0 r"}},
+		{"for (let i of i) { let i; } i;", []string{"i#2 let d1 r", "i#3 let d1 ", "i#1 global d0 r"}},
+		{"try {} catch (e) { var e = 1; } e;", []string{"e#1 var d0 r", "e#3 catch d2 "}},
+		{"if (x) { function f() {} } f();", []string{"f#1 function d1 r", "x#1 global d0 r"}},
+		{"let f; { function f() {} }", []string{"f#1 let d1 ", "f#2 function d1 "}},
+		{"'use strict'; { function f() {} } f();", []string{"f#2 function d1 ", "f#1 global d0 r"}},
+		{"class C { m() { { function f() {} } f(); } }", []string{"C#1 class d1 ", "f#4 function d1 ", "f#1 global d0 r"}},
+		{"class A {} (class B { m() { B; } }); B;", []string{"A#1 class d1 ", "B#3 class d1 r", "B#1 global d0 r"}},
+		{"function g(a, b = a) { var a; }", []string{"g#1 function d1 ", "a#2 param d2 r", "b#2 param d1 "}},
+		{"function f(a = () => b, b) { return a() }", []string{"f#1 function d1 ", "a#2 param d1 r", "b#2 param d1 r"}},
+		{"var [a = b] = c; let {k: v, [d]: w} = o;", []string{"a#1 var d1 ", "v#1 let d1 ", "w#1 let d1 ", "b#1 global d0 r", "c#1 global d0 r", "d#1 global d0 r", "o#1 global d0 r"}},
+		{"l: for (;;) break l; class C { #p; m() { this.#p; } }", []string{"C#1 class d1 "}},
+	}
+	for _, tt := range tests {
+		_, table := resolve(t, tt.src)
+		var got []string
+		for _, sym := range table.Symbols() {
+			name, rest, _ := strings.Cut(describe(sym), " ")
+			got = append(got, fmt.Sprintf("%s %v %s", name, sym.Kind, rest))
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s\ngot  %q\nwant %q", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestTDZ(t *testing.T) {
+	tests := []struct {
+		src  string
+		want []bool
+		// sym is the index of the symbol whose references are checked.
+		sym int
+	}{
+		{"a; let a = a; a; function f() { a; }", []bool{true, true, false, false}, 0},
+		{"class A extends A { static x = A; }", []bool{true, false}, 0},
+		{"for (const x of x);", []bool{true}, 0},
+		{"var a; a; var a = a;", []bool{false, false}, 0},
+		{"(a = b, b = a, c = () => c) => b;", []bool{true, false}, 1},
+		{"(a = a, {b = a, [b]: c}) => c;", []bool{true, false}, 0},
+		{"(a = a, {b = a, [b]: c}) => c;", []bool{false}, 1},
+	}
+	for _, tt := range tests {
+		_, table := resolve(t, tt.src)
+		var got []bool
+		for _, ref := range table.Symbols()[tt.sym].References {
+			got = append(got, ref.TDZ)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: TDZ = %v, want %v", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestDynamic(t *testing.T) {
+	tests := []struct {
+		src  string
+		want []bool
+	}{
+		{"var a; with (o) { a; let b; b; } a;", []bool{true, false, false}},
+		{"var a; function f() { a; { let a; a; } eval(s); } a;", []bool{true, false, false}},
+		{"var a; function f() { 'use strict'; a; eval(s); }", []bool{false}},
+		{"var a; function f() { () => eval(s); a; }", []bool{false}},
+	}
+	for _, tt := range tests {
+		_, table := resolve(t, tt.src)
+		var got []bool
+		for _, sym := range table.Symbols() {
+			if sym.Name != "a" && sym.Name != "b" {
+				continue
+			}
+			for _, ref := range sym.References {
+				got = append(got, ref.Dynamic)
+				if ref.Dynamic != (ref.Ident.ScopeContext == resolver.DynamicMark) {
+					t.Errorf("%s: dynamic reference %s stamped %d", tt.src, ref.Ident.Name, ref.Ident.ScopeContext)
+				}
+			}
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: Dynamic = %v, want %v", tt.src, got, tt.want)
+		}
+	}
+
+	_, table := resolve(t, "function f() { eval(s); } function g() {}")
+	f, g := table.Root().Children()[0], table.Root().Children()[1]
+	if !table.Root().Dynamic() || !f.Dynamic() || g.Dynamic() {
+		t.Errorf("Dynamic = %v, %v, %v, want true, true, false", table.Root().Dynamic(), f.Dynamic(), g.Dynamic())
+	}
+
+	// A root other than a program is flagged too.
+	program, err := parser.ParseFile("function f() { with (o) a; }")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn := program.Body[0].Stmt
+	table = resolver.Resolve(fn)
+	root := table.Root()
+	if root.Node() != fn || !root.Dynamic() || len(root.Children()) != 1 || !root.Children()[0].Dynamic() || root.Lookup("f") == nil {
+		t.Errorf("function resolved alone: Dynamic = %v, f declared = %v", root.Dynamic(), root.Lookup("f") != nil)
+	}
+}
+
+func TestArguments(t *testing.T) {
+	tests := []struct {
+		src           string
+		uses, aliases bool
+	}{
+		{"function f(a) { return arguments[0]; }", true, true},
+		{"function f(a) { return () => arguments; }", true, true},
+		{"function f(a) { eval(s); }", true, true},
+		{"function f(a) { 'use strict'; arguments; }", true, false},
+		{"function f(a = 1) { arguments; }", true, false},
+		{"function f(...a) { arguments; }", true, false},
+		{"function f(a) { let arguments; arguments; }", false, false},
+		{"function f(a) { function g() { arguments; } }", false, false},
+	}
+	for _, tt := range tests {
+		_, table := resolve(t, tt.src)
+		f := table.Root().Children()[0]
+		if f.UsesArguments() != tt.uses || f.AliasesArguments() != tt.aliases {
+			t.Errorf("%s: UsesArguments = %v, AliasesArguments = %v, want %v, %v",
+				tt.src, f.UsesArguments(), f.AliasesArguments(), tt.uses, tt.aliases)
+		}
+	}
+
+	_, table := resolve(t, "'use strict'; function f() {} class C { m() {} }")
+	for _, s := range table.Root().Children() {
+		if !s.Strict() {
+			t.Errorf("%v scope of %T is not strict", s.Kind(), s.Node())
+		}
+	}
+}
+
+// cancelAfter is a context cancelled once its error has been checked n
+// times.
+type cancelAfter struct {
+	context.Context
+	n, checks int
+}
+
+func (c *cancelAfter) Err() error {
+	if c.checks++; c.checks > c.n {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestResolveContext(t *testing.T) {
+	program, err := parser.ParseFile("var a;" + strings.Repeat("a;", 10000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := &cancelAfter{Context: context.Background(), n: 3}
+	table, err := resolver.ResolveContext(ctx, program)
+	if err != context.Canceled || table != nil {
+		t.Fatalf("ResolveContext = %v, %v; want nil, context.Canceled", table, err)
+	}
+	if ctx.checks != ctx.n+1 {
+		t.Errorf("context checked %d times; want %d", ctx.checks, ctx.n+1)
+	}
+	first := program.Body[1].Stmt.(*ast.ExpressionStatement).Expression.Expr.(*ast.Identifier)
+	last := program.Body[len(program.Body)-1].Stmt.(*ast.ExpressionStatement).Expression.Expr.(*ast.Identifier)
+	if first.ScopeContext == 0 || last.ScopeContext != 0 {
+		t.Errorf("scope contexts of the first and last references = %d, %d; want the first resolved only", first.ScopeContext, last.ScopeContext)
+	}
+
+	if _, err := resolver.ResolveContext(context.Background(), program); err != nil {
+		t.Errorf("ResolveContext without cancellation: %v", err)
+	}
+}